@@ -0,0 +1,222 @@
+package dt
+
+import (
+	"fmt"
+	"time"
+)
+
+// HolidaySet is implemented by types that know which dates are holidays.
+// The holidays in a HolidaySet are not business days.
+type HolidaySet interface {
+	IsHoliday(d LocalDate) bool
+}
+
+// HolidaySetFunc is an adapter to allow the use of an ordinary function
+// as a HolidaySet.
+type HolidaySetFunc func(d LocalDate) bool
+
+// IsHoliday returns f(d).
+func (f HolidaySetFunc) IsHoliday(d LocalDate) bool {
+	return f(d)
+}
+
+// holidayDates is a HolidaySet containing a fixed list of dates.
+type holidayDates map[int64]struct{}
+
+// NewHolidaySet returns a HolidaySet containing the dates specified.
+func NewHolidaySet(dates ...LocalDate) HolidaySet {
+	set := make(holidayDates, len(dates))
+	for _, d := range dates {
+		set[dayNumber(d)] = struct{}{}
+	}
+	return set
+}
+
+func (set holidayDates) IsHoliday(d LocalDate) bool {
+	_, ok := set[dayNumber(d)]
+	return ok
+}
+
+// holidayUnion is a HolidaySet containing the holidays of all of its members.
+type holidayUnion []HolidaySet
+
+// UnionHolidays returns a HolidaySet containing the holidays in any of
+// the sets. For example, the union of the holidays for two cities gives
+// the days on which a business day is not observed in both cities.
+func UnionHolidays(sets ...HolidaySet) HolidaySet {
+	var union holidayUnion
+	for _, set := range sets {
+		switch s := set.(type) {
+		case nil:
+			continue
+		case holidayUnion:
+			union = append(union, s...)
+		default:
+			union = append(union, s)
+		}
+	}
+	return union
+}
+
+func (union holidayUnion) IsHoliday(d LocalDate) bool {
+	for _, set := range union {
+		if set.IsHoliday(d) {
+			return true
+		}
+	}
+	return false
+}
+
+// dayNumber returns the number of days since January 1, 1970 for d.
+func dayNumber(d LocalDate) int64 {
+	return d.days - unixEpochDays
+}
+
+// floorDiv returns a/b rounded towards negative infinity.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// maxBusinessDaySearch is the maximum number of days that will be searched
+// for a business day, to avoid looping forever for a calendar without any
+// business days.
+const maxBusinessDaySearch = 3660
+
+// BusinessCalendar determines which dates are business days. A date is
+// a business day unless it falls on a weekend, or it is a holiday.
+//
+// The zero value of BusinessCalendar has a Saturday and Sunday weekend and
+// no holidays. BusinessCalendar values are immutable and safe for concurrent
+// use, provided that their holiday sets are.
+type BusinessCalendar struct {
+	// workdays is a bit mask of the days of the week that are not
+	// weekend days, indexed by time.Weekday. Zero means Monday to Friday,
+	// which is never ambiguous because a calendar must have at least
+	// one business day in the week.
+	workdays uint8
+	holidays HolidaySet
+}
+
+const mondayToFriday = 1<<time.Monday | 1<<time.Tuesday | 1<<time.Wednesday | 1<<time.Thursday | 1<<time.Friday
+
+// NewBusinessCalendar returns a business calendar with a Saturday and Sunday
+// weekend and the holidays specified. If more than one holiday set is specified,
+// the calendar uses their union.
+func NewBusinessCalendar(holidays ...HolidaySet) BusinessCalendar {
+	var c BusinessCalendar
+	return c.WithHolidays(holidays...)
+}
+
+// WithWeekend returns a copy of c with the weekend days specified, replacing
+// the weekend days of c. For example, WithWeekend(time.Friday, time.Saturday)
+// is the weekend for many countries in the Middle East. It panics if every
+// day of the week is a weekend day.
+func (c BusinessCalendar) WithWeekend(days ...time.Weekday) BusinessCalendar {
+	var workdays uint8 = 1<<7 - 1
+	for _, day := range days {
+		workdays &^= 1 << day
+	}
+	if workdays == 0 {
+		panic("dt: business calendar has no business days")
+	}
+	c.workdays = workdays
+	return c
+}
+
+// WithHolidays returns a copy of c with additional holidays. The holidays
+// of the resulting calendar are the union of the holidays of c and the
+// holiday sets specified.
+func (c BusinessCalendar) WithHolidays(holidays ...HolidaySet) BusinessCalendar {
+	sets := append([]HolidaySet{c.holidays}, holidays...)
+	c.holidays = UnionHolidays(sets...)
+	return c
+}
+
+// IsWeekend reports whether d falls on a weekend day.
+func (c BusinessCalendar) IsWeekend(d LocalDate) bool {
+	workdays := c.workdays
+	if workdays == 0 {
+		workdays = mondayToFriday
+	}
+	return workdays&(1<<d.Weekday()) == 0
+}
+
+// IsHoliday reports whether d is a holiday.
+func (c BusinessCalendar) IsHoliday(d LocalDate) bool {
+	return c.holidays != nil && c.holidays.IsHoliday(d)
+}
+
+// IsBusinessDay reports whether d is a business day, which is a day that
+// is neither on a weekend nor a holiday.
+func (c BusinessCalendar) IsBusinessDay(d LocalDate) bool {
+	return !c.IsWeekend(d) && !c.IsHoliday(d)
+}
+
+// NextBusinessDay returns the first business day after d. It panics if
+// there is no business day within 3660 days after d, which happens when
+// the holidays of c include every date.
+func (c BusinessCalendar) NextBusinessDay(d LocalDate) LocalDate {
+	return c.step(d, 1)
+}
+
+// PreviousBusinessDay returns the last business day before d. It panics
+// if there is no business day within 3660 days before d.
+func (c BusinessCalendar) PreviousBusinessDay(d LocalDate) LocalDate {
+	return c.step(d, -1)
+}
+
+// step returns the first business day after d in the direction specified.
+// It panics if no business day is found within maxBusinessDaySearch days.
+func (c BusinessCalendar) step(d LocalDate, direction int) LocalDate {
+	for i := 0; i < maxBusinessDaySearch; i++ {
+		d = d.AddDate(0, 0, direction)
+		if c.IsBusinessDay(d) {
+			return d
+		}
+	}
+	panic(fmt.Sprintf("dt: no business day within %d days of %s", maxBusinessDaySearch, d))
+}
+
+// AddBusinessDays returns the date that is n business days after d. If n is
+// negative, the result is n business days before d. If n is zero, the result
+// is d, even if d is not a business day.
+//
+// For example, adding one business day to a Friday gives the following
+// Monday, if Monday is not a holiday.
+//
+// AddBusinessDays panics if n is not zero and there is a run of more than
+// 3660 consecutive days without a business day, as for NextBusinessDay.
+func (c BusinessCalendar) AddBusinessDays(d LocalDate, n int) LocalDate {
+	direction := 1
+	if n < 0 {
+		direction, n = -1, -n
+	}
+	for ; n > 0; n-- {
+		d = c.step(d, direction)
+	}
+	return d
+}
+
+// BusinessDaysBetween returns the number of business days from a up to, but
+// not including, b. If b is before a the result is negative, and is the
+// number of business days from b up to, but not including, a.
+//
+// BusinessDaysBetween is the inverse of AddBusinessDays, in that
+// c.BusinessDaysBetween(d, c.AddBusinessDays(d, n)) is n whenever d is a
+// business day.
+func (c BusinessCalendar) BusinessDaysBetween(a, b LocalDate) int {
+	if b.Before(a) {
+		return -c.BusinessDaysBetween(b, a)
+	}
+	var n int
+	for d := a; d.Before(b); d = d.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(d) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,120 @@
+package dt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusinessCalendar(t *testing.T) {
+	assert := assert.New(t)
+
+	// Christmas and Boxing Day 2024 fall on Wednesday and Thursday
+	christmas := NewHolidaySet(MustParseDate("2024-12-25"), MustParseDate("2024-12-26"))
+	newYear := HolidaySetFunc(func(d LocalDate) bool {
+		return d.Month() == time.January && d.Day() == 1
+	})
+	c := NewBusinessCalendar(christmas, newYear)
+
+	assert.True(c.IsBusinessDay(MustParseDate("2024-12-24")))
+	assert.False(c.IsBusinessDay(MustParseDate("2024-12-25")))
+	assert.False(c.IsBusinessDay(MustParseDate("2024-12-28")))
+	assert.True(c.IsWeekend(MustParseDate("2024-12-28")))
+	assert.False(c.IsHoliday(MustParseDate("2024-12-28")))
+	assert.True(c.IsHoliday(MustParseDate("2025-01-01")))
+
+	assert.Equal("2024-12-27", c.NextBusinessDay(MustParseDate("2024-12-24")).String())
+	assert.Equal("2024-12-30", c.NextBusinessDay(MustParseDate("2024-12-27")).String())
+	assert.Equal("2025-01-02", c.NextBusinessDay(MustParseDate("2024-12-31")).String())
+	assert.Equal("2024-12-24", c.PreviousBusinessDay(MustParseDate("2024-12-27")).String())
+	assert.Equal("2024-12-27", c.PreviousBusinessDay(MustParseDate("2024-12-29")).String())
+
+	testCases := []struct {
+		Date     string
+		Days     int
+		Expected string
+	}{
+		{"2024-12-20", 0, "2024-12-20"},
+		{"2024-12-21", 0, "2024-12-21"},
+		{"2024-12-20", 1, "2024-12-23"},
+		{"2024-12-20", 3, "2024-12-27"},
+		{"2024-12-20", 5, "2024-12-31"},
+		{"2024-12-20", 6, "2025-01-02"},
+		{"2024-12-21", 1, "2024-12-23"},
+		{"2025-01-02", -1, "2024-12-31"},
+		{"2025-01-02", -4, "2024-12-24"},
+		{"2024-12-28", -1, "2024-12-27"},
+	}
+	for _, tc := range testCases {
+		d := MustParseDate(tc.Date)
+		actual := c.AddBusinessDays(d, tc.Days)
+		assert.Equal(tc.Expected, actual.String(), "%s %+d", tc.Date, tc.Days)
+		if c.IsBusinessDay(d) {
+			assert.Equal(tc.Days, c.BusinessDaysBetween(d, actual), "%s %+d", tc.Date, tc.Days)
+		}
+	}
+
+	assert.Equal(0, c.BusinessDaysBetween(MustParseDate("2024-12-25"), MustParseDate("2024-12-25")))
+	assert.Equal(20, c.BusinessDaysBetween(MustParseDate("2024-12-01"), MustParseDate("2025-01-01")))
+	assert.Equal(-20, c.BusinessDaysBetween(MustParseDate("2025-01-01"), MustParseDate("2024-12-01")))
+}
+
+func TestBusinessCalendarWeekend(t *testing.T) {
+	assert := assert.New(t)
+
+	var zero BusinessCalendar
+	assert.True(zero.IsWeekend(MustParseDate("2024-12-28")))
+	assert.True(zero.IsWeekend(MustParseDate("2024-12-29")))
+	assert.False(zero.IsWeekend(MustParseDate("2024-12-27")))
+	assert.True(zero.IsBusinessDay(MustParseDate("2024-12-25")))
+
+	c := zero.WithWeekend(time.Friday, time.Saturday)
+	assert.True(c.IsWeekend(MustParseDate("2024-12-27")))
+	assert.True(c.IsWeekend(MustParseDate("2024-12-28")))
+	assert.False(c.IsWeekend(MustParseDate("2024-12-29")))
+	assert.Equal("2024-12-29", c.AddBusinessDays(MustParseDate("2024-12-26"), 1).String())
+
+	none := zero.WithWeekend()
+	assert.False(none.IsWeekend(MustParseDate("2024-12-28")))
+	assert.Equal(7, none.BusinessDaysBetween(MustParseDate("2024-12-23"), MustParseDate("2024-12-30")))
+
+	assert.Panics(func() {
+		zero.WithWeekend(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+	})
+
+	always := zero.WithHolidays(HolidaySetFunc(func(LocalDate) bool { return true }))
+	assert.Panics(func() {
+		always.NextBusinessDay(MustParseDate("2024-12-28"))
+	})
+	assert.Panics(func() {
+		always.PreviousBusinessDay(MustParseDate("2024-12-28"))
+	})
+	assert.Panics(func() {
+		always.AddBusinessDays(MustParseDate("2024-12-28"), -1)
+	})
+	assert.Equal("2024-12-28", always.AddBusinessDays(MustParseDate("2024-12-28"), 0).String())
+}
+
+func TestUnionHolidays(t *testing.T) {
+	assert := assert.New(t)
+	a := NewHolidaySet(MustParseDate("2024-01-26"))
+	b := NewHolidaySet(MustParseDate("2024-07-04"))
+	union := UnionHolidays(a, nil, UnionHolidays(b))
+
+	assert.True(union.IsHoliday(MustParseDate("2024-01-26")))
+	assert.True(union.IsHoliday(MustParseDate("2024-07-04")))
+	assert.False(union.IsHoliday(MustParseDate("2024-07-05")))
+	assert.False(UnionHolidays().IsHoliday(MustParseDate("2024-07-04")))
+
+	c := NewBusinessCalendar(a).WithHolidays(b)
+	assert.True(c.IsHoliday(MustParseDate("2024-01-26")))
+	assert.True(c.IsHoliday(MustParseDate("2024-07-04")))
+
+	// negative years and the epoch map to distinct days
+	old := NewHolidaySet(Date(-1, time.December, 31), Date(1970, time.January, 1))
+	assert.True(old.IsHoliday(Date(-1, time.December, 31)))
+	assert.False(old.IsHoliday(Date(0, time.January, 1)))
+	assert.True(old.IsHoliday(Date(1970, time.January, 1)))
+	assert.False(old.IsHoliday(Date(1969, time.December, 31)))
+}
@@ -0,0 +1,108 @@
+package dt
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time. Code that needs today's date can accept
+// a Clock, so that tests can control the date.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the clock that returns the current system time.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock returns a clock that always returns the time t.
+func FixedClock(t time.Time) Clock {
+	return fixedClock{t: t}
+}
+
+type fixedClock struct {
+	t time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.t
+}
+
+// FakeClock is a clock whose time only changes when it is set or advanced.
+// The zero value returns the zero time. A FakeClock is safe for concurrent use.
+type FakeClock struct {
+	mutex sync.Mutex
+	t     time.Time
+}
+
+// NewFakeClock returns a fake clock with the time t.
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{t: t}
+}
+
+// Now returns the time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.t
+}
+
+// Set sets the time of the clock.
+func (c *FakeClock) Set(t time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.t = t
+}
+
+// Advance adds d to the time of the clock.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.t = c.t.Add(d)
+}
+
+// TodayIn returns the current date in the location specified, which
+// may be different to the date in the local time zone.
+func TodayIn(loc *time.Location) LocalDate {
+	return TodayFrom(SystemClock, loc)
+}
+
+// NowIn returns the current date and time in the location specified.
+func NowIn(loc *time.Location) LocalDateTime {
+	return NowFrom(SystemClock, loc)
+}
+
+// TodayFrom returns the date of the clock's current time in the location
+// specified.
+func TodayFrom(c Clock, loc *time.Location) LocalDate {
+	return toLocalDate(c.Now().In(loc))
+}
+
+// NowFrom returns the date and time of the clock's current time in the
+// location specified.
+func NowFrom(c Clock, loc *time.Location) LocalDateTime {
+	return toLocalDateTime(c.Now().In(loc))
+}
+
+// clockKey is the context key for a clock.
+type clockKey struct{}
+
+// WithClock returns a copy of ctx that carries the clock.
+func WithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, c)
+}
+
+// ClockFromContext returns the clock carried by ctx, or SystemClock if ctx
+// does not carry a clock.
+func ClockFromContext(ctx context.Context) Clock {
+	if c, ok := ctx.Value(clockKey{}).(Clock); ok {
+		return c
+	}
+	return SystemClock
+}
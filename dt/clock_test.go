@@ -0,0 +1,75 @@
+package dt
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	now := SystemClock.Now()
+	assert.False(t, now.Before(before))
+	assert.Equal(t, Today(), TodayFrom(SystemClock, time.Local))
+}
+
+func TestFixedClock(t *testing.T) {
+	// 2026-10-17 23:30 UTC is the 18th in Sydney and the 17th in New York
+	instant := time.Date(2026, 10, 17, 23, 30, 0, 0, time.UTC)
+	c := FixedClock(instant)
+	assert.Equal(t, instant, c.Now())
+
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	assert.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	assert.Equal(t, Date(2026, 10, 17), TodayFrom(c, time.UTC))
+	assert.Equal(t, Date(2026, 10, 18), TodayFrom(c, sydney))
+	assert.Equal(t, Date(2026, 10, 17), TodayFrom(c, newYork))
+	assert.Equal(t, DateTime(2026, 10, 18, 10, 30, 0), NowFrom(c, sydney))
+	assert.Equal(t, DateTime(2026, 10, 17, 19, 30, 0), NowFrom(c, newYork))
+}
+
+func TestTodayIn(t *testing.T) {
+	loc := time.FixedZone("UTC+14", 14*3600)
+	want := toLocalDate(time.Now().In(loc))
+	assert.Equal(t, want, TodayIn(loc))
+	assert.Equal(t, want, NowIn(loc).LocalDate())
+}
+
+func TestFakeClock(t *testing.T) {
+	var zero FakeClock
+	assert.True(t, zero.Now().IsZero())
+
+	c := NewFakeClock(time.Date(2026, 10, 17, 23, 0, 0, 0, time.UTC))
+	assert.Equal(t, Date(2026, 10, 17), TodayFrom(c, time.UTC))
+	c.Advance(time.Hour)
+	assert.Equal(t, Date(2026, 10, 18), TodayFrom(c, time.UTC))
+	c.Set(time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, DateTime(2024, 2, 29, 12, 0, 0), NowFrom(c, time.UTC))
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Advance(time.Minute)
+			c.Now()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, DateTime(2024, 2, 29, 12, 10, 0), NowFrom(c, time.UTC))
+}
+
+func TestClockFromContext(t *testing.T) {
+	assert.Equal(t, SystemClock, ClockFromContext(context.Background()))
+
+	c := FixedClock(time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC))
+	ctx := WithClock(context.Background(), c)
+	assert.Equal(t, c, ClockFromContext(ctx))
+	assert.Equal(t, Date(2026, 10, 17), TodayFrom(ClockFromContext(ctx), time.UTC))
+}
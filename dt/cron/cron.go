@@ -0,0 +1,308 @@
+// Package cron parses cron expressions and calculates the times at which
+// they fire.
+//
+// Cron expressions are evaluated in local wall time, so "0 2 * * *" fires at
+// 02:00 local time every day, including the days on which daylight saving
+// starts or ends. The NextIn and PrevIn methods map these local date-times
+// to instants in a time zone, using a dt.Resolver to decide what happens when
+// the local time is skipped or repeated by a daylight saving transition.
+//
+// An expression has five fields (minute, hour, day of month, month and day
+// of week) or six fields, where the first field is the second. Each field
+// is "*", a value, a range such as "1-5", a step such as "*/15" or "10-30/5",
+// or a comma-separated list of these. Months and days of the week can be
+// written as names, such as "JAN" or "MON", and Sunday is either 0 or 7.
+// The following extensions are supported:
+//
+//	L     in day of month, the last day of the month
+//	L-3   in day of month, the third last day of the month
+//	15W   in day of month, the weekday nearest to the 15th
+//	LW    in day of month, the last weekday of the month
+//	5L    in day of week, the last Friday of the month
+//	5#3   in day of week, the third Friday of the month
+//	?     in day of month or day of week, the same as *
+//
+// As with most cron implementations, if both the day of month and the day of
+// week are restricted (neither starts with "*" or "?"), a day matches if
+// either field matches.
+//
+// The following macros can be used instead of an expression:
+//
+//	@yearly, @annually  0 0 1 1 *
+//	@monthly            0 0 1 * *
+//	@weekly             0 0 * * 0
+//	@daily, @midnight   0 0 * * *
+//	@hourly             0 * * * *
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// macros are the expressions that can be abbreviated.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field describes one field of a cron expression.
+type field struct {
+	name     string
+	min, max int
+	names    []string // names of values, starting at min
+}
+
+var (
+	secondField = field{name: "second", min: 0, max: 59}
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day-of-month", min: 1, max: 31}
+	monthField  = field{
+		name: "month", min: 1, max: 12,
+		names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"},
+	}
+	dowField = field{
+		name: "day-of-week", min: 0, max: 7,
+		names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"},
+	}
+)
+
+// nthWeekday is a day of week of the form "5#3".
+type nthWeekday struct {
+	weekday time.Weekday
+	n       int
+}
+
+// Schedule is a parsed cron expression. The zero value never fires.
+type Schedule struct {
+	spec string
+
+	// bit n is set if value n matches
+	second, minute, hour, dom, month, dow uint64
+
+	lastDays    []int // "L" is 0, "L-3" is 3
+	nearest     []int // days of the form "15W"
+	lastWeekday bool  // "LW"
+	lastDow     uint64
+	nthDow      []nthWeekday
+
+	// domStar and dowStar are true if the field starts with "*" or "?"
+	domStar, dowStar bool
+}
+
+// Parse parses a cron expression with five or six fields, or a macro
+// such as "@daily".
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	s := Schedule{spec: spec}
+	text := spec
+	if strings.HasPrefix(text, "@") {
+		var ok bool
+		if text, ok = macros[strings.ToLower(text)]; !ok {
+			return Schedule{}, fmt.Errorf("unknown cron macro %q", spec)
+		}
+	}
+	fields := strings.Fields(text)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return Schedule{}, fmt.Errorf("cron expression %q has %d fields, expected 5 or 6", spec, len(fields))
+	}
+
+	var err error
+	if s.second, err = s.parseField(secondField, fields[0]); err != nil {
+		return Schedule{}, err
+	}
+	if s.minute, err = s.parseField(minuteField, fields[1]); err != nil {
+		return Schedule{}, err
+	}
+	if s.hour, err = s.parseField(hourField, fields[2]); err != nil {
+		return Schedule{}, err
+	}
+	if s.dom, err = s.parseField(domField, fields[3]); err != nil {
+		return Schedule{}, err
+	}
+	if s.month, err = s.parseField(monthField, fields[4]); err != nil {
+		return Schedule{}, err
+	}
+	if s.dow, err = s.parseField(dowField, fields[5]); err != nil {
+		return Schedule{}, err
+	}
+
+	// Sunday can be 0 or 7
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domStar = strings.HasPrefix(fields[3], "*") || strings.HasPrefix(fields[3], "?")
+	s.dowStar = strings.HasPrefix(fields[5], "*") || strings.HasPrefix(fields[5], "?")
+	return s, nil
+}
+
+// MustParse is like Parse but panics if the expression cannot be parsed.
+func MustParse(spec string) Schedule {
+	s, err := Parse(spec)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// String returns the expression that was parsed.
+func (s Schedule) String() string {
+	return s.spec
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s Schedule) MarshalText() ([]byte, error) {
+	return []byte(s.spec), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *Schedule) UnmarshalText(data []byte) (err error) {
+	*s, err = Parse(string(data))
+	return err
+}
+
+// parseField parses a comma-separated list of items and returns the bits of
+// the values that match. Extensions for the day of month and day of week
+// are stored in s.
+func (s *Schedule) parseField(f field, text string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(strings.ToUpper(text), ",") {
+		if f.name == domField.name && s.parseDomExtension(item) {
+			continue
+		}
+		if f.name == dowField.name {
+			ok, err := s.parseDowExtension(item)
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				continue
+			}
+		}
+		b, err := f.parseItem(item)
+		if err != nil {
+			return 0, err
+		}
+		bits |= b
+	}
+	return bits, nil
+}
+
+// parseItem parses a value, range or step, and returns the bits of the
+// values that match.
+func (f field) parseItem(item string) (uint64, error) {
+	invalid := fmt.Errorf("invalid %s value %q", f.name, item)
+	rng, stepText, hasStep := strings.Cut(item, "/")
+	step := 1
+	if hasStep {
+		var err error
+		if step, err = strconv.Atoi(stepText); err != nil || step <= 0 || step > f.max-f.min {
+			return 0, invalid
+		}
+	}
+
+	var low, high int
+	if rng == "*" || (rng == "?" && (f.name == domField.name || f.name == dowField.name)) {
+		low, high = f.min, f.max
+		if f.name == dowField.name {
+			high = 6
+		}
+	} else {
+		lowText, highText, isRange := strings.Cut(rng, "-")
+		var ok bool
+		if low, ok = f.value(lowText); !ok {
+			return 0, invalid
+		}
+		switch {
+		case isRange:
+			if high, ok = f.value(highText); !ok || high < low {
+				return 0, invalid
+			}
+		case hasStep:
+			high = f.max
+		default:
+			high = low
+		}
+	}
+
+	var bits uint64
+	for v := low; v <= high; v += step {
+		bits |= 1 << v
+	}
+	return bits, nil
+}
+
+// value parses a number or a name.
+func (f field) value(text string) (int, bool) {
+	for i, name := range f.names {
+		if text == name {
+			return f.min + i, true
+		}
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil || n < f.min || n > f.max || text[0] == '+' || text[0] == '-' {
+		return 0, false
+	}
+	return n, true
+}
+
+// parseDomExtension parses an L or W item in the day of month field,
+// and reports whether it was one.
+func (s *Schedule) parseDomExtension(item string) bool {
+	switch {
+	case item == "L":
+		s.lastDays = append(s.lastDays, 0)
+	case item == "LW":
+		s.lastWeekday = true
+	case strings.HasPrefix(item, "L-"):
+		n, err := strconv.Atoi(item[2:])
+		if err != nil || n < 0 || n > 30 || item[2] == '+' {
+			return false
+		}
+		s.lastDays = append(s.lastDays, n)
+	case strings.HasSuffix(item, "W"):
+		n, ok := domField.value(item[:len(item)-1])
+		if !ok {
+			return false
+		}
+		s.nearest = append(s.nearest, n)
+	default:
+		return false
+	}
+	return true
+}
+
+// parseDowExtension parses an L or # item in the day of week field,
+// and reports whether it was one.
+func (s *Schedule) parseDowExtension(item string) (bool, error) {
+	if weekdayText, nText, ok := strings.Cut(item, "#"); ok {
+		wd, ok := dowField.value(weekdayText)
+		n, err := strconv.Atoi(nText)
+		if !ok || err != nil || n < 1 || n > 5 {
+			return false, fmt.Errorf("invalid %s value %q", dowField.name, item)
+		}
+		s.nthDow = append(s.nthDow, nthWeekday{weekday: time.Weekday(wd % 7), n: n})
+		return true, nil
+	}
+	if len(item) > 1 && strings.HasSuffix(item, "L") {
+		wd, ok := dowField.value(item[:len(item)-1])
+		if !ok {
+			return false, fmt.Errorf("invalid %s value %q", dowField.name, item)
+		}
+		s.lastDow |= 1 << (wd % 7)
+		return true, nil
+	}
+	return false, nil
+}
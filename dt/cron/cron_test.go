@@ -0,0 +1,311 @@
+package cron
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		Spec  string
+		Error string
+	}{
+		{Spec: "* * * * *"},
+		{Spec: "*/15 9-17 * * MON-FRI"},
+		{Spec: "0 30 2 * * *"},
+		{Spec: "0 0 L * ?"},
+		{Spec: "0 0 L-3,15W,LW * ?"},
+		{Spec: "0 0 ? * 5L,MON#2"},
+		{Spec: "0 0 1 jan,jul sun"},
+		{Spec: "5/20 * * * *"},
+		{Spec: "@daily"},
+		{Spec: "@Weekly"},
+		{Spec: "", Error: `cron expression "" has 0 fields, expected 5 or 6`},
+		{Spec: "* * * *", Error: `cron expression "* * * *" has 4 fields, expected 5 or 6`},
+		{Spec: "* * * * * * *", Error: `cron expression "* * * * * * *" has 7 fields, expected 5 or 6`},
+		{Spec: "@every 5m", Error: `unknown cron macro "@every 5m"`},
+		{Spec: "60 * * * *", Error: `invalid minute value "60"`},
+		{Spec: "* 24 * * *", Error: `invalid hour value "24"`},
+		{Spec: "* * 0 * *", Error: `invalid day-of-month value "0"`},
+		{Spec: "* * 32 * *", Error: `invalid day-of-month value "32"`},
+		{Spec: "* * * 13 *", Error: `invalid month value "13"`},
+		{Spec: "* * * * 8", Error: `invalid day-of-week value "8"`},
+		{Spec: "* * * * MON#6", Error: `invalid day-of-week value "MON#6"`},
+		{Spec: "* * * * XL", Error: `invalid day-of-week value "XL"`},
+		{Spec: "* * 32W * *", Error: `invalid day-of-month value "32W"`},
+		{Spec: "* * L-31 * *", Error: `invalid day-of-month value "L-31"`},
+		{Spec: "5-1 * * * *", Error: `invalid minute value "5-1"`},
+		{Spec: "*/0 * * * *", Error: `invalid minute value "*/0"`},
+		{Spec: "5/9223372036854775807 * * * *", Error: `invalid minute value "5/9223372036854775807"`},
+		{Spec: "*/60 * * * *", Error: `invalid minute value "*/60"`},
+		{Spec: "? * * * *", Error: `invalid minute value "?"`},
+		{Spec: "1,,2 * * * *", Error: `invalid minute value ""`},
+		{Spec: "-1 * * * *", Error: `invalid minute value "-1"`},
+		{Spec: "* * * FOO *", Error: `invalid month value "FOO"`},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		s, err := Parse(tc.Spec)
+		if tc.Error == "" {
+			if assert.NoError(err, tc.Spec) {
+				assert.Equal(tc.Spec, s.String())
+			}
+		} else if assert.Error(err, tc.Spec) {
+			assert.Equal(tc.Error, err.Error())
+		}
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("@hourly", MustParse("@hourly").String())
+	assert.Panics(func() { MustParse("@never") })
+}
+
+func TestNext(t *testing.T) {
+	testCases := []struct {
+		Spec     string
+		After    string
+		Expected []string
+	}{
+		{
+			Spec:     "*/15 9-17 * * MON-FRI",
+			After:    "2024-01-05T17:40:00", // Friday
+			Expected: []string{"2024-01-05T17:45:00", "2024-01-08T09:00:00", "2024-01-08T09:15:00"},
+		},
+		{
+			Spec:     "30 10 * * * *",
+			After:    "2024-01-01T00:00:00",
+			Expected: []string{"2024-01-01T00:10:30", "2024-01-01T01:10:30"},
+		},
+		{
+			Spec:     "0 2 * * *",
+			After:    "2024-01-01T02:00:00",
+			Expected: []string{"2024-01-02T02:00:00", "2024-01-03T02:00:00"},
+		},
+		{
+			Spec:     "@yearly",
+			After:    "2024-06-15T12:00:00",
+			Expected: []string{"2025-01-01T00:00:00", "2026-01-01T00:00:00"},
+		},
+		{
+			Spec:     "@weekly",
+			After:    "2024-01-01T00:00:00",
+			Expected: []string{"2024-01-07T00:00:00", "2024-01-14T00:00:00"},
+		},
+		{
+			Spec:     "0 0 * * 7",
+			After:    "2024-01-01T00:00:00",
+			Expected: []string{"2024-01-07T00:00:00"},
+		},
+		{
+			Spec:     "0 0 L * *",
+			After:    "2024-01-31T00:00:00",
+			Expected: []string{"2024-02-29T00:00:00", "2024-03-31T00:00:00", "2024-04-30T00:00:00"},
+		},
+		{
+			Spec:     "0 0 L-2 * *",
+			After:    "2023-02-01T00:00:00",
+			Expected: []string{"2023-02-26T00:00:00", "2023-03-29T00:00:00"},
+		},
+		{
+			// the 15th of June 2024 is a Saturday and of September 2024 is a Sunday
+			Spec:     "0 9 15W * *",
+			After:    "2024-06-01T00:00:00",
+			Expected: []string{"2024-06-14T09:00:00", "2024-07-15T09:00:00", "2024-08-15T09:00:00", "2024-09-16T09:00:00"},
+		},
+		{
+			// the 1st of June 2024 is a Saturday, and nearest weekday stays in the month
+			Spec:     "0 9 1W * *",
+			After:    "2024-05-01T09:00:00",
+			Expected: []string{"2024-06-03T09:00:00"},
+		},
+		{
+			// the last day of June 2024 is a Sunday, and of August 2024 is a Saturday
+			Spec:     "0 18 LW * *",
+			After:    "2024-06-01T00:00:00",
+			Expected: []string{"2024-06-28T18:00:00", "2024-07-31T18:00:00", "2024-08-30T18:00:00"},
+		},
+		{
+			Spec:     "0 9 ? * FRIL",
+			After:    "2024-01-01T00:00:00",
+			Expected: []string{"2024-01-26T09:00:00", "2024-02-23T09:00:00", "2024-03-29T09:00:00"},
+		},
+		{
+			Spec:     "0 9 ? * 2#1,4#3",
+			After:    "2024-01-01T00:00:00",
+			Expected: []string{"2024-01-02T09:00:00", "2024-01-18T09:00:00", "2024-02-06T09:00:00", "2024-02-15T09:00:00"},
+		},
+		{
+			Spec:     "0 9 ? * MON#5",
+			After:    "2024-01-01T09:00:00",
+			Expected: []string{"2024-01-29T09:00:00", "2024-04-29T09:00:00", "2024-07-29T09:00:00"},
+		},
+		{
+			// both fields restricted: the 13th or any Friday
+			Spec:     "0 0 13 * 5",
+			After:    "2024-09-01T00:00:00",
+			Expected: []string{"2024-09-06T00:00:00", "2024-09-13T00:00:00", "2024-09-20T00:00:00"},
+		},
+		{
+			// day of month starts with *: the 1st, 14th or 27th and a Friday
+			Spec:     "0 0 */13 * 5",
+			After:    "2024-01-01T00:00:00",
+			Expected: []string{"2024-03-01T00:00:00", "2024-06-14T00:00:00", "2024-09-27T00:00:00"},
+		},
+		{
+			Spec:     "0 0 29 2 *",
+			After:    "2024-03-01T00:00:00",
+			Expected: []string{"2028-02-29T00:00:00", "2032-02-29T00:00:00"},
+		},
+		{
+			Spec:     "59 59 23 31 12 *",
+			After:    "2024-12-31T23:59:59",
+			Expected: []string{"2025-12-31T23:59:59"},
+		},
+		{
+			Spec:     "0 0 30 2 *",
+			After:    "2024-01-01T00:00:00",
+			Expected: []string{"0001-01-01T00:00:00"},
+		},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		s, err := Parse(tc.Spec)
+		if !assert.NoError(err, tc.Spec) {
+			continue
+		}
+		after := dt.MustParseDateTime(tc.After)
+		var actual []string
+		for range tc.Expected {
+			after = s.Next(after)
+			actual = append(actual, after.String())
+		}
+		assert.Equal(tc.Expected, actual, tc.Spec)
+
+		// Prev is the inverse of Next
+		for i := len(tc.Expected) - 1; i > 0; i-- {
+			prev := s.Prev(dt.MustParseDateTime(tc.Expected[i]))
+			assert.Equal(tc.Expected[i-1], prev.String(), tc.Spec)
+		}
+	}
+}
+
+func TestPrev(t *testing.T) {
+	testCases := []struct {
+		Spec     string
+		Before   string
+		Expected string
+	}{
+		{Spec: "*/15 9-17 * * MON-FRI", Before: "2024-01-08T09:00:00", Expected: "2024-01-05T17:45:00"},
+		{Spec: "0 0 L * *", Before: "2024-03-01T00:00:00", Expected: "2024-02-29T00:00:00"},
+		{Spec: "0 0 1 1 *", Before: "2024-01-01T00:00:01", Expected: "2024-01-01T00:00:00"},
+		{Spec: "0 0 1 1 *", Before: "2024-01-01T00:00:00", Expected: "2023-01-01T00:00:00"},
+		{Spec: "30 * * * * *", Before: "2024-01-01T00:00:00", Expected: "2023-12-31T23:59:30"},
+		{Spec: "0 0 30 2 *", Before: "2024-01-01T00:00:00", Expected: "0001-01-01T00:00:00"},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		s := MustParse(tc.Spec)
+		prev := s.Prev(dt.MustParseDateTime(tc.Before))
+		assert.Equal(tc.Expected, prev.String(), tc.Spec)
+	}
+}
+
+func TestNextIn(t *testing.T) {
+	assert := assert.New(t)
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	format := func(t time.Time) string {
+		return t.In(loc).Format("2006-01-02T15:04:05-07:00")
+	}
+	next := func(spec string, after time.Time, resolver dt.Resolver) []string {
+		s := MustParse(spec)
+		var list []string
+		for range 3 {
+			var err error
+			after, err = s.NextIn(after, loc, resolver)
+			if !assert.NoError(err, spec) {
+				break
+			}
+			list = append(list, format(after))
+		}
+		return list
+	}
+
+	// clocks go forward from 02:00 to 03:00 on 10 March 2024
+	start := time.Date(2024, 3, 9, 12, 0, 0, 0, loc)
+	assert.Equal([]string{
+		// 02:30 is shifted forward to 03:30, which only runs once
+		"2024-03-10T01:30:00-05:00",
+		"2024-03-10T03:30:00-04:00",
+		"2024-03-11T01:30:00-04:00",
+	}, next("30 1,2,3 * * *", start.Add(12*time.Hour), nil))
+	assert.Equal([]string{
+		"2024-03-09T02:30:00-05:00",
+		"2024-03-10T03:30:00-04:00",
+		"2024-03-11T02:30:00-04:00",
+	}, next("30 2 * * *", start.Add(-12*time.Hour), nil))
+	assert.Equal([]string{
+		"2024-03-09T02:30:00-05:00",
+		"2024-03-10T01:30:00-05:00",
+		"2024-03-11T02:30:00-04:00",
+	}, next("30 2 * * *", start.Add(-12*time.Hour), dt.ResolveEarlier))
+
+	_, err = MustParse("30 2 * * *").NextIn(start, loc, dt.ResolveStrict)
+	assert.ErrorIs(err, dt.ErrSkippedTime)
+
+	// clocks go back from 02:00 to 01:00 on 3 November 2024
+	start = time.Date(2024, 11, 2, 12, 0, 0, 0, loc)
+	assert.Equal([]string{
+		"2024-11-03T01:30:00-04:00",
+		"2024-11-04T01:30:00-05:00",
+		"2024-11-05T01:30:00-05:00",
+	}, next("30 1 * * *", start, nil))
+	assert.Equal([]string{
+		"2024-11-03T01:30:00-05:00",
+		"2024-11-04T01:30:00-05:00",
+		"2024-11-05T01:30:00-05:00",
+	}, next("30 1 * * *", start, dt.ResolveLater))
+
+	// starting in the second occurrence of 01:00 to 02:00
+	second := time.Date(2024, 11, 3, 6, 10, 0, 0, time.UTC)
+	assert.Equal([]string{
+		"2024-11-03T01:15:00-05:00",
+		"2024-11-03T01:30:00-05:00",
+		"2024-11-03T01:45:00-05:00",
+	}, next("*/15 * * * *", second, nil))
+
+	prev, err := MustParse("30 1 * * *").PrevIn(time.Date(2024, 11, 3, 5, 45, 0, 0, time.UTC), loc, dt.ResolveLater)
+	assert.NoError(err)
+	assert.Equal("2024-11-03T01:30:00-04:00", format(prev))
+	prev, err = MustParse("30 2 * * *").PrevIn(start, loc, nil)
+	assert.NoError(err)
+	assert.Equal("2024-11-02T02:30:00-04:00", format(prev))
+
+	never, err := MustParse("0 0 30 2 *").NextIn(start, loc, nil)
+	assert.NoError(err)
+	assert.True(never.IsZero())
+}
+
+func TestJSON(t *testing.T) {
+	assert := assert.New(t)
+	var v struct {
+		Schedule Schedule `json:"schedule"`
+	}
+	assert.NoError(json.Unmarshal([]byte(`{"schedule":"0 9 * * MON-FRI"}`), &v))
+	assert.Equal("2024-01-08T09:00:00", v.Schedule.Next(dt.MustParseDateTime("2024-01-06T00:00:00")).String())
+	data, err := json.Marshal(v)
+	assert.NoError(err)
+	assert.Equal(`{"schedule":"0 9 * * MON-FRI"}`, string(data))
+	assert.Error(json.Unmarshal([]byte(`{"schedule":"0 9 * *"}`), &v))
+}
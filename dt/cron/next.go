@@ -0,0 +1,257 @@
+package cron
+
+import (
+	"math/bits"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+)
+
+// searchYears is how far Next and Prev search for a matching day. The
+// Gregorian calendar repeats every 400 years, so an expression that does
+// not match in that time never matches.
+const searchYears = 400
+
+// Next returns the first local date-time after the one specified at which
+// the schedule fires. If the schedule never fires, such as "0 0 30 2 *",
+// the result is the zero value.
+func (s Schedule) Next(after dt.LocalDateTime) dt.LocalDateTime {
+	t := after.Add(time.Second)
+	d := t.LocalDate()
+	hour, minute, second := t.Clock()
+	limit := d.AddDate(searchYears, 0, 0)
+	for !d.After(limit) {
+		if s.month&(1<<d.Month()) == 0 {
+			// skip to the first day of the next month
+			d = d.AddDate(0, 1, 1-d.Day())
+			hour, minute, second = 0, 0, 0
+			continue
+		}
+		if s.matchDay(d) {
+			if h, m, sec, ok := s.nextClock(hour, minute, second); ok {
+				return d.At(dt.TimeOfDay(h, m, sec, 0))
+			}
+		}
+		d = d.AddDate(0, 0, 1)
+		hour, minute, second = 0, 0, 0
+	}
+	return dt.LocalDateTime{}
+}
+
+// Prev returns the last local date-time before the one specified at which
+// the schedule fires. If the schedule never fires, the result is the zero
+// value.
+func (s Schedule) Prev(before dt.LocalDateTime) dt.LocalDateTime {
+	t := before.Add(-time.Second)
+	d := t.LocalDate()
+	hour, minute, second := t.Clock()
+	limit := d.AddDate(-searchYears, 0, 0)
+	for !d.Before(limit) {
+		if s.month&(1<<d.Month()) == 0 {
+			// skip to the last day of the previous month
+			d = d.AddDate(0, 0, -d.Day())
+			hour, minute, second = 23, 59, 59
+			continue
+		}
+		if s.matchDay(d) {
+			if h, m, sec, ok := s.prevClock(hour, minute, second); ok {
+				return d.At(dt.TimeOfDay(h, m, sec, 0))
+			}
+		}
+		d = d.AddDate(0, 0, -1)
+		hour, minute, second = 23, 59, 59
+	}
+	return dt.LocalDateTime{}
+}
+
+// NextIn returns the first instant after the one specified at which the
+// schedule fires in the location. The schedule is evaluated in local wall
+// time, and the resolver decides the instant when the local date-time is
+// skipped or repeated by a transition. If resolver is nil, dt.ResolveShiftForward
+// is used, so a job scheduled in a gap runs when the gap ends, and a job
+// scheduled in an overlap runs once, the first time the local time occurs.
+//
+// If the resolver returns an error, NextIn returns the error. If the
+// schedule never fires, the result is the zero time.
+func (s Schedule) NextIn(after time.Time, loc *time.Location, resolver dt.Resolver) (time.Time, error) {
+	local := dt.LocalDateTimeOf(after.In(loc))
+	for {
+		next := s.Next(local)
+		if next.IsZero() {
+			return time.Time{}, nil
+		}
+		t, err := next.InLocation(loc, resolver)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if t.After(after) {
+			return t, nil
+		}
+
+		// after is in the second occurrence of an overlap, and the
+		// resolver chose the first occurrence of the local date-time
+		if m := next.MapLocation(loc); m.IsOverlap() && m.Later().After(after) {
+			return m.Later(), nil
+		}
+		local = next
+	}
+}
+
+// PrevIn returns the last instant before the one specified at which the
+// schedule fires in the location. See NextIn for how local date-times are
+// resolved.
+func (s Schedule) PrevIn(before time.Time, loc *time.Location, resolver dt.Resolver) (time.Time, error) {
+	local := dt.LocalDateTimeOf(before.In(loc))
+	for {
+		prev := s.Prev(local)
+		if prev.IsZero() {
+			return time.Time{}, nil
+		}
+		t, err := prev.InLocation(loc, resolver)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if t.Before(before) {
+			return t, nil
+		}
+
+		// before is in the first occurrence of an overlap, and the
+		// resolver chose the second occurrence of the local date-time
+		if m := prev.MapLocation(loc); m.IsOverlap() && m.Earlier().Before(before) {
+			return m.Earlier(), nil
+		}
+		local = prev
+	}
+}
+
+// nextClock returns the first time of day on or after the one specified
+// that matches the hour, minute and second fields.
+func (s Schedule) nextClock(hour, minute, second int) (int, int, int, bool) {
+	for h := nextBit(s.hour, hour, 23); h >= 0; h = nextBit(s.hour, h+1, 23) {
+		if h > hour {
+			minute, second = 0, 0
+		}
+		for m := nextBit(s.minute, minute, 59); m >= 0; m = nextBit(s.minute, m+1, 59) {
+			if m > minute {
+				second = 0
+			}
+			if sec := nextBit(s.second, second, 59); sec >= 0 {
+				return h, m, sec, true
+			}
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// prevClock returns the last time of day on or before the one specified
+// that matches the hour, minute and second fields.
+func (s Schedule) prevClock(hour, minute, second int) (int, int, int, bool) {
+	for h := prevBit(s.hour, hour); h >= 0; h = prevBit(s.hour, h-1) {
+		if h < hour {
+			minute, second = 59, 59
+		}
+		for m := prevBit(s.minute, minute); m >= 0; m = prevBit(s.minute, m-1) {
+			if m < minute {
+				second = 59
+			}
+			if sec := prevBit(s.second, second); sec >= 0 {
+				return h, m, sec, true
+			}
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// nextBit returns the lowest bit set in b that is in the range [from, to],
+// or -1 if there is none.
+func nextBit(b uint64, from, to int) int {
+	if from > to {
+		return -1
+	}
+	n := bits.TrailingZeros64(b >> from << from)
+	if n > to {
+		return -1
+	}
+	return n
+}
+
+// prevBit returns the highest bit set in b that is in the range [0, to],
+// or -1 if there is none.
+func prevBit(b uint64, to int) int {
+	if to < 0 {
+		return -1
+	}
+	return bits.Len64(b<<(63-to)>>(63-to)) - 1
+}
+
+// matchDay reports whether the day of month and day of week fields match d.
+func (s Schedule) matchDay(d dt.LocalDate) bool {
+	dom, dow := s.matchDayOfMonth(d), s.matchDayOfWeek(d)
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func (s Schedule) matchDayOfMonth(d dt.LocalDate) bool {
+	day := d.Day()
+	if s.dom&(1<<day) != 0 {
+		return true
+	}
+	year, month := d.Year(), d.Month()
+	last := daysInMonth(year, month)
+	for _, n := range s.lastDays {
+		if day == last-n {
+			return true
+		}
+	}
+	for _, n := range s.nearest {
+		if n <= last && day == nearestWeekday(d.AddDate(0, 0, n-day), last) {
+			return true
+		}
+	}
+	if s.lastWeekday && day == nearestWeekday(d.AddDate(0, 0, last-day), last) {
+		return true
+	}
+	return false
+}
+
+func (s Schedule) matchDayOfWeek(d dt.LocalDate) bool {
+	weekday := d.Weekday()
+	if s.dow&(1<<weekday) != 0 {
+		return true
+	}
+	day := d.Day()
+	if s.lastDow&(1<<weekday) != 0 && day+7 > daysInMonth(d.Year(), d.Month()) {
+		return true
+	}
+	for _, nth := range s.nthDow {
+		if nth.weekday == weekday && (day-1)/7+1 == nth.n {
+			return true
+		}
+	}
+	return false
+}
+
+// nearestWeekday returns the day of the month of the weekday nearest to d,
+// without moving into another month. The month has last days.
+func nearestWeekday(d dt.LocalDate, last int) int {
+	day := d.Day()
+	switch d.Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return 3
+		}
+		return day - 1
+	case time.Sunday:
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+func daysInMonth(year int, month time.Month) int {
+	return dt.Date(year, month+1, 0).Day()
+}
@@ -0,0 +1,340 @@
+package dt
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// DateRange represents a range of local dates. The range is half-open:
+// it includes its start date but not its end date, so the range from
+// 2024-01-01 to 2024-02-01 contains every day of January 2024. A range
+// whose start and end dates are equal is empty.
+//
+// Use NewDateRange to create a range from its start and (exclusive) end
+// dates, and ClosedDateRange to create a range from its first and last
+// (inclusive) dates.
+//
+// The text representation of a DateRange is the ISO 8601 time interval
+// format, with the end date exclusive, for example "2024-01-01/2024-02-01".
+type DateRange struct {
+	start LocalDate
+	end   LocalDate
+}
+
+var (
+	errInvalidDateRangeFormat = errors.New("invalid date range format")
+)
+
+// NewDateRange returns the range of dates from start up to, but not
+// including, end. If end is before start, the range is empty.
+func NewDateRange(start, end LocalDate) DateRange {
+	if end.Before(start) {
+		end = start
+	}
+	return DateRange{start: start, end: end}
+}
+
+// ClosedDateRange returns the range of dates from first up to and
+// including last. If last is before first, the range is empty.
+func ClosedDateRange(first, last LocalDate) DateRange {
+	return NewDateRange(first, last.AddDate(0, 0, 1))
+}
+
+// Start returns the first date in the range r.
+func (r DateRange) Start() LocalDate {
+	return r.start
+}
+
+// End returns the date immediately after the last date in the range r.
+// The end date is not included in the range.
+func (r DateRange) End() LocalDate {
+	return r.end
+}
+
+// Last returns the last date in the range r, which is the day before the
+// end date. If r is empty the result is the day before the start date.
+func (r DateRange) Last() LocalDate {
+	return r.end.AddDate(0, 0, -1)
+}
+
+// IsEmpty reports whether the range r contains no dates.
+func (r DateRange) IsEmpty() bool {
+	return !r.start.Before(r.end)
+}
+
+// Equal reports whether r and s have the same start and end dates.
+func (r DateRange) Equal(s DateRange) bool {
+	return r.start.Equal(s.start) && r.end.Equal(s.end)
+}
+
+// Days returns the number of days in the range r.
+func (r DateRange) Days() int {
+	return int(r.end.days - r.start.days)
+}
+
+// Contains reports whether the date d is in the range r.
+func (r DateRange) Contains(d LocalDate) bool {
+	return !d.Before(r.start) && d.Before(r.end)
+}
+
+// ContainsRange reports whether every date in the range s is also in
+// the range r. An empty range s is contained by r if its start date is
+// within r, or is the end of r.
+func (r DateRange) ContainsRange(s DateRange) bool {
+	return !s.start.Before(r.start) && !s.end.After(r.end)
+}
+
+// Overlaps reports whether there is at least one date that is in both
+// of the ranges r and s. An empty range does not overlap any range.
+func (r DateRange) Overlaps(s DateRange) bool {
+	return r.start.Before(s.end) && s.start.Before(r.end) && !r.IsEmpty() && !s.IsEmpty()
+}
+
+// Abuts reports whether the ranges r and s are adjacent, with one
+// ending on the day that the other starts.
+func (r DateRange) Abuts(s DateRange) bool {
+	return r.end.Equal(s.start) || s.end.Equal(r.start)
+}
+
+// Intersect returns the range of dates that are in both r and s.
+// If the ranges do not overlap, the result is false.
+func (r DateRange) Intersect(s DateRange) (DateRange, bool) {
+	if !r.Overlaps(s) {
+		return DateRange{}, false
+	}
+	return DateRange{start: maxDate(r.start, s.start), end: minDate(r.end, s.end)}, true
+}
+
+// Union returns the range of dates that are in either r or s. The union
+// can only be represented as a single range if the ranges overlap or
+// abut each other: otherwise the result is false.
+func (r DateRange) Union(s DateRange) (DateRange, bool) {
+	if !r.Overlaps(s) && !r.Abuts(s) {
+		return DateRange{}, false
+	}
+	return r.Span(s), true
+}
+
+// Span returns the smallest range that contains both r and s, including
+// any gap between them.
+func (r DateRange) Span(s DateRange) DateRange {
+	return DateRange{start: minDate(r.start, s.start), end: maxDate(r.end, s.end)}
+}
+
+// Gap returns the range of dates between r and s. If the ranges overlap
+// or abut each other, there is no gap and the result is false.
+func (r DateRange) Gap(s DateRange) (DateRange, bool) {
+	switch {
+	case r.end.Before(s.start):
+		return DateRange{start: r.end, end: s.start}, true
+	case s.end.Before(r.start):
+		return DateRange{start: s.end, end: r.start}, true
+	}
+	return DateRange{}, false
+}
+
+// Dates returns an iterator over each date in the range r, in order.
+func (r DateRange) Dates() iter.Seq[LocalDate] {
+	return func(yield func(LocalDate) bool) {
+		for d := r.start; d.Before(r.end); d = d.AddDate(0, 0, 1) {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+func minDate(a, b LocalDate) LocalDate {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func maxDate(a, b LocalDate) LocalDate {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// String returns a string representation of r in the ISO 8601
+// time interval format "start/end", where the end date is exclusive.
+func (r DateRange) String() string {
+	return dateRangeString(r)
+}
+
+// dateRangeString returns the string representation of the date range.
+func dateRangeString(r DateRange) string {
+	return toString(r.start) + "/" + toString(r.end)
+}
+
+// ParseDateRange attempts to parse a string into a date range. Leading
+// and trailing space and quotation marks are ignored. The string is expected
+// to be in one of the ISO 8601 time interval formats "start/end",
+// "start/period" or "period/end", where start and end are in any of the
+// formats accepted by ParseDate and period is in the format accepted by
+// ParsePeriod. The end date is exclusive, so "2024-01-01/2024-02-01" and
+// "2024-01-01/P1M" both represent the month of January 2024.
+func ParseDateRange(s string) (DateRange, error) {
+	s = strings.Trim(s, " \t\"'")
+	startText, endText, ok := strings.Cut(s, "/")
+	if !ok {
+		return DateRange{}, errInvalidDateRangeFormat
+	}
+
+	if isPeriodText(startText) {
+		p, err := ParsePeriod(startText)
+		if err != nil {
+			return DateRange{}, err
+		}
+		end, err := ParseDate(endText)
+		if err != nil {
+			return DateRange{}, err
+		}
+		return newDateRangeStrict(end.AddPeriod(p.Negated()), end)
+	}
+
+	start, err := ParseDate(startText)
+	if err != nil {
+		return DateRange{}, err
+	}
+	if isPeriodText(endText) {
+		p, err := ParsePeriod(endText)
+		if err != nil {
+			return DateRange{}, err
+		}
+		return newDateRangeStrict(start, start.AddPeriod(p))
+	}
+	end, err := ParseDate(endText)
+	if err != nil {
+		return DateRange{}, err
+	}
+	return newDateRangeStrict(start, end)
+}
+
+// isPeriodText reports whether s looks like an ISO 8601 duration.
+func isPeriodText(s string) bool {
+	s = strings.TrimLeft(s, " \t\"'+-")
+	return strings.HasPrefix(s, "P") || strings.HasPrefix(s, "p")
+}
+
+// newDateRangeStrict returns the date range from start to end, or
+// an error if end is before start.
+func newDateRangeStrict(start, end LocalDate) (DateRange, error) {
+	if end.Before(start) {
+		return DateRange{}, fmt.Errorf("date range end %s is before start %s", end, start)
+	}
+	return DateRange{start: start, end: end}, nil
+}
+
+// MustParseDateRange is similar to ParseDateRange, but instead of returning an error it will
+// panic if s is not in one of the expected formats.
+func MustParseDateRange(s string) DateRange {
+	r, err := ParseDateRange(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The date range is a quoted string in the ISO 8601 format (start/end).
+func (r DateRange) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + dateRangeString(r) + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The date range is expected to be a quoted string in one of the
+// ISO 8601 time interval formats.
+// By convention, a JSON null is a no-op.
+func (r *DateRange) UnmarshalJSON(data []byte) (err error) {
+	if isNullJSON(data) {
+		return nil
+	}
+	s := string(data)
+	*r, err = ParseDateRange(s)
+	return
+}
+
+// MarshalText implements the encoding.TextMarshaller interface.
+// The date range format is start/end.
+func (r DateRange) MarshalText() ([]byte, error) {
+	return []byte(dateRangeString(r)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaller interface.
+// The date range is expected to be in one of the ISO 8601 time interval formats.
+func (r *DateRange) UnmarshalText(data []byte) (err error) {
+	s := string(data)
+	*r, err = ParseDateRange(s)
+	return
+}
+
+// Scan implements the sql.Scanner interface. The value is expected to be a
+// string or []byte in one of the formats recognised by ParseDateRange, or in
+// the PostgreSQL range format, for example "[2024-01-01,2024-02-01)".
+func (r *DateRange) Scan(src interface{}) (err error) {
+	switch v := src.(type) {
+	case string:
+		*r, err = parseSQLDateRange(v)
+	case []byte:
+		*r, err = parseSQLDateRange(string(v))
+	case nil:
+		err = errors.New("cannot scan NULL into DateRange")
+	default:
+		err = fmt.Errorf("cannot scan %T into DateRange", src)
+	}
+	return
+}
+
+// parseSQLDateRange parses a date range in either the ISO 8601 format or the
+// PostgreSQL range format. A PostgreSQL range may have inclusive ("[" and "]")
+// or exclusive ("(" and ")") bounds, but must not be unbounded.
+func parseSQLDateRange(s string) (DateRange, error) {
+	s = strings.TrimSpace(s)
+	if s == "empty" {
+		return DateRange{}, nil
+	}
+	if s == "" || !strings.ContainsAny(s[:1], "[(") {
+		return ParseDateRange(s)
+	}
+
+	if len(s) < 2 || !strings.ContainsAny(s[len(s)-1:], "])") {
+		return DateRange{}, errInvalidDateRangeFormat
+	}
+	lower, upper, ok := strings.Cut(s[1:len(s)-1], ",")
+	if !ok {
+		return DateRange{}, errInvalidDateRangeFormat
+	}
+	start, err := ParseDate(lower)
+	if err != nil {
+		return DateRange{}, err
+	}
+	end, err := ParseDate(upper)
+	if err != nil {
+		return DateRange{}, err
+	}
+	if s[0] == '(' {
+		start = start.AddDate(0, 0, 1)
+	}
+	if s[len(s)-1] == ']' {
+		end = end.AddDate(0, 0, 1)
+	}
+	return newDateRangeStrict(start, end)
+}
+
+// Value implements the driver.Valuer interface. The date range is passed
+// to the database as a string in the PostgreSQL range format with an
+// inclusive start and an exclusive end, for example "[2024-01-01,2024-02-01)",
+// which is accepted by a daterange column and by Scan. An empty range is
+// passed as "empty".
+func (r DateRange) Value() (driver.Value, error) {
+	if r.IsEmpty() {
+		return "empty", nil
+	}
+	return "[" + toString(r.start) + "," + toString(r.end) + ")", nil
+}
@@ -0,0 +1,220 @@
+package dt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDateRangeBasics(t *testing.T) {
+	assert := assert.New(t)
+	jan := NewDateRange(Date(2024, time.January, 1), Date(2024, time.February, 1))
+
+	assert.Equal("2024-01-01", jan.Start().String())
+	assert.Equal("2024-02-01", jan.End().String())
+	assert.Equal("2024-01-31", jan.Last().String())
+	assert.Equal(31, jan.Days())
+	assert.False(jan.IsEmpty())
+	assert.True(jan.Contains(Date(2024, time.January, 1)))
+	assert.True(jan.Contains(Date(2024, time.January, 31)))
+	assert.False(jan.Contains(Date(2024, time.February, 1)))
+	assert.False(jan.Contains(Date(2023, time.December, 31)))
+	assert.True(jan.Equal(ClosedDateRange(Date(2024, time.January, 1), Date(2024, time.January, 31))))
+
+	empty := NewDateRange(Date(2024, time.January, 1), Date(2024, time.January, 1))
+	assert.True(empty.IsEmpty())
+	assert.Equal(0, empty.Days())
+	assert.False(empty.Contains(Date(2024, time.January, 1)))
+
+	backwards := NewDateRange(Date(2024, time.January, 1), Date(2023, time.January, 1))
+	assert.True(backwards.IsEmpty())
+	assert.Equal("2024-01-01/2024-01-01", backwards.String())
+
+	long := NewDateRange(Date(1, time.January, 1), Date(9999, time.December, 31))
+	assert.Equal(3652058, long.Days())
+}
+
+func TestDateRangeSetOperations(t *testing.T) {
+	testCases := []struct {
+		A, B      string
+		Overlaps  bool
+		Abuts     bool
+		Intersect string
+		Union     string
+		Gap       string
+		Span      string
+	}{
+		{
+			A: "2024-01-01/2024-01-10", B: "2024-01-05/2024-01-20",
+			Overlaps: true, Intersect: "2024-01-05/2024-01-10", Union: "2024-01-01/2024-01-20",
+			Span: "2024-01-01/2024-01-20",
+		},
+		{
+			A: "2024-01-01/2024-01-10", B: "2024-01-10/2024-01-20",
+			Abuts: true, Union: "2024-01-01/2024-01-20", Span: "2024-01-01/2024-01-20",
+		},
+		{
+			A: "2024-01-01/2024-01-10", B: "2024-01-15/2024-01-20",
+			Gap: "2024-01-10/2024-01-15", Span: "2024-01-01/2024-01-20",
+		},
+		{
+			A: "2024-01-15/2024-01-20", B: "2024-01-01/2024-01-10",
+			Gap: "2024-01-10/2024-01-15", Span: "2024-01-01/2024-01-20",
+		},
+		{
+			A: "2024-01-01/2024-01-31", B: "2024-01-10/2024-01-11",
+			Overlaps: true, Intersect: "2024-01-10/2024-01-11", Union: "2024-01-01/2024-01-31",
+			Span: "2024-01-01/2024-01-31",
+		},
+		{
+			A: "2024-01-01/2024-01-31", B: "2024-01-10/2024-01-10",
+			Span: "2024-01-01/2024-01-31",
+		},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		a, b := MustParseDateRange(tc.A), MustParseDateRange(tc.B)
+		name := tc.A + " " + tc.B
+		assert.Equal(tc.Overlaps, a.Overlaps(b), name)
+		assert.Equal(tc.Overlaps, b.Overlaps(a), name)
+		assert.Equal(tc.Abuts, a.Abuts(b), name)
+
+		check := func(expected string, r DateRange, ok bool) {
+			if expected == "" {
+				assert.False(ok, name)
+			} else {
+				assert.True(ok, name)
+				assert.Equal(expected, r.String(), name)
+			}
+		}
+		r, ok := a.Intersect(b)
+		check(tc.Intersect, r, ok)
+		r, ok = a.Union(b)
+		check(tc.Union, r, ok)
+		r, ok = a.Gap(b)
+		check(tc.Gap, r, ok)
+		assert.Equal(tc.Span, a.Span(b).String(), name)
+	}
+
+	jan := MustParseDateRange("2024-01-01/P1M")
+	assert.True(jan.ContainsRange(MustParseDateRange("2024-01-10/2024-01-20")))
+	assert.True(jan.ContainsRange(jan))
+	assert.False(jan.ContainsRange(MustParseDateRange("2023-12-31/2024-01-20")))
+}
+
+func TestDateRangeDates(t *testing.T) {
+	assert := assert.New(t)
+	r := MustParseDateRange("2024-02-27/2024-03-02")
+
+	var dates []string
+	for d := range r.Dates() {
+		dates = append(dates, d.String())
+	}
+	assert.Equal([]string{"2024-02-27", "2024-02-28", "2024-02-29", "2024-03-01"}, dates)
+
+	dates = nil
+	for d := range r.Dates() {
+		if d.Day() == 29 {
+			break
+		}
+		dates = append(dates, d.String())
+	}
+	assert.Equal([]string{"2024-02-27", "2024-02-28"}, dates)
+
+	for range MustParseDateRange("2024-01-01/2024-01-01").Dates() {
+		t.Error("empty range should not yield any dates")
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	testCases := []struct {
+		Text     string
+		Valid    bool
+		Expected string
+	}{
+		{Text: "2024-01-01/2024-01-31", Valid: true, Expected: "2024-01-01/2024-01-31"},
+		{Text: "2024-01-01/P1M", Valid: true, Expected: "2024-01-01/2024-02-01"},
+		{Text: "2024-01-01/P2W", Valid: true, Expected: "2024-01-01/2024-01-15"},
+		{Text: "P1M/2024-02-01", Valid: true, Expected: "2024-01-01/2024-02-01"},
+		{Text: `"20240101/20240201"`, Valid: true, Expected: "2024-01-01/2024-02-01"},
+		{Text: "2024-01-01/2024-01-01", Valid: true, Expected: "2024-01-01/2024-01-01"},
+		{Text: "2024-01-31/2024-01-01", Valid: false},
+		{Text: "2024-01-01/P-1D", Valid: false},
+		{Text: "2024-01-01", Valid: false},
+		{Text: "2024-01-01/", Valid: false},
+		{Text: "P1M/P1M", Valid: false},
+		{Text: "2024-01-01/2024-01-31/2024-02-28", Valid: false},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		r, err := ParseDateRange(tc.Text)
+		if tc.Valid {
+			assert.NoError(err, tc.Text)
+			assert.Equal(tc.Expected, r.String(), tc.Text)
+		} else {
+			assert.Error(err, tc.Text)
+		}
+	}
+}
+
+func TestDateRangeMarshal(t *testing.T) {
+	assert := assert.New(t)
+	type testStruct struct {
+		Leave DateRange `json:"leave"`
+	}
+	st := testStruct{Leave: MustParseDateRange("2024-01-01/P1M")}
+
+	b, err := json.Marshal(&st)
+	assert.NoError(err)
+	assert.Equal(`{"leave":"2024-01-01/2024-02-01"}`, string(b))
+
+	var st2 testStruct
+	assert.NoError(json.Unmarshal(b, &st2))
+	assert.True(st.Leave.Equal(st2.Leave))
+
+	v, err := st.Leave.Value()
+	assert.NoError(err)
+	assert.Equal("[2024-01-01,2024-02-01)", v)
+	var scanned DateRange
+	assert.NoError(scanned.Scan(v))
+	assert.Equal(st.Leave, scanned)
+
+	v, err = DateRange{}.Value()
+	assert.NoError(err)
+	assert.Equal("empty", v)
+	assert.NoError(scanned.Scan(v))
+	assert.True(scanned.IsEmpty())
+
+	testCases := []struct {
+		Src      interface{}
+		Expected string
+	}{
+		{Src: "2024-01-01/2024-02-01", Expected: "2024-01-01/2024-02-01"},
+		{Src: []byte("[2024-01-01,2024-02-01)"), Expected: "2024-01-01/2024-02-01"},
+		{Src: "[2024-01-01,2024-01-31]", Expected: "2024-01-01/2024-02-01"},
+		{Src: "(2023-12-31,2024-02-01)", Expected: "2024-01-01/2024-02-01"},
+		{Src: "empty", Expected: "0001-01-01/0001-01-01"},
+		{Src: "[2024-01-01,)"},
+		{Src: "[2024-02-01,2024-01-01)"},
+		{Src: "["},
+		{Src: "("},
+		{Src: []byte(" ( ")},
+		{Src: "[2024-01-01,2024-02-01"},
+		{Src: nil},
+		{Src: 42},
+	}
+	for _, tc := range testCases {
+		var r DateRange
+		err := r.Scan(tc.Src)
+		if tc.Expected != "" {
+			assert.NoError(err, "%v", tc.Src)
+			assert.Equal(tc.Expected, r.String())
+		} else {
+			assert.Error(err, "%v", tc.Src)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package holiday
+
+import (
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+)
+
+// au contains the holidays observed throughout Australia. Each state and
+// territory adds its own holidays, including the sovereign's birthday and
+// labour day, which are observed on different days in different states.
+var au = NewCalendar("Australia",
+	Fixed("New Year's Day", time.January, 1).Observed(MondayIfWeekend),
+	Fixed("Australia Day", time.January, 26).Observed(MondayIfWeekend),
+	Easter("Good Friday", -2),
+	Easter("Easter Monday", 1),
+	Fixed("Anzac Day", time.April, 25),
+	Fixed("Christmas Day", time.December, 25).Observed(MondayIfWeekend),
+	Fixed("Boxing Day", time.December, 26).Observed(MondayIfWeekend),
+)
+
+// sovereignsBirthday returns the rules for the Queen's Birthday holiday,
+// which became the King's Birthday in 2023.
+func sovereignsBirthday(n int, weekday time.Weekday, month time.Month) []Rule {
+	return []Rule{
+		NthWeekday("Queen's Birthday", n, weekday, month).Until(2022),
+		NthWeekday("King's Birthday", n, weekday, month).From(2023),
+	}
+}
+
+var auNSW = au.Extend("New South Wales", append(sovereignsBirthday(2, time.Monday, time.June),
+	Easter("Easter Saturday", -1),
+	Easter("Easter Sunday", 0),
+	NthWeekday("Labour Day", 1, time.Monday, time.October),
+)...)
+
+var auVIC = au.Extend("Victoria", append(sovereignsBirthday(2, time.Monday, time.June),
+	NthWeekday("Labour Day", 2, time.Monday, time.March),
+	Easter("Easter Saturday", -1),
+	Easter("Easter Sunday", 0),
+	Dates("Friday before the AFL Grand Final",
+		dt.Date(2015, time.October, 2),
+		dt.Date(2016, time.September, 30),
+		dt.Date(2017, time.September, 29),
+		dt.Date(2018, time.September, 28),
+		dt.Date(2019, time.September, 27),
+		dt.Date(2020, time.October, 23),
+		dt.Date(2021, time.September, 24),
+		dt.Date(2022, time.September, 23),
+		dt.Date(2023, time.September, 29),
+		dt.Date(2024, time.September, 27),
+		dt.Date(2025, time.September, 26),
+	),
+	NthWeekday("Melbourne Cup", 1, time.Tuesday, time.November),
+)...)
+
+var auQLD = au.Extend("Queensland",
+	NthWeekday("Labour Day", 1, time.Monday, time.May),
+	Easter("Easter Saturday", -1),
+	Easter("Easter Sunday", 0),
+	NthWeekday("Queen's Birthday", 2, time.Monday, time.June).Until(2015),
+	NthWeekday("Queen's Birthday", 1, time.Monday, time.October).From(2016).Until(2022),
+	NthWeekday("King's Birthday", 1, time.Monday, time.October).From(2023),
+)
+
+var auSA = au.without("Boxing Day").Extend("South Australia", append(sovereignsBirthday(2, time.Monday, time.June),
+	NthWeekday("Adelaide Cup", 2, time.Monday, time.March),
+	Easter("Easter Saturday", -1),
+	NthWeekday("Labour Day", 1, time.Monday, time.October),
+	Fixed("Proclamation Day", time.December, 26).Observed(MondayIfWeekend),
+)...)
+
+var auWA = au.Extend("Western Australia",
+	NthWeekday("Labour Day", 1, time.Monday, time.March),
+	Fixed("Anzac Day", time.April, 25).Observed(MondayIfWeekend),
+	NthWeekday("Western Australia Day", 1, time.Monday, time.June),
+
+	// The sovereign's birthday is proclaimed each year in Western Australia,
+	// and is usually the last Monday in September.
+	NthWeekday("Queen's Birthday", -1, time.Monday, time.September).Until(2022),
+	NthWeekday("King's Birthday", -1, time.Monday, time.September).From(2023).Except(
+		dt.Date(2024, time.September, 23),
+	),
+)
+
+var auTAS = au.Extend("Tasmania", append(sovereignsBirthday(2, time.Monday, time.June),
+	NthWeekday("Eight Hours Day", 2, time.Monday, time.March),
+)...)
+
+var auACT = au.Extend("Australian Capital Territory", append(sovereignsBirthday(2, time.Monday, time.June),
+	NthWeekday("Canberra Day", 2, time.Monday, time.March),
+	Easter("Easter Saturday", -1),
+	Easter("Easter Sunday", 0),
+	Fixed("Anzac Day", time.April, 25).Observed(MondayIfWeekend),
+	WeekdayOnOrAfter("Reconciliation Day", time.Monday, time.May, 27).From(2018),
+	NthWeekday("Labour Day", 1, time.Monday, time.October),
+)...)
+
+var auNT = au.Extend("Northern Territory", append(sovereignsBirthday(2, time.Monday, time.June),
+	Easter("Easter Saturday", -1),
+	NthWeekday("May Day", 1, time.Monday, time.May),
+	NthWeekday("Picnic Day", 1, time.Monday, time.August),
+)...)
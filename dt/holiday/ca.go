@@ -0,0 +1,22 @@
+package holiday
+
+import "time"
+
+// ca contains the holidays observed by the federal government of
+// Canada. Provincial holidays are not included.
+var ca = NewCalendar("Canada",
+	Fixed("New Year's Day", time.January, 1).Observed(MondayIfWeekend),
+	Easter("Good Friday", -2),
+	Easter("Easter Monday", 1),
+
+	// Victoria Day is the last Monday before May 25.
+	WeekdayOnOrBefore("Victoria Day", time.Monday, time.May, 24),
+	Fixed("Canada Day", time.July, 1).Observed(MondayIfWeekend),
+	NthWeekday("Civic Holiday", 1, time.Monday, time.August),
+	NthWeekday("Labour Day", 1, time.Monday, time.September),
+	Fixed("National Day for Truth and Reconciliation", time.September, 30).From(2021).Observed(MondayIfWeekend),
+	NthWeekday("Thanksgiving", 2, time.Monday, time.October),
+	Fixed("Remembrance Day", time.November, 11).Observed(MondayIfWeekend),
+	Fixed("Christmas Day", time.December, 25).Observed(MondayIfWeekend),
+	Fixed("Boxing Day", time.December, 26).Observed(MondayIfWeekend),
+)
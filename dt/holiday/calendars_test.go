@@ -0,0 +1,340 @@
+package holiday
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	assert := assert.New(t)
+	codes := Codes()
+	assert.Contains(codes, "AU-NSW")
+	assert.Contains(codes, "TARGET2")
+	for _, code := range codes {
+		c, ok := Lookup(code)
+		assert.True(ok, code)
+		assert.NotNil(c, code)
+	}
+	c, ok := Lookup(" au-vic ")
+	assert.True(ok)
+	assert.Equal("Victoria", c.Name())
+
+	_, ok = Lookup("XX")
+	assert.False(ok)
+}
+
+func TestBuiltinCalendars(t *testing.T) {
+	testCases := []struct {
+		Code     string
+		Year     int
+		Expected []string
+	}{
+		{
+			Code: "AU-NSW",
+			Year: 2022,
+			Expected: []string{
+				"2022-01-01 New Year's Day",
+				"2022-01-03 New Year's Day (observed)",
+				"2022-01-26 Australia Day",
+				"2022-04-15 Good Friday",
+				"2022-04-16 Easter Saturday",
+				"2022-04-17 Easter Sunday",
+				"2022-04-18 Easter Monday",
+				"2022-04-25 Anzac Day",
+				"2022-06-13 Queen's Birthday",
+				"2022-10-03 Labour Day",
+				"2022-12-25 Christmas Day",
+				"2022-12-26 Boxing Day",
+				"2022-12-27 Christmas Day (observed)",
+			},
+		},
+		{
+			Code: "AU-VIC",
+			Year: 2024,
+			Expected: []string{
+				"2024-01-01 New Year's Day",
+				"2024-01-26 Australia Day",
+				"2024-03-11 Labour Day",
+				"2024-03-29 Good Friday",
+				"2024-03-30 Easter Saturday",
+				"2024-03-31 Easter Sunday",
+				"2024-04-01 Easter Monday",
+				"2024-04-25 Anzac Day",
+				"2024-06-10 King's Birthday",
+				"2024-09-27 Friday before the AFL Grand Final",
+				"2024-11-05 Melbourne Cup",
+				"2024-12-25 Christmas Day",
+				"2024-12-26 Boxing Day",
+			},
+		},
+		{
+			Code: "AU-QLD",
+			Year: 2024,
+			Expected: []string{
+				"2024-01-01 New Year's Day",
+				"2024-01-26 Australia Day",
+				"2024-03-29 Good Friday",
+				"2024-03-30 Easter Saturday",
+				"2024-03-31 Easter Sunday",
+				"2024-04-01 Easter Monday",
+				"2024-04-25 Anzac Day",
+				"2024-05-06 Labour Day",
+				"2024-10-07 King's Birthday",
+				"2024-12-25 Christmas Day",
+				"2024-12-26 Boxing Day",
+			},
+		},
+		{
+			Code: "AU-WA",
+			Year: 2021,
+			Expected: []string{
+				"2021-01-01 New Year's Day",
+				"2021-01-26 Australia Day",
+				"2021-03-01 Labour Day",
+				"2021-04-02 Good Friday",
+				"2021-04-05 Easter Monday",
+				"2021-04-25 Anzac Day",
+				"2021-04-26 Anzac Day (observed)",
+				"2021-06-07 Western Australia Day",
+				"2021-09-27 Queen's Birthday",
+				"2021-12-25 Christmas Day",
+				"2021-12-26 Boxing Day",
+				"2021-12-27 Christmas Day (observed)",
+				"2021-12-28 Boxing Day (observed)",
+			},
+		},
+		{
+			Code: "NZ",
+			Year: 2022,
+			Expected: []string{
+				"2022-01-01 New Year's Day",
+				"2022-01-02 Day after New Year's Day",
+				"2022-01-03 New Year's Day (observed)",
+				"2022-01-04 Day after New Year's Day (observed)",
+				"2022-02-06 Waitangi Day",
+				"2022-02-07 Waitangi Day (observed)",
+				"2022-04-15 Good Friday",
+				"2022-04-18 Easter Monday",
+				"2022-04-25 Anzac Day",
+				"2022-06-06 Queen's Birthday",
+				"2022-06-24 Matariki",
+				"2022-10-24 Labour Day",
+				"2022-12-25 Christmas Day",
+				"2022-12-26 Boxing Day",
+				"2022-12-27 Christmas Day (observed)",
+			},
+		},
+		{
+			Code: "GB",
+			Year: 2002,
+			Expected: []string{
+				"2002-01-01 New Year's Day",
+				"2002-03-29 Good Friday",
+				"2002-04-01 Easter Monday",
+				"2002-05-06 Early May bank holiday",
+				"2002-06-03 Queen's Golden Jubilee",
+				"2002-06-04 Spring bank holiday",
+				"2002-08-26 Summer bank holiday",
+				"2002-12-25 Christmas Day",
+				"2002-12-26 Boxing Day",
+			},
+		},
+		{
+			Code: "GB",
+			Year: 2022,
+			Expected: []string{
+				"2022-01-01 New Year's Day",
+				"2022-01-03 New Year's Day (observed)",
+				"2022-04-15 Good Friday",
+				"2022-04-18 Easter Monday",
+				"2022-05-02 Early May bank holiday",
+				"2022-06-02 Spring bank holiday",
+				"2022-06-03 Queen's Platinum Jubilee",
+				"2022-08-29 Summer bank holiday",
+				"2022-09-19 State Funeral of Queen Elizabeth II",
+				"2022-12-25 Christmas Day",
+				"2022-12-26 Boxing Day",
+				"2022-12-27 Christmas Day (observed)",
+			},
+		},
+		{
+			Code: "GB-SCT",
+			Year: 2023,
+			Expected: []string{
+				"2023-01-01 New Year's Day",
+				"2023-01-02 2nd January",
+				"2023-01-03 New Year's Day (observed)",
+				"2023-04-07 Good Friday",
+				"2023-05-01 Early May bank holiday",
+				"2023-05-08 Coronation of King Charles III",
+				"2023-05-29 Spring bank holiday",
+				"2023-08-07 Summer bank holiday",
+				"2023-11-30 St Andrew's Day",
+				"2023-12-25 Christmas Day",
+				"2023-12-26 Boxing Day",
+			},
+		},
+		{
+			Code: "GB-NIR",
+			Year: 2024,
+			Expected: []string{
+				"2024-01-01 New Year's Day",
+				"2024-03-17 St Patrick's Day",
+				"2024-03-18 St Patrick's Day (observed)",
+				"2024-03-29 Good Friday",
+				"2024-04-01 Easter Monday",
+				"2024-05-06 Early May bank holiday",
+				"2024-05-27 Spring bank holiday",
+				"2024-07-12 Battle of the Boyne",
+				"2024-08-26 Summer bank holiday",
+				"2024-12-25 Christmas Day",
+				"2024-12-26 Boxing Day",
+			},
+		},
+		{
+			Code: "US",
+			Year: 2021,
+			Expected: []string{
+				"2021-01-01 New Year's Day",
+				"2021-01-18 Birthday of Martin Luther King, Jr.",
+				"2021-02-15 Washington's Birthday",
+				"2021-05-31 Memorial Day",
+				"2021-06-18 Juneteenth National Independence Day (observed)",
+				"2021-06-19 Juneteenth National Independence Day",
+				"2021-07-04 Independence Day",
+				"2021-07-05 Independence Day (observed)",
+				"2021-09-06 Labor Day",
+				"2021-10-11 Columbus Day",
+				"2021-11-11 Veterans Day",
+				"2021-11-25 Thanksgiving Day",
+				"2021-12-24 Christmas Day (observed)",
+				"2021-12-25 Christmas Day",
+				"2021-12-31 New Year's Day (observed)",
+			},
+		},
+		{
+			Code: "US-NY",
+			Year: 2024,
+			Expected: []string{
+				"2024-01-01 New Year's Day",
+				"2024-01-15 Birthday of Martin Luther King, Jr.",
+				"2024-02-12 Lincoln's Birthday",
+				"2024-02-19 Washington's Birthday",
+				"2024-05-27 Memorial Day",
+				"2024-06-19 Juneteenth National Independence Day",
+				"2024-07-04 Independence Day",
+				"2024-09-02 Labor Day",
+				"2024-10-14 Columbus Day",
+				"2024-11-05 Election Day",
+				"2024-11-11 Veterans Day",
+				"2024-11-28 Thanksgiving Day",
+				"2024-12-25 Christmas Day",
+			},
+		},
+		{
+			Code: "CA",
+			Year: 2024,
+			Expected: []string{
+				"2024-01-01 New Year's Day",
+				"2024-03-29 Good Friday",
+				"2024-04-01 Easter Monday",
+				"2024-05-20 Victoria Day",
+				"2024-07-01 Canada Day",
+				"2024-08-05 Civic Holiday",
+				"2024-09-02 Labour Day",
+				"2024-09-30 National Day for Truth and Reconciliation",
+				"2024-10-14 Thanksgiving",
+				"2024-11-11 Remembrance Day",
+				"2024-12-25 Christmas Day",
+				"2024-12-26 Boxing Day",
+			},
+		},
+		{
+			Code: "TARGET2",
+			Year: 2022,
+			Expected: []string{
+				"2022-01-01 New Year's Day",
+				"2022-04-15 Good Friday",
+				"2022-04-18 Easter Monday",
+				"2022-05-01 Labour Day",
+				"2022-12-25 Christmas Day",
+				"2022-12-26 Christmas Holiday",
+			},
+		},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		c, ok := Lookup(tc.Code)
+		if !assert.True(ok, tc.Code) {
+			continue
+		}
+		var actual []string
+		for _, h := range c.Holidays(tc.Year) {
+			actual = append(actual, h.Date.String()+" "+h.Name)
+		}
+		assert.Equal(tc.Expected, actual, "%s %d", tc.Code, tc.Year)
+	}
+}
+
+func TestMatariki(t *testing.T) {
+	assert := assert.New(t)
+	for year := 2022; year <= 2052; year++ {
+		d, ok := matariki.Date(year)
+		if assert.True(ok, "%d", year) {
+			assert.Equal(time.Friday, d.Weekday(), "%d", year)
+			assert.Equal(year, d.Year())
+		}
+	}
+	_, ok := matariki.Date(2021)
+	assert.False(ok)
+}
+
+func TestSubstituteHolidays(t *testing.T) {
+	testCases := []struct {
+		Code    string
+		Date    dt.LocalDate
+		Holiday bool
+	}{
+		// Christmas Day on Sunday is observed on Tuesday, after Boxing Day
+		{"AU", dt.Date(2022, time.December, 27), true},
+		{"GB", dt.Date(2022, time.December, 27), true},
+		{"NZ", dt.Date(2022, time.December, 27), true},
+
+		// New Year's Day on Saturday is observed on Friday in the United
+		// States, and on Monday elsewhere
+		{"US", dt.Date(2021, time.December, 31), true},
+		{"US", dt.Date(2022, time.January, 3), false},
+		{"AU", dt.Date(2022, time.January, 3), true},
+		{"AU", dt.Date(2021, time.December, 31), false},
+
+		// Lincoln's Birthday is moved from Sunday but not from Saturday
+		{"US-NY", dt.Date(2023, time.February, 13), true},
+		{"US-NY", dt.Date(2022, time.February, 11), false},
+		{"US-NY", dt.Date(2022, time.February, 14), false},
+
+		// TARGET2 closing days are never moved
+		{"TARGET2", dt.Date(2022, time.December, 27), false},
+		{"TARGET2", dt.Date(2022, time.May, 2), false},
+
+		// Anzac Day on Sunday is only moved in some states
+		{"AU-NSW", dt.Date(2021, time.April, 26), false},
+		{"AU-ACT", dt.Date(2021, time.April, 26), true},
+
+		// South Australia observes Proclamation Day instead of Boxing Day
+		{"AU-SA", dt.Date(2021, time.December, 28), true},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		c, _ := Lookup(tc.Code)
+		assert.Equal(tc.Holiday, c.IsHoliday(tc.Date), "%s %s", tc.Code, tc.Date)
+	}
+	sa, _ := Lookup("AU-SA")
+	for _, h := range sa.Holidays(2024) {
+		assert.NotEqual("Boxing Day", h.Name)
+	}
+}
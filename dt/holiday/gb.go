@@ -0,0 +1,51 @@
+package holiday
+
+import (
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+)
+
+// gbSpecial contains the one-off bank holidays proclaimed throughout
+// the United Kingdom.
+var gbSpecial = []Rule{
+	Dates("Millennium Celebrations", dt.Date(1999, time.December, 31)),
+	Dates("Queen's Golden Jubilee", dt.Date(2002, time.June, 3)),
+	Dates("Royal Wedding", dt.Date(2011, time.April, 29)),
+	Dates("Queen's Diamond Jubilee", dt.Date(2012, time.June, 5)),
+	Dates("Queen's Platinum Jubilee", dt.Date(2022, time.June, 3)),
+	Dates("State Funeral of Queen Elizabeth II", dt.Date(2022, time.September, 19)),
+	Dates("Coronation of King Charles III", dt.Date(2023, time.May, 8)),
+}
+
+// gb contains the bank holidays of England and Wales.
+var gb = NewCalendar("England and Wales", append([]Rule{
+	Fixed("New Year's Day", time.January, 1).Observed(MondayIfWeekend),
+	Easter("Good Friday", -2),
+	Easter("Easter Monday", 1),
+	NthWeekday("Early May bank holiday", 1, time.Monday, time.May).From(1978).Except(
+		dt.Date(1995, time.May, 8),
+		dt.Date(2020, time.May, 8),
+	),
+	NthWeekday("Spring bank holiday", -1, time.Monday, time.May).Except(
+		dt.Date(2002, time.June, 4),
+		dt.Date(2012, time.June, 4),
+		dt.Date(2022, time.June, 2),
+	),
+	NthWeekday("Summer bank holiday", -1, time.Monday, time.August),
+	Fixed("Christmas Day", time.December, 25).Observed(MondayIfWeekend),
+	Fixed("Boxing Day", time.December, 26).Observed(MondayIfWeekend),
+}, gbSpecial...)...)
+
+// gbSCT contains the bank holidays of Scotland.
+var gbSCT = gb.without("Easter Monday").Extend("Scotland",
+	Fixed("2nd January", time.January, 2).Observed(MondayIfWeekend),
+	NthWeekday("Summer bank holiday", 1, time.Monday, time.August),
+	Fixed("St Andrew's Day", time.November, 30).From(2007).Observed(MondayIfWeekend),
+)
+
+// gbNIR contains the bank holidays of Northern Ireland.
+var gbNIR = gb.Extend("Northern Ireland",
+	Fixed("St Patrick's Day", time.March, 17).Observed(MondayIfWeekend),
+	Fixed("Battle of the Boyne", time.July, 12).Observed(MondayIfWeekend),
+)
@@ -0,0 +1,248 @@
+// Package holiday calculates public holidays using rules, such as
+// "the first Monday in October" or "two days before Easter Sunday".
+//
+// A Calendar is a named set of rules for a country, state or market.
+// Calendars for a number of countries are built in, and can be found
+// using Lookup. A Calendar implements dt.HolidaySet, so it can be
+// used with dt.BusinessCalendar.
+package holiday
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+)
+
+// Holiday is a public holiday on a particular date.
+type Holiday struct {
+	Date dt.LocalDate
+	Name string
+
+	// Substitute is true if the holiday is observed on this date because
+	// the actual date of the holiday fell on a weekend.
+	Substitute bool
+}
+
+// Calendar is a named set of holiday rules. Calendars are safe for
+// concurrent use.
+type Calendar struct {
+	name  string
+	rules []Rule
+
+	mutex sync.Mutex
+	years map[int]*yearHolidays
+}
+
+// yearHolidays contains the holidays whose dates fall in a single year.
+type yearHolidays struct {
+	holidays []Holiday
+	dates    map[ymd]bool
+}
+
+// ymd is used as a map key for a local date.
+type ymd struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func toYMD(d dt.LocalDate) ymd {
+	year, month, day := d.Date()
+	return ymd{year: year, month: month, day: day}
+}
+
+// NewCalendar returns a calendar with the name and rules specified.
+func NewCalendar(name string, rules ...Rule) *Calendar {
+	return &Calendar{
+		name:  name,
+		rules: rules,
+	}
+}
+
+// Name returns the name of the calendar.
+func (c *Calendar) Name() string {
+	return c.name
+}
+
+// Rules returns a copy of the rules in the calendar.
+func (c *Calendar) Rules() []Rule {
+	rules := make([]Rule, len(c.rules))
+	copy(rules, c.rules)
+	return rules
+}
+
+// Extend returns a new calendar with the name specified, containing the rules
+// of c and the additional rules. If an additional rule has the same name as
+// a rule in c, it replaces that rule. This is how the calendar for a state
+// is derived from the calendar for its country.
+func (c *Calendar) Extend(name string, rules ...Rule) *Calendar {
+	var combined []Rule
+	for _, rule := range c.rules {
+		if !containsRule(rules, rule.name) {
+			combined = append(combined, rule)
+		}
+	}
+	combined = append(combined, rules...)
+	return NewCalendar(name, combined...)
+}
+
+// without returns a copy of c without the rules with the names specified.
+func (c *Calendar) without(names ...string) *Calendar {
+	var rules []Rule
+	for _, rule := range c.rules {
+		if !containsName(names, rule.name) {
+			rules = append(rules, rule)
+		}
+	}
+	return NewCalendar(c.name, rules...)
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsRule(rules []Rule, name string) bool {
+	for _, rule := range rules {
+		if rule.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Holidays returns the holidays whose dates fall in the year specified,
+// in date order. Substitute holidays are included, as are the actual
+// dates of holidays that fall on a weekend.
+func (c *Calendar) Holidays(year int) []Holiday {
+	holidays := c.year(year).holidays
+	result := make([]Holiday, len(holidays))
+	copy(result, holidays)
+	return result
+}
+
+// HolidaysBetween returns the holidays whose dates fall in the date range r,
+// in date order.
+func (c *Calendar) HolidaysBetween(r dt.DateRange) []Holiday {
+	var result []Holiday
+	if r.IsEmpty() {
+		return result
+	}
+	for year := r.Start().Year(); year <= r.Last().Year(); year++ {
+		for _, h := range c.year(year).holidays {
+			if r.Contains(h.Date) {
+				result = append(result, h)
+			}
+		}
+	}
+	return result
+}
+
+// IsHoliday reports whether d is a holiday, including a substitute holiday.
+// It implements the dt.HolidaySet interface.
+func (c *Calendar) IsHoliday(d dt.LocalDate) bool {
+	return c.year(d.Year()).dates[toYMD(d)]
+}
+
+// minCachedYear and maxCachedYear are the range of years whose holidays are
+// kept by a calendar once calculated. The holidays of other years are
+// calculated each time they are needed, so that checking dates in arbitrary
+// years does not use an unlimited amount of memory.
+const (
+	minCachedYear = 1900
+	maxCachedYear = 2199
+)
+
+// year returns the holidays for the year, calculating them if necessary.
+func (c *Calendar) year(year int) *yearHolidays {
+	if year < minCachedYear || year > maxCachedYear {
+		return c.calculateYear(year)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if yh, ok := c.years[year]; ok {
+		return yh
+	}
+	yh := c.calculateYear(year)
+	if c.years == nil {
+		c.years = make(map[int]*yearHolidays)
+	}
+	c.years[year] = yh
+	return yh
+}
+
+// calculateYear calculates the holidays whose dates fall in the year.
+func (c *Calendar) calculateYear(year int) *yearHolidays {
+	// A substitute holiday can fall in the year before or after the
+	// actual holiday, for example when New Year's Day is on a Saturday.
+	yh := &yearHolidays{dates: make(map[ymd]bool)}
+	for y := year - 1; y <= year+1; y++ {
+		for _, h := range c.calculate(y) {
+			if h.Date.Year() == year {
+				yh.holidays = append(yh.holidays, h)
+				yh.dates[toYMD(h.Date)] = true
+			}
+		}
+	}
+	sort.SliceStable(yh.holidays, func(i, j int) bool {
+		return yh.holidays[i].Date.Before(yh.holidays[j].Date)
+	})
+	return yh
+}
+
+// calculate applies the rules of the calendar for the year, returning the
+// holidays that result. Substitute holidays are allocated in date order of
+// the actual holidays, and are never allocated to a day that is already a
+// holiday.
+func (c *Calendar) calculate(year int) []Holiday {
+	type actual struct {
+		date dt.LocalDate
+		rule Rule
+	}
+	var actuals []actual
+	taken := make(map[ymd]bool)
+	for _, rule := range c.rules {
+		if date, ok := rule.Date(year); ok {
+			actuals = append(actuals, actual{date: date, rule: rule})
+			if !isWeekend(date) {
+				taken[toYMD(date)] = true
+			}
+		}
+	}
+	sort.SliceStable(actuals, func(i, j int) bool {
+		return actuals[i].date.Before(actuals[j].date)
+	})
+
+	isTaken := func(d dt.LocalDate) bool {
+		return taken[toYMD(d)]
+	}
+
+	var holidays []Holiday
+	for _, a := range actuals {
+		holidays = append(holidays, Holiday{Date: a.date, Name: a.rule.name})
+		if a.rule.observance == nil {
+			continue
+		}
+		observed := a.rule.observance(a.date, isTaken)
+		if !observed.Equal(a.date) {
+			holidays = append(holidays, Holiday{
+				Date:       observed,
+				Name:       a.rule.name + " (observed)",
+				Substitute: true,
+			})
+			taken[toYMD(observed)] = true
+		}
+	}
+	return holidays
+}
+
+func isWeekend(d dt.LocalDate) bool {
+	weekday := d.Weekday()
+	return weekday == time.Saturday || weekday == time.Sunday
+}
@@ -0,0 +1,176 @@
+package holiday
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEasterSunday(t *testing.T) {
+	testCases := []struct {
+		Year     int
+		Expected string
+	}{
+		{1818, "1818-03-22"},
+		{1943, "1943-04-25"},
+		{2000, "2000-04-23"},
+		{2019, "2019-04-21"},
+		{2024, "2024-03-31"},
+		{2025, "2025-04-20"},
+		{2038, "2038-04-25"},
+		{2285, "2285-03-22"},
+		{1, "0001-04-01"},
+		{0, "0000-04-09"},
+		{-1, "-0001-04-18"},
+		{-100, "-0100-04-08"},
+		{2000 - 5700000, "-5698000-04-23"},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		d := EasterSunday(tc.Year)
+		assert.Equal(tc.Expected, d.String(), "%d", tc.Year)
+		assert.Equal(time.Sunday, d.Weekday(), "%d", tc.Year)
+	}
+}
+
+func TestRules(t *testing.T) {
+	testCases := []struct {
+		Rule     Rule
+		Year     int
+		Expected string
+	}{
+		{Fixed("a", time.February, 29), 2024, "2024-02-29"},
+		{NthWeekday("a", 1, time.Monday, time.October), 2024, "2024-10-07"},
+		{NthWeekday("a", 1, time.Tuesday, time.October), 2024, "2024-10-01"},
+		{NthWeekday("a", 4, time.Thursday, time.November), 2024, "2024-11-28"},
+		{NthWeekday("a", -1, time.Monday, time.May), 2024, "2024-05-27"},
+		{NthWeekday("a", -1, time.Friday, time.May), 2024, "2024-05-31"},
+		{NthWeekday("a", -2, time.Monday, time.December), 2024, "2024-12-23"},
+		{WeekdayOnOrAfter("a", time.Monday, time.May, 27), 2024, "2024-05-27"},
+		{WeekdayOnOrAfter("a", time.Monday, time.May, 27), 2025, "2025-06-02"},
+		{WeekdayOnOrBefore("a", time.Monday, time.May, 24), 2024, "2024-05-20"},
+		{WeekdayOnOrBefore("a", time.Monday, time.May, 24), 2027, "2027-05-24"},
+		{Easter("a", -2), 2024, "2024-03-29"},
+		{Easter("a", 1), 2024, "2024-04-01"},
+		{Fixed("a", time.May, 1).Except(dt.Date(2024, time.May, 2)), 2024, "2024-05-02"},
+		{Fixed("a", time.May, 1).Except(dt.Date(2024, time.May, 2)), 2025, "2025-05-01"},
+		{Fixed("a", time.May, 1).From(2000), 1999, ""},
+		{Fixed("a", time.May, 1).Until(2000), 2001, ""},
+		{Fixed("a", time.May, 1).From(2000).Until(2000), 2000, "2000-05-01"},
+		{Dates("a", dt.Date(2011, time.April, 29)), 2011, "2011-04-29"},
+		{Dates("a", dt.Date(2011, time.April, 29)), 2012, ""},
+	}
+	assert := assert.New(t)
+
+	for i, tc := range testCases {
+		d, ok := tc.Rule.Date(tc.Year)
+		if tc.Expected == "" {
+			assert.False(ok, "%d", i)
+		} else {
+			assert.True(ok, "%d", i)
+			assert.Equal(tc.Expected, d.String(), "%d", i)
+		}
+	}
+}
+
+func TestObservance(t *testing.T) {
+	testCases := []struct {
+		Observance Observance
+		Date       string
+		Expected   string
+	}{
+		{MondayIfWeekend, "2024-12-25", "2024-12-25"},
+		{MondayIfWeekend, "2022-12-24", "2022-12-27"},
+		{MondayIfWeekend, "2022-12-25", "2022-12-27"},
+		{MondayIfSunday, "2022-12-24", "2022-12-24"},
+		{MondayIfSunday, "2022-12-25", "2022-12-27"},
+		{NearestWeekday, "2022-12-24", "2022-12-23"},
+		{NearestWeekday, "2022-12-25", "2022-12-26"},
+		{NearestWeekday, "2024-12-25", "2024-12-25"},
+	}
+	assert := assert.New(t)
+
+	// Monday 26 December 2022 is already a holiday
+	taken := func(d dt.LocalDate) bool {
+		return d.Equal(dt.Date(2022, time.December, 26))
+	}
+	for _, tc := range testCases {
+		actual := tc.Observance(dt.MustParseDate(tc.Date), taken)
+		assert.Equal(tc.Expected, actual.String(), tc.Date)
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	assert := assert.New(t)
+	c := NewCalendar("Test",
+		Fixed("Christmas Day", time.December, 25).Observed(MondayIfWeekend),
+		Fixed("Boxing Day", time.December, 26).Observed(MondayIfWeekend),
+		Fixed("New Year's Day", time.January, 1).Observed(NearestWeekday),
+	)
+	assert.Equal("Test", c.Name())
+	assert.Len(c.Rules(), 3)
+
+	// Christmas on Saturday and Boxing Day on Sunday, and New Year's Day
+	// 2022 on Saturday is observed in 2021
+	assert.Equal([]Holiday{
+		{Date: dt.Date(2021, time.January, 1), Name: "New Year's Day"},
+		{Date: dt.Date(2021, time.December, 25), Name: "Christmas Day"},
+		{Date: dt.Date(2021, time.December, 26), Name: "Boxing Day"},
+		{Date: dt.Date(2021, time.December, 27), Name: "Christmas Day (observed)", Substitute: true},
+		{Date: dt.Date(2021, time.December, 28), Name: "Boxing Day (observed)", Substitute: true},
+		{Date: dt.Date(2021, time.December, 31), Name: "New Year's Day (observed)", Substitute: true},
+	}, c.Holidays(2021))
+
+	assert.True(c.IsHoliday(dt.Date(2021, time.December, 31)))
+	assert.True(c.IsHoliday(dt.Date(2021, time.December, 28)))
+	assert.False(c.IsHoliday(dt.Date(2021, time.December, 29)))
+	assert.False(c.IsHoliday(dt.Date(2022, time.December, 30)))
+
+	between := c.HolidaysBetween(dt.MustParseDateRange("2021-12-27/2022-01-02"))
+	assert.Len(between, 4)
+	assert.Equal("2021-12-27", between[0].Date.String())
+	assert.Equal("2022-01-01", between[3].Date.String())
+	assert.Empty(c.HolidaysBetween(dt.MustParseDateRange("2021-12-29/2021-12-31")))
+
+	// modifying the result does not affect the calendar
+	holidays := c.Holidays(2021)
+	holidays[0].Name = "modified"
+	assert.Equal("New Year's Day", c.Holidays(2021)[0].Name)
+
+	extended := c.Extend("Extended",
+		Fixed("Boxing Day", time.December, 26),
+		Fixed("Christmas Eve", time.December, 24),
+	)
+	assert.Equal("Extended", extended.Name())
+	assert.Len(extended.Rules(), 4)
+	assert.True(extended.IsHoliday(dt.Date(2021, time.December, 24)))
+	assert.True(extended.IsHoliday(dt.Date(2021, time.December, 27)))
+	assert.False(extended.IsHoliday(dt.Date(2021, time.December, 28)))
+	assert.False(c.IsHoliday(dt.Date(2021, time.December, 24)))
+
+	// years outside the cached range are calculated but not kept
+	cached := len(c.years)
+	assert.True(c.IsHoliday(dt.Date(5000, time.December, 25)))
+	assert.True(c.IsHoliday(dt.Date(-5000, time.December, 25)))
+	assert.Equal("1899-01-01", c.Holidays(1899)[0].Date.String())
+	assert.Len(c.years, cached)
+}
+
+func TestCalendarBusinessDays(t *testing.T) {
+	assert := assert.New(t)
+	nsw, _ := Lookup("AU-NSW")
+	vic, _ := Lookup("AU-VIC")
+
+	bc := dt.NewBusinessCalendar(nsw)
+	assert.False(bc.IsBusinessDay(dt.Date(2022, time.December, 27)))
+	assert.Equal("2022-12-28", bc.NextBusinessDay(dt.Date(2022, time.December, 23)).String())
+	assert.Equal("2024-04-02", bc.AddBusinessDays(dt.Date(2024, time.March, 28), 1).String())
+
+	// Melbourne Cup is a holiday in Victoria but not in New South Wales
+	cup := dt.Date(2024, time.November, 5)
+	assert.True(bc.IsBusinessDay(cup))
+	assert.False(bc.WithHolidays(vic).IsBusinessDay(cup))
+}
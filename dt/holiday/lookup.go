@@ -0,0 +1,47 @@
+package holiday
+
+import (
+	"sort"
+	"strings"
+)
+
+// calendars contains the built-in calendars, keyed by code.
+var calendars = map[string]*Calendar{
+	"AU":      au,
+	"AU-ACT":  auACT,
+	"AU-NSW":  auNSW,
+	"AU-NT":   auNT,
+	"AU-QLD":  auQLD,
+	"AU-SA":   auSA,
+	"AU-TAS":  auTAS,
+	"AU-VIC":  auVIC,
+	"AU-WA":   auWA,
+	"CA":      ca,
+	"GB":      gb,
+	"GB-NIR":  gbNIR,
+	"GB-SCT":  gbSCT,
+	"NZ":      nz,
+	"TARGET2": target,
+	"US":      us,
+	"US-NY":   usNY,
+}
+
+// Lookup returns the built-in calendar for a code. Codes are ISO 3166
+// country codes, optionally followed by a hyphen and a subdivision code,
+// such as "AU-NSW" for New South Wales. The calendar for "GB" contains the
+// bank holidays of England and Wales. The code "TARGET2" returns the closing
+// days of the Eurozone TARGET2 payment system. Codes are not case sensitive.
+func Lookup(code string) (*Calendar, bool) {
+	c, ok := calendars[strings.ToUpper(strings.TrimSpace(code))]
+	return c, ok
+}
+
+// Codes returns the codes of the built-in calendars in sorted order.
+func Codes() []string {
+	codes := make([]string, 0, len(calendars))
+	for code := range calendars {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
@@ -0,0 +1,62 @@
+package holiday
+
+import (
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+)
+
+// nz contains the national public holidays of New Zealand. Regional
+// anniversary days are not included.
+var nz = NewCalendar("New Zealand",
+	Fixed("New Year's Day", time.January, 1).Observed(MondayIfWeekend),
+	Fixed("Day after New Year's Day", time.January, 2).Observed(MondayIfWeekend),
+	Fixed("Waitangi Day", time.February, 6).Until(2013),
+	Fixed("Waitangi Day", time.February, 6).From(2014).Observed(MondayIfWeekend),
+	Easter("Good Friday", -2),
+	Easter("Easter Monday", 1),
+	Fixed("Anzac Day", time.April, 25).Until(2013),
+	Fixed("Anzac Day", time.April, 25).From(2014).Observed(MondayIfWeekend),
+	NthWeekday("Queen's Birthday", 1, time.Monday, time.June).Until(2022),
+	NthWeekday("King's Birthday", 1, time.Monday, time.June).From(2023),
+	matariki,
+	NthWeekday("Labour Day", 4, time.Monday, time.October),
+	Fixed("Christmas Day", time.December, 25).Observed(MondayIfWeekend),
+	Fixed("Boxing Day", time.December, 26).Observed(MondayIfWeekend),
+)
+
+// matariki is the Matariki public holiday, whose dates are set in
+// advance by the Te Kāhui o Matariki Public Holiday Act 2022.
+var matariki = Dates("Matariki",
+	dt.Date(2022, time.June, 24),
+	dt.Date(2023, time.July, 14),
+	dt.Date(2024, time.June, 28),
+	dt.Date(2025, time.June, 20),
+	dt.Date(2026, time.July, 10),
+	dt.Date(2027, time.June, 25),
+	dt.Date(2028, time.July, 14),
+	dt.Date(2029, time.July, 6),
+	dt.Date(2030, time.June, 21),
+	dt.Date(2031, time.July, 11),
+	dt.Date(2032, time.July, 2),
+	dt.Date(2033, time.June, 24),
+	dt.Date(2034, time.July, 7),
+	dt.Date(2035, time.June, 29),
+	dt.Date(2036, time.July, 18),
+	dt.Date(2037, time.July, 10),
+	dt.Date(2038, time.June, 25),
+	dt.Date(2039, time.July, 15),
+	dt.Date(2040, time.July, 6),
+	dt.Date(2041, time.July, 19),
+	dt.Date(2042, time.July, 11),
+	dt.Date(2043, time.July, 3),
+	dt.Date(2044, time.June, 24),
+	dt.Date(2045, time.July, 7),
+	dt.Date(2046, time.June, 29),
+	dt.Date(2047, time.July, 19),
+	dt.Date(2048, time.July, 3),
+	dt.Date(2049, time.June, 25),
+	dt.Date(2050, time.July, 15),
+	dt.Date(2051, time.June, 30),
+	dt.Date(2052, time.June, 21),
+)
@@ -0,0 +1,246 @@
+package holiday
+
+import (
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+)
+
+// Rule calculates the date of a named holiday in any given year.
+// Rules are created using the functions in this package, such as
+// Fixed, NthWeekday and Easter, and can then be modified with the
+// Observed, From and Until methods.
+type Rule struct {
+	name       string
+	date       func(year int) (dt.LocalDate, bool)
+	observance Observance
+	from       int
+	until      int
+}
+
+// Name returns the name of the holiday.
+func (r Rule) Name() string {
+	return r.name
+}
+
+// Date returns the actual date of the holiday in the year specified, before
+// any substitution for a weekend. If the holiday does not occur in that year,
+// the result is false.
+func (r Rule) Date(year int) (dt.LocalDate, bool) {
+	if (r.from != 0 && year < r.from) || (r.until != 0 && year > r.until) {
+		return dt.LocalDate{}, false
+	}
+	return r.date(year)
+}
+
+// Observed returns a copy of r that uses the observance o to determine
+// the date of a substitute holiday when the holiday falls on a weekend.
+func (r Rule) Observed(o Observance) Rule {
+	r.observance = o
+	return r
+}
+
+// From returns a copy of r that only applies from the year specified.
+func (r Rule) From(year int) Rule {
+	r.from = year
+	return r
+}
+
+// Until returns a copy of r that only applies up to and including the year specified.
+func (r Rule) Until(year int) Rule {
+	r.until = year
+	return r
+}
+
+// Fixed returns a rule for a holiday that occurs on the same month and day every year.
+func Fixed(name string, month time.Month, day int) Rule {
+	return Rule{
+		name: name,
+		date: func(year int) (dt.LocalDate, bool) {
+			return dt.Date(year, month, day), true
+		},
+	}
+}
+
+// NthWeekday returns a rule for a holiday that occurs on the nth weekday
+// of a month, for example the second Monday in June. If n is negative, it
+// counts from the end of the month, so -1 is the last weekday in the month.
+func NthWeekday(name string, n int, weekday time.Weekday, month time.Month) Rule {
+	return Rule{
+		name: name,
+		date: func(year int) (dt.LocalDate, bool) {
+			return nthWeekday(year, month, n, weekday), true
+		},
+	}
+}
+
+// nthWeekday returns the nth weekday of the month.
+func nthWeekday(year int, month time.Month, n int, weekday time.Weekday) dt.LocalDate {
+	if n < 0 {
+		last := dt.Date(year, month+1, 0)
+		offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+		return last.AddDate(0, 0, -offset+(n+1)*7)
+	}
+	first := dt.Date(year, month, 1)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+(n-1)*7)
+}
+
+// WeekdayOnOrAfter returns a rule for a holiday that occurs on the first
+// weekday on or after a month and day, for example the Monday on or after 27 May.
+func WeekdayOnOrAfter(name string, weekday time.Weekday, month time.Month, day int) Rule {
+	return Rule{
+		name: name,
+		date: func(year int) (dt.LocalDate, bool) {
+			d := dt.Date(year, month, day)
+			offset := (int(weekday) - int(d.Weekday()) + 7) % 7
+			return d.AddDate(0, 0, offset), true
+		},
+	}
+}
+
+// WeekdayOnOrBefore returns a rule for a holiday that occurs on the last
+// weekday on or before a month and day, for example the Monday on or before 24 May.
+func WeekdayOnOrBefore(name string, weekday time.Weekday, month time.Month, day int) Rule {
+	return Rule{
+		name: name,
+		date: func(year int) (dt.LocalDate, bool) {
+			d := dt.Date(year, month, day)
+			offset := (int(d.Weekday()) - int(weekday) + 7) % 7
+			return d.AddDate(0, 0, -offset), true
+		},
+	}
+}
+
+// Easter returns a rule for a holiday that occurs a number of days before
+// or after Easter Sunday, for example -2 for Good Friday and 1 for Easter Monday.
+func Easter(name string, days int) Rule {
+	return Rule{
+		name: name,
+		date: func(year int) (dt.LocalDate, bool) {
+			return EasterSunday(year).AddDate(0, 0, days), true
+		},
+	}
+}
+
+// Dates returns a rule for a holiday that occurs on specific dates, such as a
+// one-off holiday for a royal wedding, or a holiday whose date is proclaimed
+// each year. There should be no more than one date in any year.
+func Dates(name string, dates ...dt.LocalDate) Rule {
+	byYear := make(map[int]dt.LocalDate, len(dates))
+	for _, d := range dates {
+		byYear[d.Year()] = d
+	}
+	return Rule{
+		name: name,
+		date: func(year int) (dt.LocalDate, bool) {
+			d, ok := byYear[year]
+			return d, ok
+		},
+	}
+}
+
+// Except returns a copy of r that occurs on the dates specified instead of
+// the date calculated by r, in the years of those dates. It is used when a
+// holiday is moved for a single year.
+func (r Rule) Except(dates ...dt.LocalDate) Rule {
+	exceptions := make(map[int]dt.LocalDate, len(dates))
+	for _, d := range dates {
+		exceptions[d.Year()] = d
+	}
+	date := r.date
+	r.date = func(year int) (dt.LocalDate, bool) {
+		if d, ok := exceptions[year]; ok {
+			return d, true
+		}
+		return date(year)
+	}
+	return r
+}
+
+// EasterSunday returns the date of Easter Sunday in the Gregorian calendar
+// for the year specified, calculated using the anonymous Gregorian algorithm.
+// Years before 1 are in the proleptic Gregorian calendar, where year 0 is
+// 1 BC.
+func EasterSunday(year int) dt.LocalDate {
+	// the dates repeat every 5,700,000 years, so the algorithm is applied to
+	// a year that is not negative, which it requires
+	y := year % easterCycle
+	if y < 0 {
+		y += easterCycle
+	}
+	a := y % 19
+	b := y / 100
+	c := y % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return dt.Date(year, time.Month(month), day)
+}
+
+// easterCycle is the number of years after which the dates of Easter repeat
+// in the Gregorian calendar.
+const easterCycle = 5700000
+
+// Observance determines the date on which a holiday is observed when it falls on
+// a weekend. It is passed the actual date of the holiday and a function that
+// reports whether a date is already a holiday, and returns the date on which
+// the holiday is observed. If the result is the actual date, there is no
+// substitute holiday.
+type Observance func(actual dt.LocalDate, taken func(dt.LocalDate) bool) dt.LocalDate
+
+// MondayIfWeekend is an Observance for a holiday that is observed on the
+// following Monday if it falls on a Saturday or Sunday. If the Monday is
+// already a holiday, the holiday is observed on the next weekday that is not
+// a holiday. This is sometimes known as "Mondayising" a holiday, and is how
+// Christmas Day and Boxing Day are observed in Australia, New Zealand and
+// the United Kingdom.
+func MondayIfWeekend(actual dt.LocalDate, taken func(dt.LocalDate) bool) dt.LocalDate {
+	if !isWeekend(actual) {
+		return actual
+	}
+	return nextFreeWeekday(actual, taken)
+}
+
+// MondayIfSunday is an Observance for a holiday that is observed on the
+// following Monday if it falls on a Sunday, but is not observed on a weekday
+// if it falls on a Saturday. If the Monday is already a holiday, the holiday
+// is observed on the next weekday that is not a holiday.
+func MondayIfSunday(actual dt.LocalDate, taken func(dt.LocalDate) bool) dt.LocalDate {
+	if actual.Weekday() != time.Sunday {
+		return actual
+	}
+	return nextFreeWeekday(actual, taken)
+}
+
+// NearestWeekday is an Observance for a holiday that is observed on the
+// preceding Friday if it falls on a Saturday, and on the following Monday if
+// it falls on a Sunday. This is how federal holidays are observed in the
+// United States.
+func NearestWeekday(actual dt.LocalDate, taken func(dt.LocalDate) bool) dt.LocalDate {
+	switch actual.Weekday() {
+	case time.Saturday:
+		return actual.AddDate(0, 0, -1)
+	case time.Sunday:
+		return actual.AddDate(0, 0, 1)
+	}
+	return actual
+}
+
+// nextFreeWeekday returns the first weekday after d that is not taken.
+func nextFreeWeekday(d dt.LocalDate, taken func(dt.LocalDate) bool) dt.LocalDate {
+	for {
+		d = d.AddDate(0, 0, 1)
+		if !isWeekend(d) && !taken(d) {
+			return d
+		}
+	}
+}
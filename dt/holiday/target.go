@@ -0,0 +1,14 @@
+package holiday
+
+import "time"
+
+// target contains the closing days of the TARGET2 payment system used
+// in the Eurozone. Closing days that fall on a weekend are not moved.
+var target = NewCalendar("TARGET2",
+	Fixed("New Year's Day", time.January, 1),
+	Easter("Good Friday", -2),
+	Easter("Easter Monday", 1),
+	Fixed("Labour Day", time.May, 1),
+	Fixed("Christmas Day", time.December, 25),
+	Fixed("Christmas Holiday", time.December, 26),
+)
@@ -0,0 +1,26 @@
+package holiday
+
+import "time"
+
+// us contains the federal holidays of the United States.
+var us = NewCalendar("United States",
+	Fixed("New Year's Day", time.January, 1).Observed(NearestWeekday),
+	NthWeekday("Birthday of Martin Luther King, Jr.", 3, time.Monday, time.January).From(1986),
+	NthWeekday("Washington's Birthday", 3, time.Monday, time.February),
+	NthWeekday("Memorial Day", -1, time.Monday, time.May),
+	Fixed("Juneteenth National Independence Day", time.June, 19).From(2021).Observed(NearestWeekday),
+	Fixed("Independence Day", time.July, 4).Observed(NearestWeekday),
+	NthWeekday("Labor Day", 1, time.Monday, time.September),
+	NthWeekday("Columbus Day", 2, time.Monday, time.October),
+	Fixed("Veterans Day", time.November, 11).Observed(NearestWeekday),
+	NthWeekday("Thanksgiving Day", 4, time.Thursday, time.November),
+	Fixed("Christmas Day", time.December, 25).Observed(NearestWeekday),
+)
+
+// usNY contains the public holidays of the State of New York.
+var usNY = us.Extend("New York",
+	Fixed("Lincoln's Birthday", time.February, 12).Observed(MondayIfSunday),
+
+	// Election Day is the Tuesday after the first Monday in November.
+	WeekdayOnOrAfter("Election Day", time.Tuesday, time.November, 2),
+)
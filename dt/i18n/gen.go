@@ -0,0 +1,213 @@
+//go:build ignore
+
+// This program generates tables.go from the JSON distribution of the Unicode
+// CLDR (https://github.com/unicode-org/cldr-json). It reads the Gregorian
+// calendar data of the cldr-dates-full package, which contains fully
+// resolved data for each locale:
+//
+//	go run gen.go -cldr /path/to/cldr-json
+//
+// Local date-times have no time zone, so time zone fields are removed from
+// the time patterns.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	cldrDir = flag.String("cldr", "", "directory containing the CLDR JSON packages")
+	output  = flag.String("output", "tables.go", "output file")
+	locales = flag.String("locales", "de,en,en-GB,es,fr,it,ja,nl,pt,zh", "comma-separated locales to generate")
+)
+
+// styles are the CLDR names of the styles, in the order of i18n.Style.
+var styles = []string{"short", "medium", "long", "full"}
+
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+type widths struct {
+	Abbreviated map[string]string `json:"abbreviated"`
+	Wide        map[string]string `json:"wide"`
+}
+
+type context struct {
+	Format     widths `json:"format"`
+	StandAlone widths `json:"stand-alone"`
+}
+
+type gregorian struct {
+	Months     context `json:"months"`
+	Days       context `json:"days"`
+	DayPeriods context `json:"dayPeriods"`
+	Eras       struct {
+		Abbr map[string]string `json:"eraAbbr"`
+	} `json:"eras"`
+	DateFormats     map[string]json.RawMessage `json:"dateFormats"`
+	TimeFormats     map[string]json.RawMessage `json:"timeFormats"`
+	DateTimeFormats map[string]json.RawMessage `json:"dateTimeFormats"`
+}
+
+type caGregorian struct {
+	Main map[string]struct {
+		Dates struct {
+			Calendars struct {
+				Gregorian gregorian `json:"gregorian"`
+			} `json:"calendars"`
+		} `json:"dates"`
+	} `json:"main"`
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen: ")
+	flag.Parse()
+	if *cldrDir == "" {
+		log.Fatal("the -cldr flag is required")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen.go from CLDR JSON data; DO NOT EDIT.\n\n")
+	buf.WriteString("package i18n\n\n")
+	buf.WriteString("var locales = map[string]*localeData{\n")
+	for _, tag := range strings.Split(*locales, ",") {
+		g, err := load(tag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := writeLocale(&buf, tag, g); err != nil {
+			log.Fatalf("%s: %v", tag, err)
+		}
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func load(tag string) (*gregorian, error) {
+	data, err := os.ReadFile(filepath.Join(*cldrDir, "cldr-dates-full", "main", tag, "ca-gregorian.json"))
+	if err != nil {
+		return nil, err
+	}
+	var ca caGregorian
+	if err := json.Unmarshal(data, &ca); err != nil {
+		return nil, fmt.Errorf("%s: %w", tag, err)
+	}
+	main, ok := ca.Main[tag]
+	if !ok {
+		return nil, fmt.Errorf("%s: no data for locale", tag)
+	}
+	return &main.Dates.Calendars.Gregorian, nil
+}
+
+func writeLocale(buf *bytes.Buffer, tag string, g *gregorian) error {
+	months := func(m map[string]string) []string {
+		var names []string
+		for i := 1; i <= 12; i++ {
+			names = append(names, m[fmt.Sprint(i)])
+		}
+		return names
+	}
+	days := func(m map[string]string) []string {
+		var names []string
+		for _, day := range weekdays {
+			names = append(names, m[day])
+		}
+		return names
+	}
+	patterns := func(m map[string]json.RawMessage, fix func(string) string) ([]string, error) {
+		var list []string
+		for _, style := range styles {
+			var pattern string
+			if err := json.Unmarshal(m[style], &pattern); err != nil {
+				return nil, fmt.Errorf("%s pattern: %w", style, err)
+			}
+			list = append(list, fix(pattern))
+		}
+		return list, nil
+	}
+	same := func(s string) string { return s }
+
+	dates, err := patterns(g.DateFormats, same)
+	if err != nil {
+		return err
+	}
+	times, err := patterns(g.TimeFormats, stripZone)
+	if err != nil {
+		return err
+	}
+	dateTimes, err := patterns(g.DateTimeFormats, same)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(buf, "%q: {\n", tag)
+	writeList(buf, "months", months(g.Months.Format.Wide))
+	writeList(buf, "shortMonths", months(g.Months.Format.Abbreviated))
+	writeList(buf, "standAloneMonths", months(g.Months.StandAlone.Wide))
+	writeList(buf, "standAloneShortMonths", months(g.Months.StandAlone.Abbreviated))
+	writeList(buf, "weekdays", days(g.Days.Format.Wide))
+	writeList(buf, "shortWeekdays", days(g.Days.Format.Abbreviated))
+	writeList(buf, "dayPeriods", []string{g.DayPeriods.Format.Abbreviated["am"], g.DayPeriods.Format.Abbreviated["pm"]})
+	writeList(buf, "eras", []string{g.Eras.Abbr["0"], g.Eras.Abbr["1"]})
+	writeList(buf, "datePatterns", dates)
+	writeList(buf, "timePatterns", times)
+	writeList(buf, "dateTimePatterns", dateTimes)
+	buf.WriteString("},\n")
+	return nil
+}
+
+func writeList(buf *bytes.Buffer, name string, list []string) {
+	fmt.Fprintf(buf, "%s: [%d]string{", name, len(list))
+	for i, s := range list {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(buf, "%q", s)
+	}
+	buf.WriteString("},\n")
+}
+
+// stripZone removes the time zone fields from a time pattern, along with
+// the space or parentheses around them.
+func stripZone(pattern string) string {
+	const zone = "\x00"
+	var sb strings.Builder
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		switch {
+		case c == '\'':
+			j := i + 1
+			for j < len(pattern) && pattern[j] != '\'' {
+				j++
+			}
+			sb.WriteString(pattern[i:min(j+1, len(pattern))])
+			i = j + 1
+		case strings.IndexByte("zZvVOXx", c) >= 0:
+			for i < len(pattern) && pattern[i] == c {
+				i++
+			}
+			sb.WriteString(zone)
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	s := strings.ReplaceAll(sb.String(), "("+zone+")", zone)
+	s = strings.NewReplacer(" "+zone, "", zone+" ", "", zone, "").Replace(s)
+	return strings.TrimSpace(s)
+}
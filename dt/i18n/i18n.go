@@ -0,0 +1,234 @@
+// Package i18n formats local dates and times for a locale, using the date
+// patterns and the names of months and days from the Unicode Common Locale
+// Data Repository (CLDR).
+//
+// The CLDR data for a subset of locales is built in, and a Locale is found
+// using Lookup. The data is generated from the JSON distribution of the
+// CLDR by gen.go. To regenerate it, download the cldr-json repository and run
+//
+//	CLDR_JSON=/path/to/cldr-json go generate
+//
+// in this directory.
+package i18n
+
+//go:generate go run gen.go -cldr $CLDR_JSON -output tables.go
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jjeffery/goda/dt"
+)
+
+// Style is the length of a formatted date or time. The styles are those
+// of the CLDR: for example, in English the date styles are "10/17/26",
+// "Oct 17, 2026", "October 17, 2026" and "Saturday, October 17, 2026".
+type Style int
+
+// Styles in order of increasing length.
+const (
+	Short Style = iota
+	Medium
+	Long
+	Full
+)
+
+// String returns the name of the style.
+func (s Style) String() string {
+	switch s {
+	case Short:
+		return "short"
+	case Medium:
+		return "medium"
+	case Long:
+		return "long"
+	case Full:
+		return "full"
+	}
+	return "unknown"
+}
+
+// index returns the index of the style in the pattern tables. An unknown
+// style is treated as Medium.
+func (s Style) index() int {
+	if s < Short || s > Full {
+		return int(Medium)
+	}
+	return int(s)
+}
+
+// localeData contains the CLDR data for a locale.
+type localeData struct {
+	months                [12]string
+	shortMonths           [12]string
+	standAloneMonths      [12]string
+	standAloneShortMonths [12]string
+	weekdays              [7]string // starting with Sunday
+	shortWeekdays         [7]string
+	dayPeriods            [2]string // AM, PM
+	eras                  [2]string // BC, AD
+
+	// patterns are indexed by style
+	datePatterns     [4]string
+	timePatterns     [4]string
+	dateTimePatterns [4]string // {1} is the date and {0} is the time
+}
+
+// Locale formats dates and times for a language and region.
+// Locales are safe for concurrent use.
+type Locale struct {
+	tag  string
+	data *localeData
+}
+
+// Lookup returns the built-in locale for a BCP 47 language tag, such as
+// "de" or "en-GB". If there is no data for the tag, subtags are removed
+// from the end of the tag until a locale is found, so that "de-AT" returns
+// the locale for "de". Tags are not case sensitive, and underscores can be
+// used instead of hyphens.
+func Lookup(tag string) (*Locale, bool) {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	for tag != "" {
+		for t, data := range locales {
+			if strings.EqualFold(t, tag) {
+				return &Locale{tag: t, data: data}, true
+			}
+		}
+		i := strings.LastIndexByte(tag, '-')
+		if i < 0 {
+			break
+		}
+		tag = tag[:i]
+	}
+	return nil, false
+}
+
+// Tags returns the tags of the built-in locales in sorted order.
+func Tags() []string {
+	tags := make([]string, 0, len(locales))
+	for tag := range locales {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Tag returns the tag of the locale, which may be less specific than the
+// tag passed to Lookup.
+func (l *Locale) Tag() string {
+	return l.tag
+}
+
+// FormatDate formats a date in a style, such as "17. Oktober 2026" for the
+// long style in German.
+func (l *Locale) FormatDate(d dt.LocalDate, style Style) string {
+	return l.Format(d.At(dt.LocalTime{}), l.data.datePatterns[style.index()])
+}
+
+// FormatTime formats a time of day in a style. Local times have no time
+// zone, so the long and full styles omit the time zone, and are usually
+// the same as the medium style.
+func (l *Locale) FormatTime(t dt.LocalTime, style Style) string {
+	return l.Format(dt.Date(2000, time.January, 1).At(t), l.data.timePatterns[style.index()])
+}
+
+// FormatDateTime formats a date-time using a date style and a time style.
+// The date style determines how the date and time are combined, such as
+// "October 17, 2026 at 9:30:00 AM".
+func (l *Locale) FormatDateTime(t dt.LocalDateTime, dateStyle Style, timeStyle Style) string {
+	pattern := strings.NewReplacer(
+		"{1}", l.data.datePatterns[dateStyle.index()],
+		"{0}", l.data.timePatterns[timeStyle.index()],
+	).Replace(l.data.dateTimePatterns[dateStyle.index()])
+	return l.Format(t, pattern)
+}
+
+// Format formats a date-time using an LDML date pattern (see
+// dt.LocalDateTime.Format), with the names of months, days, day periods and
+// eras in the language of the locale. Eras are always abbreviated, and
+// quarters are not localized.
+func (l *Locale) Format(t dt.LocalDateTime, pattern string) string {
+	return t.Format(l.localize(t, pattern))
+}
+
+// localize returns the pattern with the names in it replaced by quoted
+// literal text in the language of the locale.
+func (l *Locale) localize(t dt.LocalDateTime, pattern string) string {
+	var sb strings.Builder
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		if c == '\'' {
+			// copy quoted text, which ends at a single quote
+			j := i + 1
+			for j < len(pattern) && pattern[j] != '\'' {
+				j++
+			}
+			j = min(j+1, len(pattern))
+			sb.WriteString(pattern[i:j])
+			i = j
+			continue
+		}
+		n := 1
+		for i+n < len(pattern) && pattern[i+n] == c {
+			n++
+		}
+		if name, ok := l.name(t, c, n); ok {
+			sb.WriteByte('\'')
+			sb.WriteString(strings.ReplaceAll(name, "'", "''"))
+			sb.WriteByte('\'')
+		} else {
+			sb.WriteString(pattern[i : i+n])
+		}
+		i += n
+	}
+	return sb.String()
+}
+
+// name returns the localized text of a field of n letters, or false if
+// the field is numeric or is not localized.
+func (l *Locale) name(t dt.LocalDateTime, letter byte, n int) (string, bool) {
+	d := l.data
+	month := t.Month() - 1
+	weekday := t.Weekday()
+	switch letter {
+	case 'M', 'L':
+		if n < 3 {
+			return "", false
+		}
+		if letter == 'M' {
+			return width(n, d.shortMonths[month], d.months[month], d.standAloneShortMonths[month]), true
+		}
+		return width(n, d.standAloneShortMonths[month], d.standAloneMonths[month], d.standAloneShortMonths[month]), true
+	case 'e', 'c':
+		if n < 3 {
+			return "", false
+		}
+		return width(n, d.shortWeekdays[weekday], d.weekdays[weekday], d.shortWeekdays[weekday]), true
+	case 'E':
+		return width(n, d.shortWeekdays[weekday], d.weekdays[weekday], d.shortWeekdays[weekday]), true
+	case 'a':
+		return d.dayPeriods[t.Hour()/12], true
+	case 'G':
+		if t.Year() <= 0 {
+			return d.eras[0], true
+		}
+		return d.eras[1], true
+	}
+	return "", false
+}
+
+// width chooses the abbreviated, wide or narrow form of a name for a field
+// of n letters. The narrow form is the first letter of short, in upper case.
+func width(n int, abbreviated, wide, short string) string {
+	switch n {
+	case 4:
+		return wide
+	case 5:
+		r, _ := utf8.DecodeRuneInString(short)
+		return string(unicode.ToUpper(r))
+	}
+	return abbreviated
+}
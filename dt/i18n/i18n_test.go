@@ -0,0 +1,148 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/jjeffery/goda/dt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+		ok   bool
+	}{
+		{tag: "en", want: "en", ok: true},
+		{tag: "en-GB", want: "en-GB", ok: true},
+		{tag: "en_gb", want: "en-GB", ok: true},
+		{tag: "EN-US", want: "en", ok: true},
+		{tag: "de-AT", want: "de", ok: true},
+		{tag: "zh-Hans-CN", want: "zh", ok: true},
+		{tag: " ja ", want: "ja", ok: true},
+		{tag: "xx-YY", ok: false},
+		{tag: "", ok: false},
+	}
+	for _, tt := range tests {
+		l, ok := Lookup(tt.tag)
+		assert.Equal(t, tt.ok, ok, tt.tag)
+		if ok {
+			assert.Equal(t, tt.want, l.Tag(), tt.tag)
+		}
+	}
+}
+
+func TestTags(t *testing.T) {
+	tags := Tags()
+	assert.Contains(t, tags, "en")
+	assert.Contains(t, tags, "de")
+	assert.Contains(t, tags, "ja")
+	assert.IsIncreasing(t, tags)
+}
+
+func TestFormatDate(t *testing.T) {
+	d := dt.Date(2026, 10, 17)
+	tests := []struct {
+		tag   string
+		style Style
+		want  string
+	}{
+		{"en", Short, "10/17/26"},
+		{"en", Medium, "Oct 17, 2026"},
+		{"en", Long, "October 17, 2026"},
+		{"en", Full, "Saturday, October 17, 2026"},
+		{"en-GB", Short, "17/10/2026"},
+		{"en-GB", Medium, "17 Oct 2026"},
+		{"en-GB", Long, "17 October 2026"},
+		{"en-GB", Full, "Saturday 17 October 2026"},
+		{"de", Short, "17.10.26"},
+		{"de", Medium, "17.10.2026"},
+		{"de", Long, "17. Oktober 2026"},
+		{"de", Full, "Samstag, 17. Oktober 2026"},
+		{"es", Long, "17 de octubre de 2026"},
+		{"es", Full, "sábado, 17 de octubre de 2026"},
+		{"fr", Medium, "17 oct. 2026"},
+		{"fr", Full, "samedi 17 octobre 2026"},
+		{"it", Full, "sabato 17 ottobre 2026"},
+		{"nl", Short, "17-10-2026"},
+		{"nl", Medium, "17 okt 2026"},
+		{"pt", Medium, "17 de out. de 2026"},
+		{"ja", Medium, "2026/10/17"},
+		{"ja", Long, "2026年10月17日"},
+		{"ja", Full, "2026年10月17日土曜日"},
+		{"zh", Short, "2026/10/17"},
+		{"zh", Full, "2026年10月17日星期六"},
+		{"en", Style(99), "Oct 17, 2026"},
+	}
+	for _, tt := range tests {
+		l, ok := Lookup(tt.tag)
+		if assert.True(t, ok, tt.tag) {
+			assert.Equal(t, tt.want, l.FormatDate(d, tt.style), "%s %v", tt.tag, tt.style)
+		}
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	tm := dt.TimeOfDay(14, 5, 9, 0)
+	tests := []struct {
+		tag   string
+		style Style
+		want  string
+	}{
+		{"en", Short, "2:05\u202fPM"},
+		{"en", Full, "2:05:09\u202fPM"},
+		{"en-GB", Short, "14:05"},
+		{"es", Medium, "14:05:09"},
+		{"ja", Full, "14時05分09秒"},
+		{"zh", Full, "14:05:09"},
+	}
+	for _, tt := range tests {
+		l, _ := Lookup(tt.tag)
+		assert.Equal(t, tt.want, l.FormatTime(tm, tt.style), "%s %v", tt.tag, tt.style)
+	}
+}
+
+func TestFormatDateTime(t *testing.T) {
+	d := dt.DateTime(2026, 10, 17, 9, 30, 0)
+	tests := []struct {
+		tag       string
+		dateStyle Style
+		timeStyle Style
+		want      string
+	}{
+		{"en", Long, Short, "October 17, 2026 at 9:30\u202fAM"},
+		{"en", Short, Short, "10/17/26, 9:30\u202fAM"},
+		{"de", Full, Short, "Samstag, 17. Oktober 2026 um 09:30"},
+		{"fr", Long, Medium, "17 octobre 2026 à 09:30:00"},
+		{"fr", Short, Short, "17/10/2026 09:30"},
+		{"es", Short, Short, "17/10/26, 9:30"},
+		{"ja", Long, Medium, "2026年10月17日 9:30:00"},
+	}
+	for _, tt := range tests {
+		l, _ := Lookup(tt.tag)
+		assert.Equal(t, tt.want, l.FormatDateTime(d, tt.dateStyle, tt.timeStyle), tt.tag)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		tag     string
+		dt      dt.LocalDateTime
+		pattern string
+		want    string
+	}{
+		{"de", dt.DateTime(2026, 3, 2, 0, 0, 0), "EEE d. MMM y", "Mo. 2. März 2026"},
+		{"de", dt.DateTime(2026, 3, 2, 0, 0, 0), "LLL MMM LLLL MMMMM", "Mär März März M"},
+		{"it", dt.DateTime(2026, 3, 2, 0, 0, 0), "LLLL 'di' y", "Marzo di 2026"},
+		{"fr", dt.DateTime(2026, 3, 2, 0, 0, 0), "EEEE EEEEE", "lundi L"},
+		{"es", dt.DateTime(2026, 3, 2, 15, 0, 0), "h a", "3 p.\u00a0m."},
+		{"en", dt.DateTime(2026, 3, 2, 15, 0, 0), "'It''s' h a", "It's 3 PM"},
+		{"en", dt.DateTime(2026, 3, 2, 15, 0, 0), "QQQ yyyy", "Q1 2026"},
+		{"nl", dt.DateTime(-43, 3, 15, 0, 0, 0), "d MMMM y G", "15 maart 44 v.Chr."},
+		{"ja", dt.DateTime(2026, 3, 2, 15, 0, 0), "G y年 ah時", "西暦 2026年 午後3時"},
+	}
+	for _, tt := range tests {
+		l, _ := Lookup(tt.tag)
+		assert.Equal(t, tt.want, l.Format(tt.dt, tt.pattern), tt.pattern)
+	}
+}
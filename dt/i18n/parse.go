@@ -0,0 +1,518 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jjeffery/goda/dt"
+)
+
+var (
+	errInvalidDateFormat = errors.New("invalid date format")
+
+	// parsers contains the parsers for locales, keyed by tag.
+	parsers sync.Map
+)
+
+// word is the meaning of a word in a date. A word can have more than one
+// meaning, such as "mar", which is March and Tuesday in Spanish.
+type word struct {
+	month   time.Month // zero if not a month
+	weekday int        // time.Weekday plus one, or zero if not a weekday
+	period  int        // 1 for AM, 2 for PM, or zero if not a day period
+	filler  bool       // a word that is ignored, such as "de" or "of"
+}
+
+// parser contains the words and field order used to parse dates for a
+// locale.
+type parser struct {
+	words map[string]word // keyed by folded text without dots or spaces
+	order string          // the order of year, month and day, such as "dMy"
+
+	// timeMarkers replaces the hour, minute and second markers of the
+	// locale, such as "時" in Japanese, with colons
+	timeMarkers *strings.Replacer
+}
+
+// englishFillers are ignored in every locale.
+var englishFillers = []string{"of", "the", "at", "on", "st", "nd", "rd", "th"}
+
+// ParseDate parses a date written in the language of the locale or in
+// English, such as "17 Oct 2026", "October 17, 2026", "17-okt-2026" or
+// "Samstag, 17. Oktober 2026". Month and day names are matched without
+// regard to case or accents, and can be abbreviated. Dates without month
+// names, such as "17/10/2026", are read in the order of the locale's short
+// date style, unless the first number has four digits, in which case the
+// order is year, month, day. A year of one or two digits is in the years
+// 2000 to 2099. A day of the week must agree with the date. Any time of day
+// is parsed and then ignored.
+func (l *Locale) ParseDate(s string) (dt.LocalDate, error) {
+	t, err := l.ParseDateTime(s)
+	if err != nil {
+		return dt.LocalDate{}, err
+	}
+	return t.LocalDate(), nil
+}
+
+// MustParseDate is like ParseDate, but panics if s cannot be parsed.
+func (l *Locale) MustParseDate(s string) dt.LocalDate {
+	d, err := l.ParseDate(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return d
+}
+
+// ParseDateTime parses a date as described in ParseDate, optionally
+// followed or preceded by a time of day, such as "17 Oct 2026 14:30" or
+// "October 17, 2026 at 2:30:15 PM". The time of day has hours and minutes,
+// and optionally seconds, separated by colons or by the markers of the
+// locale, such as "14時30分" in Japanese. If the time is missing, it is
+// midnight.
+func (l *Locale) ParseDateTime(s string) (dt.LocalDateTime, error) {
+	return l.parser().parse(s)
+}
+
+// MustParseDateTime is like ParseDateTime, but panics if s cannot be parsed.
+func (l *Locale) MustParseDateTime(s string) dt.LocalDateTime {
+	t, err := l.ParseDateTime(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return t
+}
+
+func (l *Locale) parser() *parser {
+	if p, ok := parsers.Load(l.tag); ok {
+		return p.(*parser)
+	}
+	p, _ := parsers.LoadOrStore(l.tag, newParser(l.data))
+	return p.(*parser)
+}
+
+func newParser(data *localeData) *parser {
+	p := &parser{
+		words: make(map[string]word),
+		order: fieldOrder(data.datePatterns[Short]),
+	}
+	for _, d := range []*localeData{locales["en"], data} {
+		for i := range 12 {
+			for _, name := range []string{d.months[i], d.shortMonths[i], d.standAloneMonths[i], d.standAloneShortMonths[i]} {
+				p.add(name, func(w *word) { w.month = time.Month(i + 1) })
+			}
+		}
+		for i := range 7 {
+			for _, name := range []string{d.weekdays[i], d.shortWeekdays[i]} {
+				p.add(name, func(w *word) { w.weekday = i + 1 })
+			}
+		}
+		for i, name := range d.dayPeriods {
+			p.add(name, func(w *word) { w.period = i + 1 })
+		}
+	}
+	for _, filler := range englishFillers {
+		p.add(filler, func(w *word) { w.filler = true })
+	}
+	for _, pattern := range append(data.datePatterns[:], data.dateTimePatterns[:]...) {
+		for _, text := range patternText(pattern) {
+			for _, filler := range strings.Fields(text) {
+				p.add(filler, func(w *word) { w.filler = true })
+			}
+		}
+	}
+
+	// the markers after the hour, minute and second fields
+	var markers []string
+	for _, field := range []string{"Hh", "m", "s"} {
+		if marker := fieldMarker(data.timePatterns[Full], field); marker != "" && marker != ":" {
+			if field == "s" {
+				markers = append(markers, marker, "")
+			} else {
+				markers = append(markers, marker, ":")
+			}
+		}
+	}
+	p.timeMarkers = strings.NewReplacer(markers...)
+	return p
+}
+
+// add adds the meaning of a word. Words that are numbers, such as "10月",
+// are not added.
+func (p *parser) add(text string, set func(*word)) {
+	key := fold(text)
+	if key == "" || unicode.IsDigit(rune(key[0])) {
+		return
+	}
+	w := p.words[key]
+	set(&w)
+	p.words[key] = w
+}
+
+// fieldOrder returns the order of the year, month and day fields of a
+// pattern, such as "dMy" for "dd/MM/y".
+func fieldOrder(pattern string) string {
+	var order []byte
+	for _, c := range []byte(stripQuoted(pattern)) {
+		if (c == 'y' || c == 'M' || c == 'd') && !strings.Contains(string(order), string(c)) {
+			order = append(order, c)
+		}
+	}
+	if len(order) != 3 {
+		return "yMd"
+	}
+	return string(order)
+}
+
+// fieldMarker returns the literal text that follows the first field in a
+// pattern with one of the letters specified.
+func fieldMarker(pattern string, letters string) string {
+	pattern = stripQuoted(pattern)
+	i := strings.IndexAny(pattern, letters)
+	if i < 0 {
+		return ""
+	}
+	for c := pattern[i]; i < len(pattern) && pattern[i] == c; {
+		i++
+	}
+	rest := pattern[i:]
+	if j := strings.IndexFunc(rest, isPatternLetter); j >= 0 {
+		rest = rest[:j]
+	}
+	return strings.TrimSpace(rest)
+}
+
+func isPatternLetter(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+}
+
+// patternText returns the literal text of a pattern: quoted text, and
+// unquoted text that is not ASCII, such as "年".
+func patternText(pattern string) []string {
+	var text []string
+	for i := 0; i < len(pattern); {
+		if pattern[i] == '\'' {
+			j := strings.IndexByte(pattern[i+1:], '\'')
+			if j < 0 {
+				j = len(pattern) - i - 1
+			}
+			text = append(text, pattern[i+1:i+1+j])
+			i += j + 2
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(pattern[i:])
+		if r >= utf8.RuneSelf && !unicode.IsSpace(r) {
+			text = append(text, string(r))
+		}
+		i += n
+	}
+	return text
+}
+
+// stripQuoted removes the quoted text from a pattern.
+func stripQuoted(pattern string) string {
+	var sb strings.Builder
+	quoted := false
+	for _, r := range pattern {
+		if r == '\'' {
+			quoted = !quoted
+		} else if !quoted {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// number is a number in a date, and the number of digits it has.
+type number struct {
+	value, digits int
+}
+
+func (p *parser) parse(s string) (dt.LocalDateTime, error) {
+	text := p.timeMarkers.Replace(s)
+	var (
+		numbers              []number
+		names                []word
+		hasTime              bool
+		hour, minute, second int
+		period               int
+		numberEnd            = -1 // the end of the last number
+	)
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case unicode.IsSpace(r) || strings.ContainsRune(",-/.()", r):
+			i += size
+		case '0' <= r && r <= '9':
+			n, rest := parseNumber(text[i:])
+			if strings.HasPrefix(rest, ":") {
+				if hasTime {
+					return dt.LocalDateTime{}, errInvalidDateFormat
+				}
+				var ok bool
+				if hour, minute, second, rest, ok = parseClock(n.value, rest); !ok {
+					return dt.LocalDateTime{}, errInvalidDateFormat
+				}
+				hasTime = true
+			} else {
+				numbers = append(numbers, n)
+			}
+			i = len(text) - len(rest)
+			numberEnd = i
+		default:
+			w, n := p.match(text[i:])
+			if n == 0 {
+				end := strings.IndexFunc(text[i:], func(r rune) bool { return !unicode.IsLetter(r) })
+				if end < 0 {
+					end = len(text) - i
+				}
+				return dt.LocalDateTime{}, fmt.Errorf("unknown word %q in date %q", text[i:i+max(end, size)], s)
+			}
+			switch {
+			case w.filler && (i == numberEnd || (w.month == 0 && w.weekday == 0 && w.period == 0)):
+				// a filler after a number, such as "日" in "17日", is not
+				// also the name of a day
+			case w.period != 0 && w.month == 0 && w.weekday == 0:
+				if period != 0 {
+					return dt.LocalDateTime{}, errInvalidDateFormat
+				}
+				period = w.period
+			default:
+				names = append(names, w)
+			}
+			i += n
+		}
+	}
+
+	month, weekday, err := resolveNames(names, s)
+	if err != nil {
+		return dt.LocalDateTime{}, err
+	}
+	d, err := p.resolveDate(numbers, month)
+	if err != nil {
+		return dt.LocalDateTime{}, err
+	}
+	if weekday != 0 && int(d.Weekday())+1 != weekday {
+		return dt.LocalDateTime{}, errInvalidDateFormat
+	}
+
+	if period != 0 {
+		if !hasTime || hour < 1 || hour > 12 {
+			return dt.LocalDateTime{}, errInvalidDateFormat
+		}
+		hour %= 12
+		if period == 2 {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 || second > 59 {
+		return dt.LocalDateTime{}, errInvalidDateFormat
+	}
+	return d.At(dt.TimeOfDay(hour, minute, second, 0)), nil
+}
+
+// match returns the meaning of the longest word at the start of s, and its
+// length in bytes. Dots and spaces after a dot are skipped, so "p. m."
+// matches "pm".
+func (p *parser) match(s string) (word, int) {
+	var (
+		best   word
+		length int
+	)
+	for key, w := range p.words {
+		if n := matchWord(s, key); n > length {
+			best, length = w, n
+		}
+	}
+	return best, length
+}
+
+// matchWord returns the length in bytes of the text at the start of s that
+// matches a folded key, or zero if it does not match.
+func matchWord(s string, key string) int {
+	i := 0
+	var last rune
+	for _, k := range key {
+		if last != 0 && i < len(s) && s[i] == '.' {
+			for i++; i < len(s) && s[i] == ' '; i++ {
+			}
+		}
+		if i >= len(s) {
+			return 0
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if foldRune(r) != k {
+			return 0
+		}
+		i += size
+		last = r
+	}
+
+	// a word must end at a word boundary, except in scripts that do not
+	// use spaces between words
+	if next, _ := utf8.DecodeRuneInString(s[i:]); unicode.IsLetter(next) && !isCJK(next) && !isCJK(last) {
+		return 0
+	}
+	return i
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// resolveNames returns the month and weekday from the names in a date.
+// A name that is both a month and a weekday is a weekday if another name
+// is a month.
+func resolveNames(names []word, s string) (time.Month, int, error) {
+	isMonth := func(w word) bool { return w.month != 0 }
+	if countMonths(names, isMonth) > 1 {
+		isMonth = func(w word) bool { return w.month != 0 && w.weekday == 0 }
+	}
+	if countMonths(names, isMonth) > 1 {
+		return 0, 0, fmt.Errorf("more than one month in date %q", s)
+	}
+
+	var month time.Month
+	weekday := 0
+	for _, w := range names {
+		switch {
+		case isMonth(w):
+			month = w.month
+		case w.weekday == 0:
+			return 0, 0, errInvalidDateFormat
+		case weekday != 0 && weekday != w.weekday:
+			return 0, 0, fmt.Errorf("more than one day of the week in date %q", s)
+		default:
+			weekday = w.weekday
+		}
+	}
+	return month, weekday, nil
+}
+
+// countMonths returns the number of different months in names.
+func countMonths(names []word, isMonth func(word) bool) int {
+	var months uint16
+	for _, w := range names {
+		if isMonth(w) {
+			months |= 1 << w.month
+		}
+	}
+	return bits.OnesCount16(months)
+}
+
+// resolveDate returns the date from the numbers in a date and the month
+// name, if any.
+func (p *parser) resolveDate(numbers []number, month time.Month) (dt.LocalDate, error) {
+	var year, day int
+	switch {
+	case month != 0 && len(numbers) == 2:
+		// the year is the number that cannot be a day, or else the
+		// number in the year position of the locale's order
+		a, b := numbers[0], numbers[1]
+		switch {
+		case a.digits > 2 || a.value > 31:
+			year, day = toYear(a), b.value
+		case b.digits > 2 || b.value > 31:
+			year, day = toYear(b), a.value
+		case strings.IndexByte(p.order, 'y') < strings.IndexByte(p.order, 'd'):
+			year, day = toYear(a), b.value
+		default:
+			year, day = toYear(b), a.value
+		}
+	case month == 0 && len(numbers) == 3:
+		order := p.order
+		if numbers[0].digits > 2 {
+			order = "yMd"
+		}
+		for i, field := range []byte(order) {
+			switch field {
+			case 'y':
+				year = toYear(numbers[i])
+			case 'M':
+				if numbers[i].value < 1 || numbers[i].value > 12 {
+					return dt.LocalDate{}, errInvalidDateFormat
+				}
+				month = time.Month(numbers[i].value)
+			case 'd':
+				day = numbers[i].value
+			}
+		}
+	default:
+		return dt.LocalDate{}, errInvalidDateFormat
+	}
+
+	d := dt.Date(year, month, day)
+	if d.Day() != day || d.Month() != month {
+		return dt.LocalDate{}, errInvalidDateFormat
+	}
+	return d, nil
+}
+
+// toYear returns the year for a number, where a year of one or two digits
+// is in the years 2000 to 2099.
+func toYear(n number) int {
+	if n.digits <= 2 {
+		return 2000 + n.value
+	}
+	return n.value
+}
+
+// parseNumber parses the decimal digits at the start of s.
+func parseNumber(s string) (number, string) {
+	var n number
+	for n.digits < len(s) && '0' <= s[n.digits] && s[n.digits] <= '9' && n.digits < 9 {
+		n.value = n.value*10 + int(s[n.digits]-'0')
+		n.digits++
+	}
+	return n, s[n.digits:]
+}
+
+// parseClock parses the minutes and optional seconds of a time of day
+// after the hour, where s starts with a colon. The minutes can be followed
+// by a colon without seconds, which is what remains of a time such as
+// "14時30分" after its markers are replaced.
+func parseClock(hour int, s string) (int, int, int, string, bool) {
+	minute, rest := parseNumber(s[1:])
+	if minute.digits != 2 {
+		return 0, 0, 0, s, false
+	}
+	second := number{}
+	if strings.HasPrefix(rest, ":") {
+		if second, rest = parseNumber(rest[1:]); second.digits != 2 && second.digits != 0 {
+			return 0, 0, 0, s, false
+		}
+	}
+	return hour, minute.value, second.value, rest, true
+}
+
+// fold returns text in lower case without accents, dots or spaces.
+func fold(text string) string {
+	var sb strings.Builder
+	for _, r := range text {
+		if r != '.' && !unicode.IsSpace(r) {
+			sb.WriteRune(foldRune(r))
+		}
+	}
+	return sb.String()
+}
+
+// foldRune returns a rune in lower case without accents.
+func foldRune(r rune) rune {
+	r = unicode.ToLower(r)
+	if i := strings.IndexRune(accented, r); i >= 0 {
+		return rune(unaccented[utf8.RuneCountInString(accented[:i])])
+	}
+	return r
+}
+
+// accented contains the accented letters of the built-in locales, and the
+// letters without accents are at the same positions in unaccented.
+const (
+	accented   = "àáâãäåçèéêëìíîïñòóôõöùúûüýÿ"
+	unaccented = "aaaaaaceeeeiiiinooooouuuuyy"
+)
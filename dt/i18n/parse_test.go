@@ -0,0 +1,135 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/jjeffery/goda/dt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		tag  string
+		text string
+		want dt.LocalDate
+	}{
+		{"en", "17 Oct 2026", dt.Date(2026, 10, 17)},
+		{"en", "October 17, 2026", dt.Date(2026, 10, 17)},
+		{"en", "october 17th, 2026", dt.Date(2026, 10, 17)},
+		{"en", "Sat, 17 OCT 2026", dt.Date(2026, 10, 17)},
+		{"en", "Saturday the 17th of October 2026", dt.Date(2026, 10, 17)},
+		{"en", "17-Oct-26", dt.Date(2026, 10, 17)},
+		{"en", "Oct 17 26", dt.Date(2026, 10, 17)},
+		{"en", "10/17/2026", dt.Date(2026, 10, 17)},
+		{"en", "2026-10-17", dt.Date(2026, 10, 17)},
+		{"en-GB", "17/10/2026", dt.Date(2026, 10, 17)},
+		{"en-GB", "17 Sept 2026", dt.Date(2026, 9, 17)},
+		{"de", "17-okt-2026", dt.Date(2026, 10, 17)},
+		{"de", "17. Oktober 2026", dt.Date(2026, 10, 17)},
+		{"de", "17. März 2026", dt.Date(2026, 3, 17)},
+		{"de", "17. marz 2026", dt.Date(2026, 3, 17)},
+		{"de", "17 Oct 2026", dt.Date(2026, 10, 17)},
+		{"de", "17.10.26", dt.Date(2026, 10, 17)},
+		{"es", "17 de octubre de 2026", dt.Date(2026, 10, 17)},
+		{"es", "sabado, 17 de octubre de 2026", dt.Date(2026, 10, 17)},
+		{"es", "mar 17 mar 2026", dt.Date(2026, 3, 17)},
+		{"fr", "17 févr. 2026", dt.Date(2026, 2, 17)},
+		{"fr", "1 fevrier 2026", dt.Date(2026, 2, 1)},
+		{"fr", "mar. 17 mars 2026", dt.Date(2026, 3, 17)},
+		{"it", "sabato 17 ottobre 2026", dt.Date(2026, 10, 17)},
+		{"nl", "17 mrt 2026", dt.Date(2026, 3, 17)},
+		{"pt", "17 de out. de 2026", dt.Date(2026, 10, 17)},
+		{"ja", "2026年10月17日", dt.Date(2026, 10, 17)},
+		{"ja", "2026年3月1日日曜日", dt.Date(2026, 3, 1)},
+		{"zh", "2026年10月17日星期六", dt.Date(2026, 10, 17)},
+		{"zh", "2026/10/17", dt.Date(2026, 10, 17)},
+	}
+	for _, tt := range tests {
+		l, _ := Lookup(tt.tag)
+		d, err := l.ParseDate(tt.text)
+		if assert.NoError(t, err, "%s %s", tt.tag, tt.text) {
+			assert.Equal(t, tt.want, d, "%s %s", tt.tag, tt.text)
+		}
+	}
+}
+
+func TestParseDateErrors(t *testing.T) {
+	tests := []struct {
+		tag     string
+		text    string
+		errText string
+	}{
+		{"en", "", "invalid date format"},
+		{"en", "17 Oct", "invalid date format"},
+		{"en", "31 Feb 2026", "invalid date format"},
+		{"en", "13/17/2026", "invalid date format"},
+		{"en", "Friday 17 Oct 2026", "invalid date format"},
+		{"en", "Fri Sat 17 Oct 2026", `more than one day of the week in date "Fri Sat 17 Oct 2026"`},
+		{"en", "17 Oct Nov 2026", `more than one month in date "17 Oct Nov 2026"`},
+		{"en", "17 Oktober 2026", `unknown word "Oktober" in date "17 Oktober 2026"`},
+		{"en", "17 Octo 2026", `unknown word "Octo" in date "17 Octo 2026"`},
+		{"en", "17 Oct 2026 PM", "invalid date format"},
+		{"en", "17 Oct 2026 25:00", "invalid date format"},
+		{"en", "17 Oct 2026 9:5", "invalid date format"},
+		{"de", "17. Oktober 2026 @", `unknown word "@" in date "17. Oktober 2026 @"`},
+	}
+	for _, tt := range tests {
+		l, _ := Lookup(tt.tag)
+		_, err := l.ParseDate(tt.text)
+		if assert.Error(t, err, tt.text) {
+			assert.Equal(t, tt.errText, err.Error(), tt.text)
+		}
+	}
+}
+
+func TestParseDateTime(t *testing.T) {
+	tests := []struct {
+		tag  string
+		text string
+		want dt.LocalDateTime
+	}{
+		{"en", "October 17, 2026 at 2:30:15 PM", dt.DateTime(2026, 10, 17, 14, 30, 15)},
+		{"en", "17 Oct 2026 14:30", dt.DateTime(2026, 10, 17, 14, 30, 0)},
+		{"en", "12:05 am 17 Oct 2026", dt.DateTime(2026, 10, 17, 0, 5, 0)},
+		{"en", "17 Oct 2026", dt.DateTime(2026, 10, 17, 0, 0, 0)},
+		{"es", "17/10/26, 9:30 p. m.", dt.DateTime(2026, 10, 17, 21, 30, 0)},
+		{"de", "Samstag, 17. Oktober 2026 um 09:30", dt.DateTime(2026, 10, 17, 9, 30, 0)},
+		{"ja", "2026年10月17日 14時05分09秒", dt.DateTime(2026, 10, 17, 14, 5, 9)},
+		{"ja", "2026年10月17日 14時30分", dt.DateTime(2026, 10, 17, 14, 30, 0)},
+		{"ja", "14時30分 2026年10月17日", dt.DateTime(2026, 10, 17, 14, 30, 0)},
+		{"ja", "2026/10/17 午後3:00", dt.DateTime(2026, 10, 17, 15, 0, 0)},
+	}
+	for _, tt := range tests {
+		l, _ := Lookup(tt.tag)
+		got, err := l.ParseDateTime(tt.text)
+		if assert.NoError(t, err, tt.text) {
+			assert.Equal(t, tt.want, got, tt.text)
+		}
+	}
+}
+
+func TestParseFormatted(t *testing.T) {
+	// every locale parses the dates and times it formats
+	start := dt.DateTime(2025, 12, 25, 13, 45, 30)
+	for _, tag := range Tags() {
+		l, _ := Lookup(tag)
+		for day := range 40 {
+			d := start.AddDate(0, 0, day*10)
+			for _, style := range []Style{Short, Medium, Long, Full} {
+				text := l.FormatDateTime(d, style, Medium)
+				got, err := l.ParseDateTime(text)
+				if assert.NoError(t, err, "%s %s", tag, text) {
+					assert.Equal(t, d, got, "%s %s", tag, text)
+				}
+			}
+		}
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	l, _ := Lookup("en")
+	assert.Equal(t, dt.Date(2026, 10, 17), l.MustParseDate("17 Oct 2026"))
+	assert.Panics(t, func() { l.MustParseDate("17 Oct") })
+	assert.Equal(t, dt.DateTime(2026, 10, 17, 9, 0, 0), l.MustParseDateTime("17 Oct 2026 9:00"))
+	assert.Panics(t, func() { l.MustParseDateTime("17 Oct 9:00") })
+}
@@ -0,0 +1,344 @@
+package ics
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+	"github.com/jjeffery/goda/dt/recur"
+)
+
+// Calendar is a VCALENDAR component.
+type Calendar struct {
+	*Component
+
+	mutex     sync.Mutex
+	locations map[string]*time.Location
+}
+
+// NewCalendar returns an empty calendar with the VERSION and PRODID
+// properties set. The product identifier should be of the form
+// "-//Company//Product//EN".
+func NewCalendar(prodID string) *Calendar {
+	c := &Calendar{Component: &Component{Name: "VCALENDAR"}}
+	c.Add(&Property{Name: "VERSION", Value: "2.0"})
+	c.Add(&Property{Name: "PRODID", Value: prodID})
+	return c
+}
+
+// Events returns the VEVENT components of the calendar.
+func (c *Calendar) Events() []*Event {
+	var events []*Event
+	for _, sub := range c.ComponentsNamed("VEVENT") {
+		events = append(events, &Event{item{Component: sub, cal: c}})
+	}
+	return events
+}
+
+// Todos returns the VTODO components of the calendar.
+func (c *Calendar) Todos() []*Todo {
+	var todos []*Todo
+	for _, sub := range c.ComponentsNamed("VTODO") {
+		todos = append(todos, &Todo{item{Component: sub, cal: c}})
+	}
+	return todos
+}
+
+// NewEvent adds a VEVENT component to the calendar with the unique
+// identifier specified, and returns it.
+func (c *Calendar) NewEvent(uid string) *Event {
+	return &Event{c.newItem("VEVENT", uid)}
+}
+
+// NewTodo adds a VTODO component to the calendar with the unique
+// identifier specified, and returns it.
+func (c *Calendar) NewTodo(uid string) *Todo {
+	return &Todo{c.newItem("VTODO", uid)}
+}
+
+func (c *Calendar) newItem(name, uid string) item {
+	sub := &Component{Name: name}
+	it := item{Component: sub, cal: c}
+	it.setText("UID", uid)
+	c.Components = append(c.Components, sub)
+	return it
+}
+
+// Location returns the location for a TZID. The TZID is first looked up
+// in the IANA time zone database, and if it is not found there, it is
+// looked up in the VTIMEZONE components of the calendar, which is common
+// for calendars exported by Microsoft Outlook.
+func (c *Calendar) Location(tzid string) (*time.Location, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if loc, ok := c.locations[tzid]; ok {
+		return loc, nil
+	}
+	loc, err := c.loadLocation(tzid)
+	if err != nil {
+		return nil, err
+	}
+	if c.locations == nil {
+		c.locations = make(map[string]*time.Location)
+	}
+	c.locations[tzid] = loc
+	return loc, nil
+}
+
+func (c *Calendar) loadLocation(tzid string) (*time.Location, error) {
+	// some clients prefix a TZID with a solidus to indicate a globally unique ID
+	name := strings.TrimPrefix(tzid, "/")
+	if loc, err := time.LoadLocation(name); err == nil && name != "" && name != "Local" {
+		return loc, nil
+	}
+	for _, tz := range c.ComponentsNamed("VTIMEZONE") {
+		if p := tz.Property("TZID"); p != nil && p.Value == tzid {
+			return timezoneLocation(tz)
+		}
+	}
+	return nil, fmt.Errorf("unknown TZID %q", tzid)
+}
+
+// DateTime returns the value of a DATE or DATE-TIME property. If the property
+// has a TZID parameter, it is resolved using the Location method. If the TZID
+// cannot be resolved, the value is still returned, with an error.
+func (c *Calendar) DateTime(p *Property) (DateTime, error) {
+	return c.parseValue(p, p.Value)
+}
+
+func (c *Calendar) parseValue(p *Property, value string) (DateTime, error) {
+	v, err := parseDateTime(value, p.Param("TZID"))
+	if err != nil {
+		return DateTime{}, fmt.Errorf("invalid %s value %q", p.Name, value)
+	}
+	if v.kind == Zoned {
+		v.loc, err = c.Location(v.tzid)
+	}
+	return v, err
+}
+
+// AddTimezone adds a VTIMEZONE component for the location, containing the
+// transitions of the location that occur in the date range. It does nothing
+// if the calendar already has a VTIMEZONE for the location.
+//
+// RFC 5545 requires a VTIMEZONE component for each TZID used in a calendar,
+// and some calendar clients will not import a calendar without them.
+func (c *Calendar) AddTimezone(loc *time.Location, r dt.DateRange) {
+	for _, tz := range c.ComponentsNamed("VTIMEZONE") {
+		if p := tz.Property("TZID"); p != nil && p.Value == loc.String() {
+			return
+		}
+	}
+	tz := newTimezoneComponent(loc, r)
+
+	// VTIMEZONE components conventionally precede the events that use them
+	i := 0
+	for i < len(c.Components) && c.Components[i].Name == "VTIMEZONE" {
+		i++
+	}
+	c.Components = append(c.Components[:i], append([]*Component{tz}, c.Components[i:]...)...)
+}
+
+// item contains the properties common to events and to-dos.
+type item struct {
+	*Component
+	cal *Calendar
+}
+
+// UID returns the unique identifier.
+func (it item) UID() string {
+	return it.text("UID")
+}
+
+// Summary returns the SUMMARY property, which is the title.
+func (it item) Summary() string {
+	return it.text("SUMMARY")
+}
+
+// SetSummary sets the SUMMARY property.
+func (it item) SetSummary(s string) {
+	it.setText("SUMMARY", s)
+}
+
+// Description returns the DESCRIPTION property.
+func (it item) Description() string {
+	return it.text("DESCRIPTION")
+}
+
+// SetDescription sets the DESCRIPTION property.
+func (it item) SetDescription(s string) {
+	it.setText("DESCRIPTION", s)
+}
+
+// Status returns the STATUS property, such as "CONFIRMED" or "COMPLETED".
+func (it item) Status() string {
+	return it.text("STATUS")
+}
+
+// SetStatus sets the STATUS property.
+func (it item) SetStatus(s string) {
+	it.setText("STATUS", s)
+}
+
+// Start returns the DTSTART property. If there is no DTSTART property,
+// the result is the zero value.
+func (it item) Start() (DateTime, error) {
+	return it.dateTime("DTSTART")
+}
+
+// SetStart sets the DTSTART property.
+func (it item) SetStart(v DateTime) {
+	it.setDateTime("DTSTART", v)
+}
+
+// Recurrence returns the recurrence set of the item, which is made from
+// its DTSTART, RRULE, RDATE and EXDATE properties. RDATE and EXDATE values in
+// a different time zone to DTSTART are converted to the time zone of DTSTART.
+// Occurrences of the set are local date-times in the time zone of DTSTART.
+func (it item) Recurrence() (recur.Set, error) {
+	start, err := it.Start()
+	if err != nil {
+		return recur.Set{}, err
+	}
+	if start.IsZero() {
+		return recur.Set{}, fmt.Errorf("%s has no DTSTART", it.Name)
+	}
+	s := recur.Set{
+		Start:    start.local,
+		DateOnly: start.kind == Date,
+		Location: start.loc,
+	}
+	for _, p := range it.PropertiesNamed("RRULE") {
+		r, err := recur.ParseRule(p.Value)
+		if err != nil {
+			return recur.Set{}, err
+		}
+		s.Rules = append(s.Rules, r)
+	}
+	for _, name := range []string{"RDATE", "EXDATE"} {
+		for _, p := range it.PropertiesNamed(name) {
+			if strings.EqualFold(p.Param("VALUE"), "PERIOD") {
+				return recur.Set{}, fmt.Errorf("%s with VALUE=PERIOD is not supported", name)
+			}
+			for _, value := range strings.Split(p.Value, ",") {
+				v, err := it.cal.parseValue(p, value)
+				if err != nil {
+					return recur.Set{}, err
+				}
+				local := v.in(start).local
+				if name == "RDATE" {
+					s.RDates = append(s.RDates, local)
+				} else {
+					s.ExDates = append(s.ExDates, local)
+				}
+			}
+		}
+	}
+	return s, nil
+}
+
+// SetRecurrence replaces the RRULE, RDATE and EXDATE properties with those
+// of the recurrence set. The DTSTART property is not changed, and the
+// recurrence set's dates are formatted in the same way as DTSTART.
+func (it item) SetRecurrence(s recur.Set) error {
+	start, err := it.Start()
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{"RRULE", "RDATE", "EXDATE"} {
+		it.Remove(name)
+	}
+	for _, r := range s.Rules {
+		it.Add(&Property{Name: "RRULE", Value: r.String()})
+	}
+	for _, list := range []struct {
+		name  string
+		dates []dt.LocalDateTime
+	}{{"RDATE", s.RDates}, {"EXDATE", s.ExDates}} {
+		if len(list.dates) == 0 {
+			continue
+		}
+		values := make([]string, len(list.dates))
+		for i, d := range list.dates {
+			v := start
+			v.local = d
+			values[i] = v.String()
+		}
+		p := start.property(list.name)
+		p.Value = strings.Join(values, ",")
+		it.Add(p)
+	}
+	return nil
+}
+
+func (it item) dateTime(name string) (DateTime, error) {
+	p := it.Property(name)
+	if p == nil {
+		return DateTime{}, nil
+	}
+	return it.cal.DateTime(p)
+}
+
+func (it item) setDateTime(name string, v DateTime) {
+	if v.IsZero() {
+		it.Remove(name)
+		return
+	}
+	it.Set(v.property(name))
+}
+
+// Event is a VEVENT component.
+type Event struct {
+	item
+}
+
+// Location returns the LOCATION property, which is the venue of the event.
+func (e *Event) Location() string {
+	return e.text("LOCATION")
+}
+
+// SetLocation sets the LOCATION property.
+func (e *Event) SetLocation(s string) {
+	e.setText("LOCATION", s)
+}
+
+// End returns the DTEND property. If there is no DTEND property, the result
+// is the zero value, and the end is determined by the DURATION property or by
+// DTSTART, as specified in RFC 5545 section 3.6.1.
+func (e *Event) End() (DateTime, error) {
+	return e.dateTime("DTEND")
+}
+
+// SetEnd sets the DTEND property.
+func (e *Event) SetEnd(v DateTime) {
+	e.setDateTime("DTEND", v)
+}
+
+// Todo is a VTODO component.
+type Todo struct {
+	item
+}
+
+// Due returns the DUE property. If there is no DUE property, the result
+// is the zero value.
+func (t *Todo) Due() (DateTime, error) {
+	return t.dateTime("DUE")
+}
+
+// SetDue sets the DUE property.
+func (t *Todo) SetDue(v DateTime) {
+	t.setDateTime("DUE", v)
+}
+
+// Completed returns the COMPLETED property, which is always in UTC. If
+// there is no COMPLETED property, the result is the zero value.
+func (t *Todo) Completed() (DateTime, error) {
+	return t.dateTime("COMPLETED")
+}
+
+// SetCompleted sets the COMPLETED property.
+func (t *Todo) SetCompleted(v DateTime) {
+	t.setDateTime("COMPLETED", v)
+}
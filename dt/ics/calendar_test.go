@@ -0,0 +1,461 @@
+package ics
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+	"github.com/jjeffery/goda/dt/recur"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseFile(t *testing.T, name string) *Calendar {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func inZone(t *testing.T, ldt dt.LocalDateTime, loc *time.Location) dt.ZonedDateTime {
+	t.Helper()
+	z, err := ldt.InZone(loc, dt.ResolveShiftForward)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return z
+}
+
+func TestEvents(t *testing.T) {
+	assert := assert.New(t)
+	c := parseFile(t, "google.ics")
+	events := c.Events()
+	if !assert.Len(events, 2) {
+		return
+	}
+
+	e := events[0]
+	assert.Equal("5k2l3j4h5g6f7d8s9a0q1w2e3r@google.com", e.UID())
+	assert.Equal("Stand-up", e.Summary())
+	assert.Equal("Level 3, 100 George St, Sydney NSW 2000", e.Location())
+	assert.Equal("Daily stand-up.\nJoin with Google Meet: https://meet.google.com/abc-defg-hij\n\nAgenda: yesterday, today; blockers", e.Description())
+	assert.Equal("CONFIRMED", e.Status())
+	start, err := e.Start()
+	assert.NoError(err)
+	assert.Equal(Zoned, start.Kind())
+	assert.Equal("Australia/Sydney", start.TZID())
+	assert.Equal(dt.DateTime(2024, 1, 8, 9, 30, 0), start.LocalDateTime())
+	if z, ok := start.ZonedDateTime(); assert.True(ok) {
+		assert.Equal("2024-01-07T22:30:00Z", z.Time().UTC().Format(time.RFC3339))
+	}
+
+	e = events[1]
+	assert.Equal("Australia Day", e.Summary())
+	assert.Equal("", e.Location())
+	start, err = e.Start()
+	assert.NoError(err)
+	assert.Equal(Date, start.Kind())
+	assert.Equal(dt.Date(2024, 1, 26), start.LocalDate())
+	_, ok := start.ZonedDateTime()
+	assert.False(ok)
+	end, err := e.End()
+	assert.NoError(err)
+	assert.Equal("20240127", end.String())
+}
+
+func TestTodos(t *testing.T) {
+	assert := assert.New(t)
+	c := parseFile(t, "thunderbird.ics")
+	todos := c.Todos()
+	if !assert.Len(todos, 2) {
+		return
+	}
+	todo := todos[0]
+	assert.Equal("Steuererklärung abgeben", todo.Summary())
+	assert.Equal("Belege sammeln; Formulare ausfüllen, prüfen\nund elektronisch übermitteln.", todo.Description())
+	assert.Equal("COMPLETED", todo.Status())
+	due, err := todo.Due()
+	assert.NoError(err)
+	assert.Equal(Zoned, due.Kind())
+	if z, ok := due.ZonedDateTime(); assert.True(ok) {
+		assert.Equal("2024-07-31T16:00:00Z", z.Time().UTC().Format(time.RFC3339))
+	}
+	completed, err := todo.Completed()
+	assert.NoError(err)
+	assert.Equal(UTC, completed.Kind())
+	assert.Equal("20240220T163000Z", completed.String())
+
+	completed, err = todos[1].Completed()
+	assert.NoError(err)
+	assert.True(completed.IsZero())
+}
+
+func TestFloating(t *testing.T) {
+	assert := assert.New(t)
+	c := parseFile(t, "apple.ics")
+	events := c.Events()
+	if !assert.Len(events, 3) {
+		return
+	}
+	assert.Equal("Dîner au café « Le Dôme » avec Émilie et François — réservation confirmée", events[0].Summary())
+
+	start, err := events[1].Start()
+	assert.NoError(err)
+	assert.Equal(Floating, start.Kind())
+	assert.Equal(dt.DateTime(2024, 3, 2, 7, 0, 0), start.LocalDateTime())
+	assert.Equal("20240302T070000", start.String())
+	_, ok := start.ZonedDateTime()
+	assert.False(ok)
+}
+
+func TestTimezone(t *testing.T) {
+	assert := assert.New(t)
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	c := parseFile(t, "outlook.ics")
+
+	// the rules in the VTIMEZONE have applied in Sydney since 2008
+	loc, err := c.Location("AUS Eastern Standard Time")
+	if !assert.NoError(err) {
+		return
+	}
+	for year := 2008; year <= 2037; year++ {
+		for day := time.Date(year, 1, 1, 12, 0, 0, 0, time.UTC); day.Year() == year; day = day.Add(24 * time.Hour) {
+			name, offset := day.In(loc).Zone()
+			_, expected := day.In(sydney).Zone()
+			if offset != expected {
+				assert.Fail("offset mismatch", "%s: %s %d, expected %d", day, name, offset, expected)
+				break
+			}
+		}
+	}
+	transition := time.Date(2024, 4, 6, 16, 0, 0, 0, time.UTC)
+	_, before := transition.Add(-time.Second).In(loc).Zone()
+	_, after := transition.In(loc).Zone()
+	assert.Equal(11*3600, before)
+	assert.Equal(10*3600, after)
+
+	loc, err = c.Location("Customized Time Zone")
+	if assert.NoError(err) {
+		_, offset := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC).In(loc).Zone()
+		assert.Equal(5*3600+1800, offset)
+	}
+
+	start, err := c.Events()[2].Start()
+	assert.NoError(err)
+	if z, ok := start.ZonedDateTime(); assert.True(ok) {
+		assert.Equal("2024-03-20T03:30:00Z", z.Time().UTC().Format(time.RFC3339))
+	}
+
+	_, err = c.Location("Unknown Standard Time")
+	assert.EqualError(err, `unknown TZID "Unknown Standard Time"`)
+}
+
+func TestUnknownTZID(t *testing.T) {
+	assert := assert.New(t)
+	c := NewCalendar("-//Example//Test//EN")
+	e := c.NewEvent("1")
+	p := &Property{Name: "DTSTART", Value: "20240101T090000"}
+	p.SetParam("TZID", "Nowhere")
+	e.Set(p)
+
+	start, err := e.Start()
+	assert.EqualError(err, `unknown TZID "Nowhere"`)
+	assert.Equal(Zoned, start.Kind())
+	assert.Equal("Nowhere", start.TZID())
+	assert.Equal(dt.DateTime(2024, 1, 1, 9, 0, 0), start.LocalDateTime())
+	_, ok := start.ZonedDateTime()
+	assert.False(ok)
+
+	p.Value = "2024-01-01"
+	_, err = e.Start()
+	assert.EqualError(err, `invalid DTSTART value "2024-01-01"`)
+}
+
+func TestRecurrence(t *testing.T) {
+	testCases := []struct {
+		File     string
+		Index    int
+		Todo     bool
+		Count    int
+		Expected []string
+	}{
+		{
+			File:  "outlook.ics",
+			Index: 0,
+			Expected: []string{
+				"2024-01-09T10:00:00",
+				"2024-04-09T10:00:00",
+				"2024-10-08T10:00:00",
+			},
+		},
+		{
+			File:  "thunderbird.ics",
+			Index: 0,
+			Expected: []string{
+				"2024-01-24T19:30:00",
+				"2024-04-17T19:30:00",
+				"2024-09-18T19:30:00",
+			},
+		},
+		{
+			// UNTIL is 10:00 in Berlin after the start of daylight saving
+			File:  "thunderbird.ics",
+			Index: 1,
+			Todo:  true,
+			Count: 24,
+			Expected: []string{
+				"2024-03-25T09:00:00",
+				"2024-03-28T09:00:00",
+			},
+		},
+		{
+			File:  "apple.ics",
+			Index: 1,
+			Count: 10,
+			Expected: []string{
+				"2024-04-27T07:00:00",
+				"2024-05-04T07:00:00",
+			},
+		},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		c := parseFile(t, tc.File)
+		var s recur.Set
+		var err error
+		if tc.Todo {
+			s, err = c.Todos()[tc.Index].Recurrence()
+		} else {
+			s, err = c.Events()[tc.Index].Recurrence()
+		}
+		if !assert.NoError(err, tc.File) {
+			continue
+		}
+		var occurrences []string
+		for v := range s.All() {
+			occurrences = append(occurrences, v.String())
+		}
+		if tc.Count == 0 {
+			assert.Equal(tc.Expected, occurrences, tc.File)
+		} else if assert.Len(occurrences, tc.Count, tc.File) {
+			assert.Equal(tc.Expected, occurrences[tc.Count-len(tc.Expected):], tc.File)
+		}
+	}
+}
+
+func TestNewCalendar(t *testing.T) {
+	assert := assert.New(t)
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	c := NewCalendar("-//Example//Test//EN")
+	e := c.NewEvent("event-1@example.com")
+	e.SetSummary("Lunch, with friends")
+	e.SetLocation("Café; upstairs")
+	e.SetStart(ZonedOf(inZone(t, dt.DateTime(2024, 3, 30, 12, 0, 0), paris)))
+	e.SetEnd(ZonedOf(inZone(t, dt.DateTime(2024, 3, 31, 12, 0, 0), paris)))
+	assert.NoError(e.SetRecurrence(recur.Set{
+		Rules:   []recur.Rule{recur.MustParseRule("FREQ=DAILY;COUNT=5")},
+		ExDates: []dt.LocalDateTime{dt.DateTime(2024, 4, 1, 12, 0, 0)},
+	}))
+	todo := c.NewTodo("todo-1@example.com")
+	todo.SetSummary("Book a table")
+	todo.SetDue(DateOf(dt.Date(2024, 3, 29)))
+	todo.SetStart(FloatingOf(dt.DateTime(2024, 3, 28, 9, 0, 0)))
+	c.AddTimezone(paris, dt.MustParseDateRange("2024-01-01/2025-01-01"))
+	c.AddTimezone(paris, dt.MustParseDateRange("2024-01-01/2025-01-01"))
+
+	data, err := Marshal(c)
+	assert.NoError(err)
+	assert.Equal("BEGIN:VCALENDAR\r\n"+
+		"VERSION:2.0\r\n"+
+		"PRODID:-//Example//Test//EN\r\n"+
+		"BEGIN:VTIMEZONE\r\n"+
+		"TZID:Europe/Paris\r\n"+
+		"BEGIN:STANDARD\r\n"+
+		"DTSTART:20231029T030000\r\n"+
+		"TZOFFSETFROM:+0200\r\n"+
+		"TZOFFSETTO:+0100\r\n"+
+		"TZNAME:CET\r\n"+
+		"RDATE:20241027T030000\r\n"+
+		"END:STANDARD\r\n"+
+		"BEGIN:DAYLIGHT\r\n"+
+		"DTSTART:20240331T020000\r\n"+
+		"TZOFFSETFROM:+0100\r\n"+
+		"TZOFFSETTO:+0200\r\n"+
+		"TZNAME:CEST\r\n"+
+		"END:DAYLIGHT\r\n"+
+		"END:VTIMEZONE\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"UID:event-1@example.com\r\n"+
+		"SUMMARY:Lunch\\, with friends\r\n"+
+		"LOCATION:Café\\; upstairs\r\n"+
+		"DTSTART;TZID=Europe/Paris:20240330T120000\r\n"+
+		"DTEND;TZID=Europe/Paris:20240331T120000\r\n"+
+		"RRULE:FREQ=DAILY;COUNT=5\r\n"+
+		"EXDATE;TZID=Europe/Paris:20240401T120000\r\n"+
+		"END:VEVENT\r\n"+
+		"BEGIN:VTODO\r\n"+
+		"UID:todo-1@example.com\r\n"+
+		"SUMMARY:Book a table\r\n"+
+		"DUE;VALUE=DATE:20240329\r\n"+
+		"DTSTART:20240328T090000\r\n"+
+		"END:VTODO\r\n"+
+		"END:VCALENDAR\r\n", string(data))
+
+	// the generated VTIMEZONE agrees with the time zone database
+	loc, err := timezoneLocation(c.ComponentsNamed("VTIMEZONE")[0])
+	if assert.NoError(err) {
+		for day := time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC); day.Year() == 2024; day = day.Add(time.Hour) {
+			_, offset := day.In(loc).Zone()
+			_, expected := day.In(paris).Zone()
+			if !assert.Equal(expected, offset, day.String()) {
+				break
+			}
+		}
+	}
+
+	c2, err := Parse(data)
+	if !assert.NoError(err) {
+		return
+	}
+	e = c2.Events()[0]
+	assert.Equal("Lunch, with friends", e.Summary())
+	s, err := e.Recurrence()
+	assert.NoError(err)
+	var occurrences []dt.LocalDate
+	for d := range s.Dates() {
+		occurrences = append(occurrences, d)
+	}
+	assert.Equal([]dt.LocalDate{
+		dt.Date(2024, 3, 30),
+		dt.Date(2024, 3, 31),
+		dt.Date(2024, 4, 2),
+		dt.Date(2024, 4, 3),
+	}, occurrences)
+	due, err := c2.Todos()[0].Due()
+	assert.NoError(err)
+	assert.Equal(DateOf(dt.Date(2024, 3, 29)), due)
+}
+
+func TestAddTimezoneWithoutTransitions(t *testing.T) {
+	assert := assert.New(t)
+	loc := time.FixedZone("JST", 9*3600)
+	c := NewCalendar("-//Example//Test//EN")
+	c.AddTimezone(loc, dt.MustParseDateRange("2024-01-01/2025-01-01"))
+	tz := c.ComponentsNamed("VTIMEZONE")
+	if !assert.Len(tz, 1) || !assert.Len(tz[0].Components, 1) {
+		return
+	}
+	assert.Equal("JST", tz[0].text("TZID"))
+	obs := tz[0].Components[0]
+	assert.Equal("STANDARD", obs.Name)
+	assert.Equal("20240101T000000", obs.text("DTSTART"))
+	assert.Equal("+0900", obs.text("TZOFFSETFROM"))
+	assert.Equal("+0900", obs.text("TZOFFSETTO"))
+	assert.Equal("JST", obs.text("TZNAME"))
+
+	loc, err := timezoneLocation(tz[0])
+	if assert.NoError(err) {
+		for _, year := range []int{1900, 2024, 2100} {
+			name, offset := time.Date(year, 6, 1, 0, 0, 0, 0, time.UTC).In(loc).Zone()
+			assert.Equal("JST", name, year)
+			assert.Equal(9*3600, offset, year)
+		}
+	}
+}
+
+func TestUTCOffset(t *testing.T) {
+	testCases := []struct {
+		Text   string
+		Offset int
+		Valid  bool
+		Format string
+	}{
+		{Text: "+1000", Offset: 36000, Valid: true},
+		{Text: "-0430", Offset: -16200, Valid: true},
+		{Text: "+0000", Offset: 0, Valid: true},
+		{Text: "+053012", Offset: 19812, Valid: true},
+		{Text: "+010000", Offset: 3600, Valid: true, Format: "+0100"},
+		{Text: "1000"},
+		{Text: "+10"},
+		{Text: "+0960"},
+		{Text: "+-100"},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		offset, err := parseUTCOffset(tc.Text)
+		if !tc.Valid {
+			assert.Error(err, tc.Text)
+			continue
+		}
+		assert.NoError(err, tc.Text)
+		assert.Equal(tc.Offset, offset, tc.Text)
+		format := tc.Format
+		if format == "" {
+			format = tc.Text
+		}
+		assert.Equal(format, formatUTCOffset(offset), tc.Text)
+	}
+}
+
+func TestTimezoneLimits(t *testing.T) {
+	assert := assert.New(t)
+	vtimezone := func(observances ...string) *Component {
+		text := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Example//Test//EN\r\nBEGIN:VTIMEZONE\r\nTZID:Test\r\n"
+		for _, obs := range observances {
+			text += obs
+		}
+		text += "END:VTIMEZONE\r\nEND:VCALENDAR\r\n"
+		c, err := Parse([]byte(text))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.ComponentsNamed("VTIMEZONE")[0]
+	}
+	observance := func(name, dtstart, from, to, tzname, rrule string) string {
+		text := "BEGIN:" + name + "\r\nDTSTART:" + dtstart + "\r\nTZOFFSETFROM:" + from + "\r\nTZOFFSETTO:" + to + "\r\n"
+		if tzname != "" {
+			text += "TZNAME:" + tzname + "\r\n"
+		}
+		if rrule != "" {
+			text += "RRULE:" + rrule + "\r\n"
+		}
+		return text + "END:" + name + "\r\n"
+	}
+
+	for _, freq := range []string{"SECONDLY", "MINUTELY", "HOURLY", "DAILY", "WEEKLY"} {
+		tz := vtimezone(observance("STANDARD", "20240101T000000", "+1000", "+1000", "", "FREQ="+freq))
+		_, err := timezoneLocation(tz)
+		assert.EqualError(err, "VTIMEZONE Test: STANDARD: RRULE frequency must be YEARLY or MONTHLY", freq)
+	}
+
+	// every day of every month for 400 years
+	tz := vtimezone(observance("STANDARD", "16010101T000000", "+1000", "+1000", "", "FREQ=MONTHLY;BYMONTHDAY=1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20,21,22,23,24,25,26,27,28"))
+	_, err := timezoneLocation(tz)
+	assert.EqualError(err, "VTIMEZONE Test: STANDARD: more than 10000 transitions")
+
+	// more distinct zones than can be indexed by a byte
+	var observances []string
+	for i := range 300 {
+		from := fmt.Sprintf("+%02d%02d", i/60, i%60)
+		to := fmt.Sprintf("+%02d%02d", (i+1)/60, (i+1)%60)
+		observances = append(observances, observance("STANDARD", fmt.Sprintf("%04d0101T000000", 1990+i/10), from, to, fmt.Sprintf("Z%d", i), ""))
+	}
+	_, err = timezoneLocation(vtimezone(observances...))
+	assert.EqualError(err, "VTIMEZONE Test: too many distinct UTC offsets and names")
+}
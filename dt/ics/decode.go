@@ -0,0 +1,228 @@
+package ics
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A Decoder reads calendars from an input stream.
+type Decoder struct {
+	r           *bufio.Reader
+	pending     string // next physical line, if havePending
+	pendingNo   int    // line number of the pending line
+	havePending bool
+	lineNo      int // number of physical lines read
+	err         error
+}
+
+// NewDecoder returns a new decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// Decode reads the next VCALENDAR component from its input. At the end of
+// the input, Decode returns io.EOF.
+func (d *Decoder) Decode() (*Calendar, error) {
+	c, err := d.decodeComponent()
+	if err != nil {
+		return nil, err
+	}
+	if c.Name != "VCALENDAR" {
+		return nil, fmt.Errorf("expected VCALENDAR, found %s", c.Name)
+	}
+	return &Calendar{Component: c}, nil
+}
+
+// Parse parses iCalendar data containing a single VCALENDAR component.
+func Parse(data []byte) (*Calendar, error) {
+	d := NewDecoder(strings.NewReader(string(data)))
+	c, err := d.Decode()
+	if err == io.EOF {
+		return nil, errors.New("no VCALENDAR component")
+	}
+	return c, err
+}
+
+// decodeComponent reads the next top-level component.
+func (d *Decoder) decodeComponent() (*Component, error) {
+	var stack []*Component
+	for {
+		line, lineNo, err := d.readLine()
+		if err == io.EOF && len(stack) > 0 {
+			return nil, fmt.Errorf("missing END:%s", stack[len(stack)-1].Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == "" {
+			continue
+		}
+		p, err := parseContentLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		switch p.Name {
+		case "BEGIN":
+			c := &Component{Name: strings.ToUpper(p.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, c)
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || !strings.EqualFold(stack[len(stack)-1].Name, p.Value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", lineNo, p.Value)
+			}
+			c := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return c, nil
+			}
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: property %s is not in a component", lineNo, p.Name)
+			}
+			c := stack[len(stack)-1]
+			c.Properties = append(c.Properties, p)
+		}
+	}
+}
+
+// readLine returns the next unfolded content line that is not empty,
+// and the number of its first physical line.
+func (d *Decoder) readLine() (string, int, error) {
+	for {
+		if !d.havePending && !d.readPhysical() {
+			return "", 0, d.err
+		}
+		line, lineNo := d.pending, d.pendingNo
+		d.havePending = false
+
+		// a line starting with white space continues the previous line
+		for d.readPhysical() {
+			s := d.pending
+			if s == "" || (s[0] != ' ' && s[0] != '\t') {
+				break
+			}
+			line += s[1:]
+			d.havePending = false
+		}
+		if line != "" {
+			return line, lineNo, nil
+		}
+	}
+}
+
+// readPhysical reads the next physical line into pending, unless there is
+// already a pending line. It returns false at the end of the input.
+func (d *Decoder) readPhysical() bool {
+	if d.havePending {
+		return true
+	}
+	if d.err != nil {
+		return false
+	}
+	s, err := d.r.ReadString('\n')
+	if err != nil {
+		d.err = err
+		if s == "" {
+			return false
+		}
+	}
+	d.lineNo++
+	if d.lineNo == 1 {
+		s = strings.TrimPrefix(s, "\ufeff")
+	}
+	d.pending, d.pendingNo, d.havePending = strings.TrimRight(s, "\r\n"), d.lineNo, true
+	return true
+}
+
+// parseContentLine parses an unfolded content line into a property.
+func parseContentLine(line string) (*Property, error) {
+	end := strings.IndexAny(line, ";:")
+	if end <= 0 {
+		return nil, fmt.Errorf("invalid content line %q", truncate(line))
+	}
+	p := &Property{Name: strings.ToUpper(line[:end])}
+	i := end
+	for line[i] == ';' {
+		i++
+		eq := strings.IndexByte(line[i:], '=')
+		if eq <= 0 {
+			return nil, fmt.Errorf("invalid parameter in %s", p.Name)
+		}
+		param := Param{Name: strings.ToUpper(line[i : i+eq])}
+		i += eq + 1
+		for {
+			var value string
+			if i < len(line) && line[i] == '"' {
+				quote := strings.IndexByte(line[i+1:], '"')
+				if quote < 0 {
+					return nil, fmt.Errorf("unterminated quoted parameter %s in %s", param.Name, p.Name)
+				}
+				value = line[i+1 : i+1+quote]
+				i += quote + 2
+			} else {
+				n := strings.IndexAny(line[i:], ",;:")
+				if n < 0 {
+					return nil, fmt.Errorf("missing value in %s", p.Name)
+				}
+				value = line[i : i+n]
+				i += n
+			}
+			param.Values = append(param.Values, unescapeParam(value))
+			if i >= len(line) {
+				return nil, fmt.Errorf("missing value in %s", p.Name)
+			}
+			if line[i] != ',' {
+				break
+			}
+			i++
+		}
+		p.Params = append(p.Params, param)
+		if line[i] != ';' && line[i] != ':' {
+			return nil, fmt.Errorf("invalid parameter %s in %s", param.Name, p.Name)
+		}
+	}
+	p.Value = line[i+1:]
+	return p, nil
+}
+
+// unescapeParam replaces the escape sequences in a parameter value
+// specified in RFC 6868.
+func unescapeParam(s string) string {
+	if !strings.Contains(s, "^") {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '^' && i+1 < len(s) {
+			switch s[i+1] {
+			case 'n':
+				sb.WriteByte('\n')
+				i++
+				continue
+			case '\'':
+				sb.WriteByte('"')
+				i++
+				continue
+			case '^':
+				sb.WriteByte('^')
+				i++
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+func truncate(s string) string {
+	if len(s) > 40 {
+		return s[:40] + "..."
+	}
+	return s
+}
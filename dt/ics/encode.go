@@ -0,0 +1,117 @@
+package ics
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLineLength is the maximum length of a content line in octets,
+// not including the line break.
+const maxLineLength = 75
+
+// An Encoder writes calendars to an output stream.
+type Encoder struct {
+	w *bufio.Writer
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w)}
+}
+
+// Encode writes the calendar to the stream. Lines end with CRLF and are
+// folded so that no line is longer than 75 octets.
+func (e *Encoder) Encode(c *Calendar) error {
+	e.encodeComponent(c.Component)
+	return e.w.Flush()
+}
+
+// Marshal returns the iCalendar encoding of c.
+func Marshal(c *Calendar) ([]byte, error) {
+	var sb strings.Builder
+	if err := NewEncoder(&sb).Encode(c); err != nil {
+		return nil, err
+	}
+	return []byte(sb.String()), nil
+}
+
+func (e *Encoder) encodeComponent(c *Component) {
+	e.writeLine("BEGIN:" + c.Name)
+	for _, p := range c.Properties {
+		e.writeLine(contentLine(p))
+	}
+	for _, sub := range c.Components {
+		e.encodeComponent(sub)
+	}
+	e.writeLine("END:" + c.Name)
+}
+
+// writeLine writes a content line, folding it if necessary. Lines are
+// never folded in the middle of a UTF-8 encoded character.
+func (e *Encoder) writeLine(line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		n := limit
+		for n > 0 && !utf8.RuneStart(line[n]) {
+			n--
+		}
+		e.w.WriteString(line[:n])
+		e.w.WriteString("\r\n ")
+		line = line[n:]
+
+		// continuation lines start with a space
+		limit = maxLineLength - 1
+	}
+	e.w.WriteString(line)
+	e.w.WriteString("\r\n")
+}
+
+// contentLine returns the unfolded content line for a property.
+func contentLine(p *Property) string {
+	var sb strings.Builder
+	sb.WriteString(p.Name)
+	for _, param := range p.Params {
+		sb.WriteByte(';')
+		sb.WriteString(param.Name)
+		sb.WriteByte('=')
+		for i, value := range param.Values {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writeParamValue(&sb, value)
+		}
+	}
+	sb.WriteByte(':')
+	sb.WriteString(p.Value)
+	return sb.String()
+}
+
+// writeParamValue writes a parameter value, escaping characters as specified
+// in RFC 6868, and quoting the value if it contains a separator.
+func writeParamValue(sb *strings.Builder, value string) {
+	quote := strings.ContainsAny(value, ";:,")
+	if quote {
+		sb.WriteByte('"')
+	}
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '^':
+			sb.WriteString("^^")
+		case '"':
+			sb.WriteString("^'")
+		case '\n':
+			sb.WriteString("^n")
+		case '\r':
+			if i+1 >= len(value) || value[i+1] != '\n' {
+				sb.WriteString("^n")
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	if quote {
+		sb.WriteByte('"')
+	}
+}
@@ -0,0 +1,219 @@
+// Package ics reads and writes iCalendar data, as specified by RFC 5545.
+//
+// The data is represented as a tree of components, each with a list of
+// properties, so that calendar data is preserved when it is read and written,
+// including properties that this package does not interpret. The Calendar,
+// Event and Todo types provide typed access to the common properties.
+//
+// Values of type DATE are represented by dt.LocalDate, and values of type
+// DATE-TIME are represented by dt.LocalDateTime when they are floating, or
+// by dt.ZonedDateTime when they are in UTC or have a TZID parameter. A TZID
+// is resolved to a time.Location using the IANA time zone database, or if
+// it is not found there, using the VTIMEZONE component of the calendar.
+package ics
+
+import "strings"
+
+// Param is a property parameter, such as TZID or VALUE.
+type Param struct {
+	Name   string
+	Values []string
+}
+
+// Property is a property of a component. The value is stored exactly as it
+// appears in iCalendar data, so that values can be written as they were read.
+// Use Text and SetText for values of type TEXT, which are escaped.
+type Property struct {
+	Name   string
+	Params []Param
+	Value  string
+}
+
+// Param returns the first value of the named parameter, or an empty
+// string if the property does not have the parameter. Parameter names
+// are not case sensitive.
+func (p *Property) Param(name string) string {
+	for _, param := range p.Params {
+		if strings.EqualFold(param.Name, name) && len(param.Values) > 0 {
+			return param.Values[0]
+		}
+	}
+	return ""
+}
+
+// SetParam sets the values of the named parameter, replacing any existing
+// values. If there are no values, the parameter is removed.
+func (p *Property) SetParam(name string, values ...string) {
+	name = strings.ToUpper(name)
+	for i, param := range p.Params {
+		if param.Name == name {
+			if len(values) == 0 {
+				p.Params = append(p.Params[:i:i], p.Params[i+1:]...)
+			} else {
+				p.Params[i].Values = values
+			}
+			return
+		}
+	}
+	if len(values) > 0 {
+		p.Params = append(p.Params, Param{Name: name, Values: values})
+	}
+}
+
+// Text returns the value of a property of type TEXT, with escaped
+// characters replaced.
+func (p *Property) Text() string {
+	return unescapeText(p.Value)
+}
+
+// SetText sets the value of a property of type TEXT, escaping characters
+// as required.
+func (p *Property) SetText(s string) {
+	p.Value = escapeText(s)
+}
+
+// Component is an iCalendar component, such as VCALENDAR, VEVENT or VTIMEZONE.
+type Component struct {
+	Name       string
+	Properties []*Property
+	Components []*Component
+}
+
+// Property returns the first property with the name specified, or nil
+// if there is no such property. Property names are not case sensitive.
+func (c *Component) Property(name string) *Property {
+	for _, p := range c.Properties {
+		if strings.EqualFold(p.Name, name) {
+			return p
+		}
+	}
+	return nil
+}
+
+// PropertiesNamed returns the properties with the name specified.
+func (c *Component) PropertiesNamed(name string) []*Property {
+	var list []*Property
+	for _, p := range c.Properties {
+		if strings.EqualFold(p.Name, name) {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+// Set replaces all properties with the same name as p with p. If there
+// is no such property, p is added.
+func (c *Component) Set(p *Property) {
+	for i, existing := range c.Properties {
+		if strings.EqualFold(existing.Name, p.Name) {
+			c.Properties[i] = p
+			c.removeProperties(p.Name, i+1)
+			return
+		}
+	}
+	c.Properties = append(c.Properties, p)
+}
+
+// Add adds the property p, keeping any existing properties with the same name.
+func (c *Component) Add(p *Property) {
+	c.Properties = append(c.Properties, p)
+}
+
+// Remove removes all properties with the name specified.
+func (c *Component) Remove(name string) {
+	c.removeProperties(name, 0)
+}
+
+// removeProperties removes the named properties from index start onwards.
+func (c *Component) removeProperties(name string, start int) {
+	properties := c.Properties[:start]
+	for _, p := range c.Properties[start:] {
+		if !strings.EqualFold(p.Name, name) {
+			properties = append(properties, p)
+		}
+	}
+	c.Properties = properties
+}
+
+// ComponentsNamed returns the sub-components with the name specified.
+func (c *Component) ComponentsNamed(name string) []*Component {
+	var list []*Component
+	for _, sub := range c.Components {
+		if strings.EqualFold(sub.Name, name) {
+			list = append(list, sub)
+		}
+	}
+	return list
+}
+
+// text returns the text of the named property, or an empty string.
+func (c *Component) text(name string) string {
+	if p := c.Property(name); p != nil {
+		return p.Text()
+	}
+	return ""
+}
+
+// setText sets the text of the named property. If s is empty, the
+// property is removed.
+func (c *Component) setText(name, s string) {
+	if s == "" {
+		c.Remove(name)
+		return
+	}
+	p := &Property{Name: name}
+	p.SetText(s)
+	c.Set(p)
+}
+
+// escapeText escapes a TEXT value as specified in RFC 5545 section 3.3.11.
+func escapeText(s string) string {
+	if !strings.ContainsAny(s, "\\;,\n\r") {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\', ';', ',':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			// CRLF is written as a single newline
+			if i+1 >= len(s) || s[i+1] != '\n' {
+				sb.WriteString(`\n`)
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// unescapeText reverses escapeText. Unknown escape sequences are
+// left unchanged.
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 >= len(s) {
+			sb.WriteByte(c)
+			continue
+		}
+		i++
+		switch next := s[i]; next {
+		case 'n', 'N':
+			sb.WriteByte('\n')
+		case '\\', ';', ',':
+			sb.WriteByte(next)
+		default:
+			sb.WriteByte('\\')
+			sb.WriteByte(next)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,226 @@
+package ics
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	files, err := filepath.Glob("testdata/*.ics")
+	assert.NoError(err)
+	assert.NotEmpty(files)
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if !assert.NoError(err, file) {
+			continue
+		}
+		c, err := Parse(data)
+		if !assert.NoError(err, file) {
+			continue
+		}
+		out, err := Marshal(c)
+		assert.NoError(err, file)
+		assert.Equal(string(data), string(out), file)
+
+		c2, err := Parse(out)
+		assert.NoError(err, file)
+		assert.Equal(c.Component, c2.Component, file)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	assert := assert.New(t)
+
+	// LF line endings, tab continuation, lower case names, blank lines and a BOM
+	text := "\ufeffbegin:vcalendar\n" +
+		"version:2.0\n" +
+		"\n" +
+		"BEGIN:VEVENT\n" +
+		"summary;language=en:A long\n" +
+		"\t summary\n" +
+		"X-PARAM;X-A=\"a;b:c\";X-B=one,\"two,three\";X-C=^'q^'^n^^^x:value:with:colons\n" +
+		"END:VEVENT\n" +
+		"END:VCALENDAR\n"
+	c, err := Parse([]byte(text))
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal("VCALENDAR", c.Name)
+	assert.Equal("2.0", c.Property("VERSION").Value)
+	if assert.Len(c.Components, 1) {
+		e := c.Components[0]
+		assert.Equal("VEVENT", e.Name)
+		assert.Equal("A long summary", e.Property("Summary").Text())
+		assert.Equal("en", e.Property("SUMMARY").Param("language"))
+
+		p := e.Property("X-PARAM")
+		assert.Equal("value:with:colons", p.Value)
+		assert.Equal([]Param{
+			{Name: "X-A", Values: []string{"a;b:c"}},
+			{Name: "X-B", Values: []string{"one", "two,three"}},
+			{Name: "X-C", Values: []string{"\"q\"\n^^x"}},
+		}, p.Params)
+	}
+}
+
+func TestDecodeMultiple(t *testing.T) {
+	assert := assert.New(t)
+	text := "BEGIN:VCALENDAR\r\nPRODID:one\r\nEND:VCALENDAR\r\nBEGIN:VCALENDAR\r\nPRODID:two\r\nEND:VCALENDAR\r\n"
+	d := NewDecoder(strings.NewReader(text))
+	for _, expected := range []string{"one", "two"} {
+		c, err := d.Decode()
+		if assert.NoError(err) {
+			assert.Equal(expected, c.Property("PRODID").Value)
+		}
+	}
+	_, err := d.Decode()
+	assert.Equal(io.EOF, err)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		Text  string
+		Error string
+	}{
+		{Text: "", Error: "no VCALENDAR component"},
+		{Text: "BEGIN:VCALENDAR\nVERSION:2.0\n", Error: "missing END:VCALENDAR"},
+		{Text: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR\n", Error: "line 3: unexpected END:VCALENDAR"},
+		{Text: "VERSION:2.0\n", Error: "line 1: property VERSION is not in a component"},
+		{Text: "BEGIN:VEVENT\nEND:VEVENT\n", Error: "expected VCALENDAR, found VEVENT"},
+		{Text: "BEGIN:VCALENDAR\nNO-COLON\nEND:VCALENDAR\n", Error: `line 2: invalid content line "NO-COLON"`},
+		{Text: "BEGIN:VCALENDAR\nX;A=\"b:c\nEND:VCALENDAR\n", Error: "line 2: unterminated quoted parameter A in X"},
+		{Text: "BEGIN:VCALENDAR\nX;A:c\nEND:VCALENDAR\n", Error: "line 2: invalid parameter in X"},
+		{Text: "BEGIN:VCALENDAR\nX;A=\"b\"c:d\nEND:VCALENDAR\n", Error: "line 2: invalid parameter A in X"},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		_, err := Parse([]byte(tc.Text))
+		if assert.Error(err, tc.Text) {
+			assert.Equal(tc.Error, err.Error(), tc.Text)
+		}
+	}
+}
+
+func TestEncodeFolding(t *testing.T) {
+	assert := assert.New(t)
+	c := NewCalendar("-//Example//Test//EN")
+	c.Properties = nil
+	c.setText("X-ASCII", strings.Repeat("a", 160))
+	c.setText("X-UTF8", strings.Repeat("€", 60))
+
+	data, err := Marshal(c)
+	assert.NoError(err)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\r\n"), "\r\n")
+	assert.Equal([]string{
+		"BEGIN:VCALENDAR",
+		"X-ASCII:" + strings.Repeat("a", 67),
+		" " + strings.Repeat("a", 74),
+		" " + strings.Repeat("a", 19),
+		// three-octet characters are not split, so lines are 73 octets
+		"X-UTF8:" + strings.Repeat("€", 22),
+		" " + strings.Repeat("€", 24),
+		" " + strings.Repeat("€", 14),
+		"END:VCALENDAR",
+	}, lines)
+
+	c2, err := Parse(data)
+	if assert.NoError(err) {
+		assert.Equal(strings.Repeat("a", 160), c2.text("X-ASCII"))
+		assert.Equal(strings.Repeat("€", 60), c2.text("X-UTF8"))
+	}
+}
+
+func TestEncodeParams(t *testing.T) {
+	testCases := []struct {
+		Values   []string
+		Expected string
+	}{
+		{Values: []string{"plain"}, Expected: "X;P=plain:v"},
+		{Values: []string{"Smith, John"}, Expected: `X;P="Smith, John":v`},
+		{Values: []string{"mailto:a@example.com"}, Expected: `X;P="mailto:a@example.com":v`},
+		{Values: []string{"a", "b;c"}, Expected: `X;P=a,"b;c":v`},
+		{Values: []string{`say "hi"`}, Expected: "X;P=say ^'hi^':v"},
+		{Values: []string{"line 1\nline 2"}, Expected: "X;P=line 1^nline 2:v"},
+		{Values: []string{"line 1\r\nline 2"}, Expected: "X;P=line 1^nline 2:v"},
+		{Values: []string{"^"}, Expected: "X;P=^^:v"},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		p := &Property{Name: "X", Value: "v"}
+		p.SetParam("p", tc.Values...)
+		line := contentLine(p)
+		assert.Equal(tc.Expected, line)
+
+		p2, err := parseContentLine(line)
+		if assert.NoError(err, line) {
+			expected := strings.ReplaceAll(strings.Join(tc.Values, ","), "\r\n", "\n")
+			assert.Equal(expected, strings.Join(p2.Params[0].Values, ","), line)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	testCases := []struct {
+		Text    string
+		Escaped string
+	}{
+		{Text: "plain text", Escaped: "plain text"},
+		{Text: "a, b; c", Escaped: `a\, b\; c`},
+		{Text: `back\slash`, Escaped: `back\\slash`},
+		{Text: "line 1\nline 2", Escaped: `line 1\nline 2`},
+		{Text: "", Escaped: ""},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		assert.Equal(tc.Escaped, escapeText(tc.Text))
+		assert.Equal(tc.Text, unescapeText(tc.Escaped))
+	}
+
+	assert.Equal("line 1\nline 2", unescapeText(`line 1\Nline 2`))
+	assert.Equal(`unknown \x escape`, unescapeText(`unknown \x escape`))
+	assert.Equal(`line 1\nline 2`, escapeText("line 1\r\nline 2"))
+}
+
+func TestComponent(t *testing.T) {
+	assert := assert.New(t)
+	c := &Component{Name: "VEVENT"}
+	c.Add(&Property{Name: "ATTENDEE", Value: "mailto:a@example.com"})
+	c.Add(&Property{Name: "SUMMARY", Value: "one"})
+	c.Add(&Property{Name: "ATTENDEE", Value: "mailto:b@example.com"})
+	assert.Len(c.PropertiesNamed("attendee"), 2)
+
+	c.Set(&Property{Name: "ATTENDEE", Value: "mailto:c@example.com"})
+	if assert.Len(c.PropertiesNamed("ATTENDEE"), 1) {
+		assert.Equal("mailto:c@example.com", c.Property("ATTENDEE").Value)
+	}
+	assert.Equal("ATTENDEE", c.Properties[0].Name)
+
+	c.Remove("attendee")
+	assert.Nil(c.Property("ATTENDEE"))
+	assert.Len(c.Properties, 1)
+
+	c.setText("SUMMARY", "")
+	assert.Empty(c.Properties)
+
+	p := &Property{Name: "DTSTART"}
+	p.SetParam("tzid", "Europe/Paris")
+	p.SetParam("VALUE", "DATE-TIME")
+	p.SetParam("TZID", "Asia/Tokyo")
+	assert.Equal([]Param{
+		{Name: "TZID", Values: []string{"Asia/Tokyo"}},
+		{Name: "VALUE", Values: []string{"DATE-TIME"}},
+	}, p.Params)
+	p.SetParam("TZID")
+	assert.Equal("", p.Param("TZID"))
+	assert.Len(p.Params, 1)
+}
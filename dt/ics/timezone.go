@@ -0,0 +1,333 @@
+package ics
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+	"github.com/jjeffery/goda/dt/recur"
+)
+
+var (
+	errInvalidUTCOffset   = errors.New("invalid UTC offset")
+	errTooManyTransitions = fmt.Errorf("more than %d transitions", maxTransitions)
+	errTooManyZones       = errors.New("too many distinct UTC offsets and names")
+)
+
+// maxTransitionYear is the last year for which the transitions of a
+// VTIMEZONE are calculated. Transitions are stored as 32-bit seconds since
+// the Unix epoch, which overflow in January 2038.
+const maxTransitionYear = 2037
+
+// maxTransitions is the largest number of transitions in a VTIMEZONE. Real
+// time zones have a few hundred at most, and the limit stops a calendar
+// from an untrusted source from using excessive memory and time.
+const maxTransitions = 10000
+
+// transition is a change of UTC offset in a time zone.
+type transition struct {
+	when       int64 // Unix time
+	offsetFrom int   // seconds east of UTC
+	offsetTo   int
+	isDST      bool
+	name       string
+}
+
+// timezoneLocation returns a location for a VTIMEZONE component, with the
+// transitions of its STANDARD and DAYLIGHT observances up to the end of 2037.
+// After that, the offset of the last transition applies.
+func timezoneLocation(tz *Component) (*time.Location, error) {
+	tzid := tz.text("TZID")
+	var transitions []transition
+	for _, obs := range tz.Components {
+		if obs.Name != "STANDARD" && obs.Name != "DAYLIGHT" {
+			continue
+		}
+		list, err := observanceTransitions(obs)
+		if err != nil {
+			return nil, fmt.Errorf("VTIMEZONE %s: %w", tzid, err)
+		}
+		transitions = append(transitions, list...)
+		if len(transitions) > maxTransitions {
+			return nil, fmt.Errorf("VTIMEZONE %s: %w", tzid, errTooManyTransitions)
+		}
+	}
+	if len(transitions) == 0 {
+		return nil, fmt.Errorf("VTIMEZONE %s has no observances", tzid)
+	}
+	slices.SortFunc(transitions, func(a, b transition) int {
+		return cmp.Compare(a.when, b.when)
+	})
+	data, err := tzData(transitions)
+	if err != nil {
+		return nil, fmt.Errorf("VTIMEZONE %s: %w", tzid, err)
+	}
+	return time.LoadLocationFromTZData(tzid, data)
+}
+
+// observanceTransitions returns the transitions of a STANDARD or DAYLIGHT
+// observance, which are its DTSTART and the occurrences of its RRULE and
+// RDATE properties. A rule must have a yearly or monthly frequency, as a
+// time zone does not change offset more often than that.
+func observanceTransitions(obs *Component) ([]transition, error) {
+	from, err := parseUTCOffset(obs.text("TZOFFSETFROM"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: TZOFFSETFROM: %w", obs.Name, err)
+	}
+	to, err := parseUTCOffset(obs.text("TZOFFSETTO"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: TZOFFSETTO: %w", obs.Name, err)
+	}
+	p := obs.Property("DTSTART")
+	if p == nil {
+		return nil, fmt.Errorf("%s has no DTSTART", obs.Name)
+	}
+	start, err := parseDateTime(p.Value, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid DTSTART value %q", obs.Name, p.Value)
+	}
+
+	// onsets are local times in the offset before the transition, which is
+	// also how a rule's UNTIL in UTC is converted to local time
+	s := recur.Set{Start: start.local, Location: time.FixedZone("", from)}
+	for _, p := range obs.PropertiesNamed("RRULE") {
+		r, err := recur.ParseRule(p.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", obs.Name, err)
+		}
+		if r.Freq != recur.Yearly && r.Freq != recur.Monthly {
+			return nil, fmt.Errorf("%s: RRULE frequency must be YEARLY or MONTHLY", obs.Name)
+		}
+		s.Rules = append(s.Rules, r)
+	}
+	for _, p := range obs.PropertiesNamed("RDATE") {
+		for _, value := range strings.Split(p.Value, ",") {
+			v, err := parseDateTime(value, "")
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid RDATE value %q", obs.Name, value)
+			}
+			s.RDates = append(s.RDates, v.local)
+		}
+	}
+
+	var list []transition
+	end := dt.Date(maxTransitionYear+1, time.January, 1).At(dt.LocalTime{})
+	for onset := range s.Between(dt.LocalDateTime{}, end) {
+		if len(list) == maxTransitions {
+			return nil, fmt.Errorf("%s: %w", obs.Name, errTooManyTransitions)
+		}
+		list = append(list, transition{
+			when:       onset.Unix() - int64(from),
+			offsetFrom: from,
+			offsetTo:   to,
+			isDST:      obs.Name == "DAYLIGHT",
+			name:       obs.text("TZNAME"),
+		})
+	}
+	return list, nil
+}
+
+// tzData returns time zone data in the format of RFC 8536 (version 1)
+// for a list of transitions sorted by time. The format indexes zones and
+// their names with a byte, which limits the number of distinct zones.
+func tzData(transitions []transition) ([]byte, error) {
+	type zone struct {
+		offset int
+		isDST  bool
+		name   string
+	}
+	var (
+		zones []zone
+		times []int64
+		index []byte
+		names strings.Builder
+	)
+	addZone := func(z zone) byte {
+		if i := slices.Index(zones, z); i >= 0 {
+			return byte(i)
+		}
+		zones = append(zones, z)
+		return byte(len(zones) - 1) // checked below
+	}
+
+	// the first zone applies before the first transition
+	first := 0
+	for first < len(transitions) && transitions[first].when < math.MinInt32 {
+		first++
+	}
+	if first < len(transitions) {
+		t := transitions[first]
+		addZone(zone{offset: t.offsetFrom, name: offsetName(t.offsetFrom, transitions)})
+	} else {
+		t := transitions[first-1]
+		addZone(zone{offset: t.offsetTo, isDST: t.isDST, name: t.name})
+	}
+	for _, t := range transitions[first:] {
+		if t.when > math.MaxInt32 {
+			break
+		}
+		times = append(times, t.when)
+		index = append(index, addZone(zone{offset: t.offsetTo, isDST: t.isDST, name: t.name}))
+	}
+
+	if len(zones) > math.MaxUint8+1 {
+		return nil, errTooManyZones
+	}
+	nameIndex := make([]byte, len(zones))
+	for i, z := range zones {
+		name := z.name
+		if name == "" {
+			name = formatUTCOffset(z.offset)
+		}
+		if names.Len() > math.MaxUint8 {
+			return nil, errTooManyZones
+		}
+		nameIndex[i] = byte(names.Len())
+		names.WriteString(name)
+		names.WriteByte(0)
+	}
+
+	data := []byte("TZif")
+	data = append(data, make([]byte, 16)...) // version 1, reserved
+	for _, n := range []int{0, 0, 0, len(times), len(zones), names.Len()} {
+		data = binary.BigEndian.AppendUint32(data, uint32(n))
+	}
+	for _, t := range times {
+		data = binary.BigEndian.AppendUint32(data, uint32(int32(t)))
+	}
+	data = append(data, index...)
+	for i, z := range zones {
+		data = binary.BigEndian.AppendUint32(data, uint32(int32(z.offset)))
+		isDST := byte(0)
+		if z.isDST {
+			isDST = 1
+		}
+		data = append(data, isDST, nameIndex[i])
+	}
+	return append(data, names.String()...), nil
+}
+
+// offsetName returns the name of the standard time with the offset,
+// if the list has a transition to it.
+func offsetName(offset int, transitions []transition) string {
+	for _, t := range transitions {
+		if t.offsetTo == offset && !t.isDST {
+			return t.name
+		}
+	}
+	return ""
+}
+
+// parseUTCOffset parses a UTC-OFFSET value, such as "+1000" or "-0430",
+// and returns the offset in seconds east of UTC.
+func parseUTCOffset(s string) (int, error) {
+	if (len(s) != 5 && len(s) != 7) || (s[0] != '+' && s[0] != '-') {
+		return 0, errInvalidUTCOffset
+	}
+	n, err := strconv.Atoi(s[1:])
+	if err != nil || n < 0 {
+		return 0, errInvalidUTCOffset
+	}
+	if len(s) == 5 {
+		n *= 100
+	}
+	hours, minutes, seconds := n/10000, n/100%100, n%100
+	if minutes > 59 || seconds > 59 {
+		return 0, errInvalidUTCOffset
+	}
+	offset := hours*3600 + minutes*60 + seconds
+	if s[0] == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+// formatUTCOffset formats an offset in seconds east of UTC as a UTC-OFFSET value.
+func formatUTCOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	s := fmt.Sprintf("%c%02d%02d", sign, offset/3600, offset/60%60)
+	if seconds := offset % 60; seconds != 0 {
+		s += fmt.Sprintf("%02d", seconds)
+	}
+	return s
+}
+
+// newTimezoneComponent returns a VTIMEZONE component for the location
+// with the transitions that affect the date range. Transitions with the
+// same offsets and name are combined into one observance using RDATE.
+func newTimezoneComponent(loc *time.Location, r dt.DateRange) *Component {
+	start, _ := r.Start().At(dt.LocalTime{}).InLocation(loc, dt.ResolveShiftForward)
+	end, _ := r.End().At(dt.LocalTime{}).InLocation(loc, dt.ResolveShiftForward)
+
+	// the transition that started the zone in effect at the start of the
+	// range, or the start of the range if the zone has always been in effect
+	var transitions []transition
+	t, next := start.ZoneBounds()
+	if t.IsZero() {
+		name, offset := start.Zone()
+		transitions = append(transitions, transition{
+			when:       start.Unix(),
+			offsetFrom: offset,
+			offsetTo:   offset,
+			isDST:      start.IsDST(),
+			name:       name,
+		})
+		t = next
+	}
+	for !t.IsZero() && t.Before(end) {
+		_, before := t.Add(-time.Second).Zone()
+		name, after := t.Zone()
+		transitions = append(transitions, transition{
+			when:       t.Unix(),
+			offsetFrom: before,
+			offsetTo:   after,
+			isDST:      t.IsDST(),
+			name:       name,
+		})
+		_, t = t.ZoneBounds()
+	}
+
+	tz := &Component{Name: "VTIMEZONE"}
+	tz.Add(&Property{Name: "TZID", Value: loc.String()})
+	var observances []*Component
+	var keys []transition
+	for _, t := range transitions {
+		onset := dt.LocalDateTimeOf(time.Unix(t.when+int64(t.offsetFrom), 0).UTC())
+		value := FloatingOf(onset).String()
+		key := t
+		key.when = 0
+		if i := slices.Index(keys, key); i >= 0 {
+			obs := observances[i]
+			if p := obs.Property("RDATE"); p != nil {
+				p.Value += "," + value
+			} else {
+				obs.Add(&Property{Name: "RDATE", Value: value})
+			}
+			continue
+		}
+		obs := &Component{Name: "STANDARD"}
+		if t.isDST {
+			obs.Name = "DAYLIGHT"
+		}
+		obs.Add(&Property{Name: "DTSTART", Value: value})
+		obs.Add(&Property{Name: "TZOFFSETFROM", Value: formatUTCOffset(t.offsetFrom)})
+		obs.Add(&Property{Name: "TZOFFSETTO", Value: formatUTCOffset(t.offsetTo)})
+		if t.name != "" {
+			obs.setText("TZNAME", t.name)
+		}
+		observances = append(observances, obs)
+		keys = append(keys, key)
+	}
+	tz.Components = observances
+	return tz
+}
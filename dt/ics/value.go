@@ -0,0 +1,172 @@
+package ics
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+)
+
+var errInvalidDateTimeFormat = errors.New("invalid iCalendar date-time format")
+
+// Kind is the kind of a DateTime value.
+type Kind int
+
+// Kinds of DateTime value.
+const (
+	// Date is a DATE value, which has no time of day.
+	Date Kind = iota + 1
+
+	// Floating is a DATE-TIME value without a time zone, which occurs at the
+	// same local time in any time zone.
+	Floating
+
+	// UTC is a DATE-TIME value in UTC, which has a "Z" suffix.
+	UTC
+
+	// Zoned is a DATE-TIME value with a TZID parameter.
+	Zoned
+)
+
+// DateTime is the value of a property with a value type of DATE or
+// DATE-TIME, such as DTSTART. The zero value has no kind, and represents
+// a missing value.
+type DateTime struct {
+	kind  Kind
+	local dt.LocalDateTime
+	tzid  string
+	loc   *time.Location
+}
+
+// DateOf returns a DATE value.
+func DateOf(d dt.LocalDate) DateTime {
+	return DateTime{kind: Date, local: d.At(dt.LocalTime{})}
+}
+
+// FloatingOf returns a floating DATE-TIME value.
+func FloatingOf(ldt dt.LocalDateTime) DateTime {
+	return DateTime{kind: Floating, local: ldt}
+}
+
+// ZonedOf returns a DATE-TIME value for a zoned date-time. If the location
+// of z is UTC, the value has a kind of UTC. Otherwise the TZID of the value
+// is the name of the location, which should be an IANA time zone name.
+func ZonedOf(z dt.ZonedDateTime) DateTime {
+	loc := z.Location()
+	if loc == time.UTC {
+		return DateTime{kind: UTC, local: z.LocalDateTime(), loc: time.UTC}
+	}
+	return DateTime{kind: Zoned, local: z.LocalDateTime(), tzid: loc.String(), loc: loc}
+}
+
+// Kind returns the kind of value, or zero if v is the zero value.
+func (v DateTime) Kind() Kind {
+	return v.kind
+}
+
+// IsZero reports whether v is the zero value, which represents a missing value.
+func (v DateTime) IsZero() bool {
+	return v.kind == 0
+}
+
+// LocalDate returns the date of the value. For a zoned value, it is the
+// date in the value's time zone.
+func (v DateTime) LocalDate() dt.LocalDate {
+	return v.local.LocalDate()
+}
+
+// LocalDateTime returns the local date-time of the value. For a DATE value,
+// the time is midnight. For a zoned value, it is the local date-time in the
+// value's time zone.
+func (v DateTime) LocalDateTime() dt.LocalDateTime {
+	return v.local
+}
+
+// TZID returns the TZID parameter of a zoned value.
+func (v DateTime) TZID() string {
+	return v.tzid
+}
+
+// ZonedDateTime returns the zoned date-time for values of kind UTC and Zoned.
+// The result is false for other values, and for zoned values whose TZID could
+// not be resolved to a location.
+//
+// If the local date-time is skipped or repeated because of a daylight saving
+// transition, it is resolved as specified in RFC 5545 section 3.3.5, which is
+// consistent with dt.ResolveShiftForward.
+func (v DateTime) ZonedDateTime() (dt.ZonedDateTime, bool) {
+	if v.loc == nil {
+		return dt.ZonedDateTime{}, false
+	}
+	z, err := v.local.InZone(v.loc, dt.ResolveShiftForward)
+	return z, err == nil
+}
+
+// String returns the value in iCalendar format, without any TZID.
+func (v DateTime) String() string {
+	year, month, day, hour, minute, second := v.local.DateTime()
+	if v.kind == Date {
+		return fmt.Sprintf("%04d%02d%02d", year, month, day)
+	}
+	s := fmt.Sprintf("%04d%02d%02dT%02d%02d%02d", year, month, day, hour, minute, second)
+	if v.kind == UTC {
+		s += "Z"
+	}
+	return s
+}
+
+// parseDateTime parses a DATE or DATE-TIME value. Zoned values are not
+// resolved to a location.
+func parseDateTime(s string, tzid string) (DateTime, error) {
+	s = strings.TrimSpace(s)
+	var v DateTime
+	layout := "20060102T150405"
+	switch {
+	case len(s) == 8:
+		layout = "20060102"
+		v.kind = Date
+	case len(s) == 16 && (s[15] == 'Z' || s[15] == 'z'):
+		s = s[:15]
+		v.kind, v.loc = UTC, time.UTC
+	case tzid != "":
+		v.kind, v.tzid = Zoned, tzid
+	default:
+		v.kind = Floating
+	}
+	t, err := time.Parse(layout, strings.ToUpper(s))
+	if err != nil {
+		return DateTime{}, errInvalidDateTimeFormat
+	}
+	v.local = dt.LocalDateTimeOf(t)
+	return v, nil
+}
+
+// property returns a property with the value v.
+func (v DateTime) property(name string) *Property {
+	p := &Property{Name: name, Value: v.String()}
+	switch v.kind {
+	case Date:
+		p.SetParam("VALUE", "DATE")
+	case Zoned:
+		p.SetParam("TZID", v.tzid)
+	}
+	return p
+}
+
+// in returns v converted to the time zone of w, if both are zoned and
+// have locations. It is used to convert RDATE and EXDATE values to the
+// time zone of DTSTART.
+func (v DateTime) in(w DateTime) DateTime {
+	if v.loc == nil || w.loc == nil || v.loc == w.loc {
+		return v
+	}
+	z, ok := v.ZonedDateTime()
+	if !ok {
+		return v
+	}
+	v.local = z.In(w.loc).LocalDateTime()
+	v.kind, v.tzid, v.loc = w.kind, w.tzid, w.loc
+	return v
+}
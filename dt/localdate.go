@@ -1,73 +1,78 @@
 package dt
 
 import (
+	"database/sql/driver"
 	"encoding/xml"
 	"errors"
 	"fmt"
-	"regexp"
-	"strconv"
-	"strings"
+	"math"
 	"time"
 )
 
 // LocalDate represents a date without a time or a timezone.
-// Calculations on LocalDate are performed using the standard
-// library's time.Time type. For these calculations the time is
-// midnight and the timezone is UTC.
+// A LocalDate is stored as the number of days since January 1, year 1
+// in the proleptic Gregorian calendar, and calculations on LocalDate
+// use integer arithmetic. Dates can be compared with the == operator,
+// and the zero value is January 1, year 1. The range of a LocalDate is
+// wider than that of a time.Time.
 type LocalDate struct {
-	t time.Time
+	days int64
 }
 
 // After reports whether the local date d is after e
 func (d LocalDate) After(e LocalDate) bool {
-	return d.t.After(e.t)
+	return d.days > e.days
 }
 
 // Before reports whether the local date d is before e
 func (d LocalDate) Before(e LocalDate) bool {
-	return d.t.Before(e.t)
+	return d.days < e.days
 }
 
 // Equal reports whether d and e represent the same local date.
 func (d LocalDate) Equal(e LocalDate) bool {
-	return d.t.Equal(e.t)
+	return d.days == e.days
 }
 
 // IsZero reports whether d represents the zero local date,
 // January 1, year 1.
 func (d LocalDate) IsZero() bool {
-	return d.t.IsZero()
+	return d.days == 0
 }
 
 // Date returns the year, month and day on which d occurs.
 func (d LocalDate) Date() (year int, month time.Month, day int) {
-	return d.t.Date()
+	return fromDays(d.days)
 }
 
 // Unix returns d as a Unix time, the number of seconds elapsed
 // since January 1, 1970 UTC to midnight of the date UTC.
 func (d LocalDate) Unix() int64 {
-	return d.t.Unix()
+	return (d.days - unixEpochDays) * secondsPerDay
 }
 
 // Year returns the year in which d occurs.
 func (d LocalDate) Year() int {
-	return d.t.Year()
+	year, _, _ := d.Date()
+	return year
 }
 
 // Month returns the month of the year specified by d.
 func (d LocalDate) Month() time.Month {
-	return d.t.Month()
+	_, month, _ := d.Date()
+	return month
 }
 
 // Day returns the day of the month specified by d.
 func (d LocalDate) Day() int {
-	return d.t.Day()
+	_, _, day := d.Date()
+	return day
 }
 
 // Weekday returns the day of the week specified by d.
 func (d LocalDate) Weekday() time.Weekday {
-	return d.t.Weekday()
+	// January 1, year 1 is a Monday
+	return time.Weekday((d.days%7 + 7 + int64(time.Monday)) % 7)
 }
 
 // ISOWeek returns the ISO 8601 year and week number in which d occurs.
@@ -75,35 +80,99 @@ func (d LocalDate) Weekday() time.Weekday {
 // week 52 or 53 of year n-1, and Dec 29 to Dec 31 might belong to week 1
 // of year n+1.
 func (d LocalDate) ISOWeek() (year, week int) {
-	year, week = d.t.ISOWeek()
-	return
+	// weeks start on Monday, and belong to the year of their Thursday
+	thursday := LocalDate{days: d.days - int64(mod(int(d.Weekday())-1, 7)) + 3}
+	return thursday.Year(), (thursday.YearDay()-1)/7 + 1
+}
+
+// ISOWeekString returns the ISO 8601 week date of d in the extended
+// format yyyy-Www-d, such as "2026-W42-6". The year is the ISO week-based
+// year, and the day of the week is 1 for Monday through 7 for Sunday. Use
+// the pattern "YYYY'W'wwe" with Format for the basic format.
+func (d LocalDate) ISOWeekString() string {
+	var buf [16]byte
+	return string(d.AppendISOWeek(buf[:0]))
+}
+
+// AppendISOWeek is like ISOWeekString but appends the week date to b and
+// returns the extended buffer.
+func (d LocalDate) AppendISOWeek(b []byte) []byte {
+	year, week := d.ISOWeek()
+	b = appendNumber(b, year, 4)
+	b = append(b, '-', 'W')
+	b = appendNumber(b, week, 2)
+	b = append(b, '-')
+	return appendNumber(b, isoWeekday(d.Weekday()), 1)
 }
 
 // YearDay returns the day of the year specified by D, in the range [1,365] for non-leap years,
 // and [1,366] in leap years.
 func (d LocalDate) YearDay() int {
-	return d.t.YearDay()
+	return int(d.days-toDays(d.Year(), time.January, 1)) + 1
 }
 
 const (
 	secondsPerDay     = 24 * 60 * 60
 	nanosecondsPerDay = secondsPerDay * 1000000000
+
+	// unixEpochDays is the number of days from January 1, year 1 to
+	// January 1, 1970.
+	unixEpochDays = 719162
+
+	// daysPer400Years is the number of days in a cycle of the Gregorian
+	// calendar, which repeats every 400 years.
+	daysPer400Years = 365*400 + 97
 )
 
-// toDays converts a duration that might contain a fractional number of days
-// into an exact number of days. Truncation occurs towards zero. This function
-// is used when using durations for date arithmetic.
-func toDays(duration time.Duration) time.Duration {
-	days := duration.Nanoseconds() / nanosecondsPerDay
-	nanoseconds := days * nanosecondsPerDay
-	return time.Duration(nanoseconds)
+// toDays returns the number of days from January 1, year 1 to the date,
+// which must be in the range of LocalDate. The month and day may be
+// outside their usual ranges.
+func toDays(year int, month time.Month, day int) int64 {
+	// normalize the month, then count from March so that the leap day
+	// is at the end of the year
+	y := int64(year) + floorDiv(int64(month)-1, 12)
+	m := int64(mod(int(month)-1, 12)) + 1
+	if m <= 2 {
+		y--
+		m += 12
+	}
+	era := floorDiv(y, 400)
+	yoe := y - era*400
+	doy := (153*(m-3)+2)/5 + int64(day) - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	// March 1, year 0 is 306 days before January 1, year 1
+	return era*daysPer400Years + doe - 306
+}
+
+// fromDays returns the date that is the number of days from January 1,
+// year 1.
+func fromDays(days int64) (year int, month time.Month, day int) {
+	days += 306
+	era := floorDiv(days, daysPer400Years)
+	doe := days - era*daysPer400Years
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365
+	doy := doe - (365*yoe + yoe/4 - yoe/100)
+	mp := (5*doy + 2) / 153
+	day = int(doy - (153*mp+2)/5 + 1)
+	month = time.Month(mp + 3)
+	y := yoe + era*400
+	if month > 12 {
+		month -= 12
+		y++
+	}
+	return int(y), month, day
+}
 
+// dayDuration converts a duration that might contain a fractional number
+// of days into an exact number of days. Truncation occurs towards zero.
+// This function is used when using durations for date arithmetic.
+func dayDuration(duration time.Duration) int64 {
+	return duration.Nanoseconds() / nanosecondsPerDay
 }
 
 // Add returns the local date d + duration.
 func (d LocalDate) Add(duration time.Duration) LocalDate {
-	t := d.t.Add(toDays(duration))
-	return LocalDate{t: t}
+	return LocalDate{days: d.days + dayDuration(duration)}
 }
 
 // Sub returns the duration d-e, which will be an integral number of days.
@@ -111,7 +180,15 @@ func (d LocalDate) Add(duration time.Duration) LocalDate {
 // in a Duration, the maximum (or minimum) duration will be returned.
 // To compute d-duration, use d.Add(-duration).
 func (d LocalDate) Sub(e LocalDate) time.Duration {
-	return d.t.Sub(e.t)
+	const maxDays = math.MaxInt64 / nanosecondsPerDay
+	switch days := d.days - e.days; {
+	case days > maxDays:
+		return math.MaxInt64
+	case days < -maxDays:
+		return math.MinInt64
+	default:
+		return time.Duration(days * nanosecondsPerDay)
+	}
 }
 
 // AddDate returns the local date corresponding to adding the given number of years,
@@ -121,8 +198,8 @@ func (d LocalDate) Sub(e LocalDate) time.Duration {
 // AddDate normalizes its result in the same way that Date does, so, for example,
 // adding one month to October 31 yields December 1, the normalized form for November 31.
 func (d LocalDate) AddDate(years int, months int, days int) LocalDate {
-	t := d.t.AddDate(years, months, days)
-	return LocalDate{t: t}
+	year, month, day := d.Date()
+	return Date(year+years, month+time.Month(months), day+days)
 }
 
 // toDate converts the time.Time value into a LocalDate.,
@@ -131,7 +208,9 @@ func toLocalDate(t time.Time) LocalDate {
 	return Date(y, m, d)
 }
 
-// Today returns the current local date.
+// Today returns the current date in the local time zone of the process.
+// Use TodayIn for the date in another time zone, and TodayFrom for the
+// date of a Clock.
 func Today() LocalDate {
 	return toLocalDate(time.Now())
 }
@@ -142,9 +221,20 @@ func Today() LocalDate {
 // and will be normalized during the conversion.
 // For example, October 32 converts to November 1.
 func Date(year int, month time.Month, day int) LocalDate {
-	return LocalDate{
-		t: time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
-	}
+	return LocalDate{days: toDays(year, month, day)}
+}
+
+// ISOWeekDate returns the LocalDate of a day of the week in an ISO 8601
+// week of the ISO week-based year. Weeks start on Monday, and week 1 is
+// the week that contains the first Thursday of the year.
+//
+// The week may be outside its usual range and will be normalized, so
+// week 0 is the last week of the previous week-based year.
+func ISOWeekDate(year int, week int, weekday time.Weekday) LocalDate {
+	// the 4th of January is always in week 1
+	jan4 := Date(year, time.January, 4)
+	monday := jan4.AddDate(0, 0, 1-isoWeekday(jan4.Weekday()))
+	return monday.AddDate(0, 0, (week-1)*7+isoWeekday(weekday)-1)
 }
 
 // String returns a string representation of d. The date
@@ -155,109 +245,63 @@ func (d LocalDate) String() string {
 
 // toString returns the string representation of the date.
 func toString(d LocalDate) string {
-	year, month, day := d.Date()
-	sign := ""
-	if year < 0 {
-		year = -year
-		sign = "-"
-	}
-	return fmt.Sprintf("%s%04d-%02d-%02d", sign, year, int(month), day)
+	var buf [16]byte
+	return string(appendDate(buf[:0], d))
 }
 
-// toQuotedString returns the string representation of the date in quotation marks.
-func toQuotedString(d LocalDate) string {
-	return fmt.Sprintf(`"%s"`, toString(d))
-}
-
-var calendarDateFormats = [...]*regexp.Regexp{
-	// ISO 8601 representations
-	regexp.MustCompile(`^(-?\d{4})-(\d{1,2})-(\d{1,2})(T[0-9:.zZ+-]*)?$`),
-	regexp.MustCompile(`^(-?\d{4})(\d{2})(\d{2})(T[0-9:.zZ+-]*)?$`),
-
-	// Not ISO 8601, but still unambiguous
-	regexp.MustCompile(`^(-?\d{4})\.(\d{1,2})\.(\d{1,2})(T[0-9:.zZ+-]*)?$`),
-	regexp.MustCompile(`^(-?\d{4})/(\d{1,2})/(\d{1,2})(T[0-9:.zZ+-]*)?$`),
-}
-
-var ordinalDateFormats = [...]*regexp.Regexp{
-	// ISO 8601 representations
-	regexp.MustCompile(`^(-?\d{4})-(\d{3})(T[0-9:.zZ+-]*)?$`),
-	regexp.MustCompile(`^(-?\d{4})(\d{3})(T[0-9:.zZ+-]*)?$`),
+// appendDate appends the date in the format yyyy-mm-dd to b.
+func appendDate(b []byte, d LocalDate) []byte {
+	year, month, day := d.Date()
+	return appendYearMonthDay(b, year, month, day)
 }
 
-var (
-	errInvalidDateFormat = errors.New("invalid date format")
-)
-
-// ParseDate attempts to parse a string into a local date. Leading
-// and trailing space and quotation marks are ignored. The following
-// date formates are recognised: yyyy-mm-dd, yyyymmdd, yyyy.mm.dd,
-// yyyy/mm/dd, yyyy-ddd, yyyyddd.
-func ParseDate(s string) (LocalDate, error) {
-	s = strings.Trim(s, " \t\"'")
-	for _, regexp := range calendarDateFormats {
-		match := regexp.FindStringSubmatch(s)
-		if match != nil {
-			// no error checking here because matching the regexp
-			// guarantees that parsing the strings will succeed.
-			year, _ := strconv.ParseInt(match[1], 10, 0)
-			month, _ := strconv.ParseInt(match[2], 10, 0)
-			day, _ := strconv.ParseInt(match[3], 10, 0)
-			return Date(int(year), time.Month(month), int(day)), nil
-		}
-	}
-
-	for _, regexp := range ordinalDateFormats {
-		match := regexp.FindStringSubmatch(s)
-		if match != nil {
-			// no error checking here because matching the regexp
-			// guarantees that parsing the strings will succeed.
-			year, _ := strconv.ParseInt(match[1], 10, 0)
-			dayOfYear, _ := strconv.ParseInt(match[2], 10, 0)
-			duration := time.Duration((dayOfYear - 1) * nanosecondsPerDay)
-			return Date(int(year), 1, 1).Add(duration), nil
-		}
-	}
-
-	return LocalDate{}, errInvalidDateFormat
+func appendYearMonthDay(b []byte, year int, month time.Month, day int) []byte {
+	b = appendNumber(b, year, 4)
+	b = append(b, '-')
+	b = appendNumber(b, int(month), 2)
+	b = append(b, '-')
+	return appendNumber(b, day, 2)
 }
 
-// MustParseDate is similar to ParseDate, but instead of returning an error it will
-// panic if s is not in one of the expected formats.
-func MustParseDate(s string) LocalDate {
-	d, err := ParseDate(s)
-	if err != nil {
-		panic(err.Error())
-	}
-	return d
-}
+var errInvalidDateFormat = errors.New("invalid date format")
 
 // MarshalJSON implements the json.Marshaler interface.
 // The date is a quoted string in an ISO 8601 format (yyyy-mm-dd).
 func (d LocalDate) MarshalJSON() ([]byte, error) {
-	return []byte(toQuotedString(d)), nil
+	b := make([]byte, 0, 12)
+	b = append(b, '"')
+	b = appendDate(b, d)
+	return append(b, '"'), nil
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 // The date is expected to be a quoted string in an ISO 8601
 // format (calendar or ordinal).
+// By convention, a JSON null is a no-op.
 func (d *LocalDate) UnmarshalJSON(data []byte) (err error) {
-	s := string(data)
-	*d, err = ParseDate(s)
+	if isNullJSON(data) {
+		return nil
+	}
+	*d, err = parseDate(data, false)
 	return
 }
 
 // MarshalText implements the encoding.TextMarshaller interface.
 // The date format is yyyy-mm-dd.
 func (d LocalDate) MarshalText() ([]byte, error) {
-	return []byte(toString(d)), nil
+	return d.AppendText(make([]byte, 0, 10))
+}
+
+// AppendText implements the encoding.TextAppender interface. It appends
+// the date in the format yyyy-mm-dd to b.
+func (d LocalDate) AppendText(b []byte) ([]byte, error) {
+	return appendDate(b, d), nil
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaller interface.
 // The date is expected to an ISO 8601 format (calendar or ordinal).
 func (d *LocalDate) UnmarshalText(data []byte) (err error) {
-	s := string(data)
-	*d, err = ParseDate(s)
+	*d, err = parseDate(data, false)
 	return
 }
 
@@ -296,3 +340,34 @@ func (d *LocalDate) UnmarshalXMLAttr(attr xml.Attr) error {
 	}
 	return nil
 }
+
+// Scan implements the sql.Scanner interface. The value is expected
+// to be a time.Time, in which case the date is taken from the wall
+// clock in the time's location, or a string or []byte in one of the
+// formats recognised by ParseDate. A NULL value cannot be scanned into
+// a LocalDate: use NullLocalDate for nullable columns.
+func (d *LocalDate) Scan(src interface{}) (err error) {
+	switch v := src.(type) {
+	case time.Time:
+		*d = toLocalDate(v)
+	case string:
+		*d, err = ParseDate(v)
+	case []byte:
+		*d, err = parseDate(v, false)
+	case nil:
+		err = errors.New("cannot scan NULL into LocalDate")
+	default:
+		err = fmt.Errorf("cannot scan %T into LocalDate", src)
+	}
+	return
+}
+
+// Value implements the driver.Valuer interface. The date is
+// passed to the database as a string in the format yyyy-mm-dd,
+// which is accepted by the DATE column types of all commonly used
+// databases. A string is used rather than a time.Time, because some
+// databases convert a time.Time into the session timezone before
+// discarding the time, which can change the date.
+func (d LocalDate) Value() (driver.Value, error) {
+	return toString(d), nil
+}
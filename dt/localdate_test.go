@@ -1,10 +1,14 @@
 package dt
 
 import (
+	"encoding"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"math"
 	"testing"
 	"time"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -245,3 +249,230 @@ func TestMarshalXML(t *testing.T) {
 		assert.Equal(tc.st, st)
 	}
 }
+
+func TestLocalDateScan(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		Src      interface{}
+		Valid    bool
+		Expected string
+	}{
+		{Src: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true, Expected: "2021-01-02"},
+		{Src: time.Date(2021, 1, 2, 23, 59, 59, 0, time.UTC), Valid: true, Expected: "2021-01-02"},
+		{Src: time.Date(2021, 1, 2, 8, 0, 0, 0, sydney), Valid: true, Expected: "2021-01-02"},
+		{Src: time.Date(2021, 1, 2, 20, 0, 0, 0, time.FixedZone("", -10*3600)), Valid: true, Expected: "2021-01-02"},
+		{Src: "2021-01-02", Valid: true, Expected: "2021-01-02"},
+		{Src: []byte("2021-01-02"), Valid: true, Expected: "2021-01-02"},
+		{Src: "2021-01-02T00:00:00Z", Valid: true, Expected: "2021-01-02"},
+		{Src: "2021-002", Valid: true, Expected: "2021-01-02"},
+		{Src: "xxx", Valid: false},
+		{Src: nil, Valid: false},
+		{Src: int64(20210102), Valid: false},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		var d LocalDate
+		err := d.Scan(tc.Src)
+		if tc.Valid {
+			assert.NoError(err, "%v", tc.Src)
+			assert.Equal(tc.Expected, d.String())
+		} else {
+			assert.Error(err, "%v", tc.Src)
+		}
+	}
+}
+
+func TestLocalDateValue(t *testing.T) {
+	assert := assert.New(t)
+	v, err := Date(2021, time.January, 2).Value()
+	assert.NoError(err)
+	assert.Equal("2021-01-02", v)
+
+	var d LocalDate
+	assert.NoError(d.Scan(v))
+	assert.Equal(Date(2021, time.January, 2), d)
+}
+
+func TestLocalDateUnmarshalJSONNull(t *testing.T) {
+	assert := assert.New(t)
+	d := Date(2021, time.January, 2)
+	assert.NoError(d.UnmarshalJSON([]byte("null")))
+	assert.Equal("2021-01-02", d.String())
+
+	var st struct {
+		Date     LocalDate     `json:"date"`
+		DateTime LocalDateTime `json:"dateTime"`
+		Time     LocalTime     `json:"time"`
+		Period   Period        `json:"period"`
+	}
+	assert.NoError(json.Unmarshal([]byte(`{"date":null,"dateTime":null,"time":null,"period":null}`), &st))
+	assert.True(st.Date.IsZero())
+	assert.True(st.DateTime.IsZero())
+	assert.True(st.Time.IsZero())
+	assert.True(st.Period.IsZero())
+}
+
+func TestLocalDateMatchesTime(t *testing.T) {
+	assert := assert.New(t)
+	start := time.Date(-401, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3*daysPer400Years; i += 11 {
+		tm := start.AddDate(0, 0, i)
+		d := toLocalDate(tm)
+
+		year, month, day := d.Date()
+		wantYear, wantMonth, wantDay := tm.Date()
+		assert.Equal([]int{wantYear, int(wantMonth), wantDay}, []int{year, int(month), day})
+		assert.Equal(tm.Weekday(), d.Weekday(), "%v", tm)
+		assert.Equal(tm.YearDay(), d.YearDay(), "%v", tm)
+		assert.Equal(tm.Unix(), d.Unix(), "%v", tm)
+		isoYear, isoWeek := d.ISOWeek()
+		wantISOYear, wantISOWeek := tm.ISOWeek()
+		assert.Equal([]int{wantISOYear, wantISOWeek}, []int{isoYear, isoWeek}, "%v", tm)
+
+		for _, delta := range [][3]int{{0, 1, 0}, {-1, 0, 0}, {1, -13, 45}, {0, 0, -400}} {
+			want := toLocalDate(tm.AddDate(delta[0], delta[1], delta[2]))
+			assert.Equal(want, d.AddDate(delta[0], delta[1], delta[2]), "%v %v", tm, delta)
+		}
+	}
+}
+
+func TestLocalDateNormalize(t *testing.T) {
+	assert := assert.New(t)
+	for _, month := range []time.Month{-25, -12, -1, 0, 1, 12, 13, 25} {
+		for _, day := range []int{-400, -1, 0, 1, 31, 60, 366} {
+			tm := time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+			assert.Equal(toLocalDate(tm), Date(2024, month, day), "month=%d day=%d", month, day)
+		}
+	}
+}
+
+func TestLocalDateArithmetic(t *testing.T) {
+	assert := assert.New(t)
+	d := Date(2026, time.October, 17)
+	assert.Equal(Date(2026, time.October, 18), d.Add(36*time.Hour))
+	assert.Equal(Date(2026, time.October, 16), d.Add(-36*time.Hour))
+	assert.Equal(Date(2026, time.October, 17), d.Add(23*time.Hour))
+	assert.Equal(48*time.Hour, Date(2026, time.October, 19).Sub(d))
+	assert.Equal(time.Duration(math.MaxInt64), Date(2026, 1, 1).Sub(Date(1, 1, 1)))
+	assert.Equal(time.Duration(math.MinInt64), Date(1, 1, 1).Sub(Date(2026, 1, 1)))
+	assert.True(Date(1, time.January, 1).IsZero())
+	assert.True(LocalDate{} == Date(1, time.January, 1))
+	assert.True(d.After(d.AddDate(0, 0, -1)))
+	assert.True(d.Before(d.AddDate(0, 0, 1)))
+
+	// dates far outside the range of an int32 day count
+	far := Date(6_000_000, time.January, 1)
+	year, month, day := far.Date()
+	assert.Equal([]int{6_000_000, 1, 1}, []int{year, int(month), day})
+	assert.Equal(toLocalDate(time.Date(2026, time.October, 17+1<<31, 0, 0, 0, 0, time.UTC)), d.AddDate(0, 0, 1<<31))
+	assert.Equal(toLocalDate(time.Date(-6_000_000, time.March, 1, 0, 0, 0, 0, time.UTC)), Date(-6_000_000, time.March, 1))
+	assert.True(far.After(d))
+	assert.Equal(8, int(unsafe.Sizeof(d)))
+}
+
+func BenchmarkLocalDateCache(b *testing.B) {
+	const rows = 1 << 20
+	b.ReportAllocs()
+	for range b.N {
+		cache := make([]LocalDate, rows)
+		for i := range cache {
+			cache[i] = Date(2000, time.January, 1+i%10000)
+		}
+		b.ReportMetric(float64(unsafe.Sizeof(cache[0])*rows), "bytes/cache")
+	}
+}
+
+func BenchmarkLocalDateArithmetic(b *testing.B) {
+	b.ReportAllocs()
+	d := Date(2026, time.October, 17)
+	for range b.N {
+		e := d.AddDate(0, 1, 1)
+		_ = e.Weekday()
+		_, _ = e.ISOWeek()
+		_ = e.YearDay()
+	}
+}
+
+func TestLocalDateAppend(t *testing.T) {
+	assert := assert.New(t)
+	var _ encoding.TextAppender = LocalDate{}
+	tests := []struct {
+		date LocalDate
+		text string
+	}{
+		{date: Date(2026, time.October, 17), text: "2026-10-17"},
+		{date: Date(1, time.January, 1), text: "0001-01-01"},
+		{date: Date(0, time.December, 31), text: "0000-12-31"},
+		{date: Date(-44, time.March, 15), text: "-0044-03-15"},
+		{date: Date(12345, time.June, 7), text: "12345-06-07"},
+	}
+	for _, tt := range tests {
+		b, err := tt.date.AppendText([]byte("date="))
+		assert.NoError(err)
+		assert.Equal("date="+tt.text, string(b))
+		b, err = tt.date.MarshalJSON()
+		assert.NoError(err)
+		assert.Equal(`"`+tt.text+`"`, string(b))
+		assert.Equal(tt.text, tt.date.String())
+	}
+	d := Date(2026, time.October, 17)
+	assert.Equal("on Sat 17/10/2026", string(d.AppendFormat([]byte("on "), "EEE dd/MM/yyyy")))
+
+	buf := make([]byte, 0, 64)
+	assert.Zero(testing.AllocsPerRun(100, func() { d.AppendText(buf[:0]) }))
+	assert.Zero(testing.AllocsPerRun(100, func() { d.AppendFormat(buf[:0], "QQQ yyyy-MM-dd") }))
+	assert.LessOrEqual(testing.AllocsPerRun(100, func() { d.MarshalJSON() }), 1.0)
+}
+
+func BenchmarkLocalDateMarshalJSON(b *testing.B) {
+	b.ReportAllocs()
+	d := Date(2026, time.October, 17)
+	for range b.N {
+		d.MarshalJSON()
+	}
+}
+
+func BenchmarkLocalDateAppendText(b *testing.B) {
+	b.ReportAllocs()
+	d := Date(2026, time.October, 17)
+	buf := make([]byte, 0, 64)
+	for range b.N {
+		buf, _ = d.AppendText(buf[:0])
+	}
+}
+
+func TestISOWeekDate(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		year    int
+		week    int
+		weekday time.Weekday
+		date    LocalDate
+		text    string
+	}{
+		{year: 2026, week: 42, weekday: time.Saturday, date: Date(2026, time.October, 17), text: "2026-W42-6"},
+		{year: 2025, week: 1, weekday: time.Monday, date: Date(2024, time.December, 30), text: "2025-W01-1"},
+		{year: 2020, week: 53, weekday: time.Sunday, date: Date(2021, time.January, 3), text: "2020-W53-7"},
+		{year: 2026, week: 53, weekday: time.Thursday, date: Date(2026, time.December, 31), text: "2026-W53-4"},
+		{year: -1, week: 1, weekday: time.Monday, date: Date(-1, time.January, 4), text: "-0001-W01-1"},
+	}
+	for _, tt := range tests {
+		assert.Equal(tt.date, ISOWeekDate(tt.year, tt.week, tt.weekday), tt.text)
+		assert.Equal(tt.text, tt.date.ISOWeekString())
+		assert.Equal("week "+tt.text, string(tt.date.AppendISOWeek([]byte("week "))))
+	}
+
+	// week 0 is the last week of the previous year
+	assert.Equal(ISOWeekDate(2020, 53, time.Friday), ISOWeekDate(2021, 0, time.Friday))
+	assert.Equal("2026W426", Date(2026, time.October, 17).Format("YYYY'W'wwe"))
+
+	for d := Date(2019, time.December, 1); d.Before(Date(2027, time.February, 1)); d = d.AddDate(0, 0, 1) {
+		year, week := d.ISOWeek()
+		assert.Equal(d, ISOWeekDate(year, week, d.Weekday()))
+		assert.Equal(d, MustParseDate(d.ISOWeekString()))
+	}
+}
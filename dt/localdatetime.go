@@ -1,8 +1,11 @@
 package dt
 
 import (
+	"database/sql/driver"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -123,6 +126,13 @@ func (d LocalDateTime) YearDay() int {
 	return d.t.YearDay()
 }
 
+// toSeconds converts a duration that might contain a fractional number of seconds
+// into an exact number of seconds. Truncation occurs towards zero. This function
+// is used when using durations for date-time arithmetic.
+func toSeconds(duration time.Duration) time.Duration {
+	return duration.Truncate(time.Second)
+}
+
 // Add returns the local date-time d + duration.
 func (dt LocalDateTime) Add(duration time.Duration) LocalDateTime {
 	t := dt.t.Add(toSeconds(duration))
@@ -155,7 +165,9 @@ func toLocalDateTime(t time.Time) LocalDateTime {
 	return DateTime(y, m, d, hour, minute, second)
 }
 
-// Now returns the current local date-time.
+// Now returns the current date-time in the local time zone of the process.
+// Use NowIn for the date-time in another time zone, and NowFrom for the
+// date-time of a Clock.
 func Now() LocalDateTime {
 	return toLocalDateTime(time.Now())
 }
@@ -179,46 +191,60 @@ func (d LocalDateTime) String() string {
 
 // localDateTimeString returns the string representation of the date.
 func localDateTimeString(d LocalDateTime) string {
-	year, month, day, hour, minute, second := d.DateTime()
-	sign := ""
-	if year < 0 {
-		year = -year
-		sign = "-"
-	}
-	return fmt.Sprintf("%s%04d-%02d-%02dT%02d:%02d:%02d", sign, year, int(month), day, hour, minute, second)
+	var buf [32]byte
+	return string(appendDateTime(buf[:0], d))
 }
 
-// localDateQuotedString returns the string representation of the date in quotation marks.
-func localDateQuotedString(d LocalDateTime) string {
-	return fmt.Sprintf(`"%s"`, localDateTimeString(d))
+// appendDateTime appends the date-time in the format yyyy-mm-ddThh:mm:ss
+// to b.
+func appendDateTime(b []byte, d LocalDateTime) []byte {
+	year, month, day, hour, minute, second := d.DateTime()
+	b = appendYearMonthDay(b, year, month, day)
+	b = append(b, 'T')
+	b = appendNumber(b, hour, 2)
+	b = append(b, ':')
+	b = appendNumber(b, minute, 2)
+	b = append(b, ':')
+	return appendNumber(b, second, 2)
 }
 
 // MarshalJSON implements the json.Marshaler interface.
 // The date is a quoted string in an ISO 8601 format (yyyy-mm-dd).
 func (d LocalDateTime) MarshalJSON() ([]byte, error) {
-	return []byte(localDateQuotedString(d)), nil
+	b := make([]byte, 0, 21)
+	b = append(b, '"')
+	b = appendDateTime(b, d)
+	return append(b, '"'), nil
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 // The date is expected to be a quoted string in an ISO 8601
 // format (calendar or ordinal).
+// By convention, a JSON null is a no-op.
 func (d *LocalDateTime) UnmarshalJSON(data []byte) (err error) {
-	s := string(data)
-	*d, err = ParseDateTime(s)
+	if isNullJSON(data) {
+		return nil
+	}
+	*d, err = parseDateTime(data, false)
 	return
 }
 
 // MarshalText implements the encoding.TextMarshaller interface.
 // The date format is yyyy-mm-dd.
 func (d LocalDateTime) MarshalText() ([]byte, error) {
-	return []byte(localDateTimeString(d)), nil
+	return d.AppendText(make([]byte, 0, 19))
+}
+
+// AppendText implements the encoding.TextAppender interface. It appends
+// the date-time in the format yyyy-mm-ddThh:mm:ss to b.
+func (d LocalDateTime) AppendText(b []byte) ([]byte, error) {
+	return appendDateTime(b, d), nil
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaller interface.
 // The date is expected to an ISO 8601 format (calendar or ordinal).
 func (d *LocalDateTime) UnmarshalText(data []byte) (err error) {
-	s := string(data)
-	*d, err = ParseDateTime(s)
+	*d, err = parseDateTime(data, false)
 	return
 }
 
@@ -257,3 +283,42 @@ func (d *LocalDateTime) UnmarshalXMLAttr(attr xml.Attr) error {
 	}
 	return nil
 }
+
+// Scan implements the sql.Scanner interface. The value is expected
+// to be a time.Time, in which case the date and time are taken from
+// the wall clock in the time's location, or a string or []byte in one
+// of the formats recognised by ParseDateTime. The date and time may
+// also be separated by a space instead of a "T", which is how many
+// databases format a timestamp as text. A NULL value cannot be scanned
+// into a LocalDateTime: use NullLocalDateTime for nullable columns.
+func (dt *LocalDateTime) Scan(src interface{}) (err error) {
+	switch v := src.(type) {
+	case time.Time:
+		*dt = toLocalDateTime(v)
+	case string:
+		*dt, err = parseSQLDateTime(v)
+	case []byte:
+		*dt, err = parseSQLDateTime(string(v))
+	case nil:
+		err = errors.New("cannot scan NULL into LocalDateTime")
+	default:
+		err = fmt.Errorf("cannot scan %T into LocalDateTime", src)
+	}
+	return
+}
+
+// parseSQLDateTime parses a date-time returned by a database driver as text,
+// where the date and time are commonly separated by a space.
+func parseSQLDateTime(s string) (LocalDateTime, error) {
+	s = strings.Trim(s, " \t\"'")
+	return ParseDateTime(strings.Replace(s, " ", "T", 1))
+}
+
+// Value implements the driver.Valuer interface. The date-time is
+// passed to the database as a string in the format yyyy-mm-ddThh:mm:ss,
+// which is accepted by the TIMESTAMP WITHOUT TIME ZONE and DATETIME
+// column types of all commonly used databases. A string is used rather
+// than a time.Time so that the driver does not attach a timezone.
+func (dt LocalDateTime) Value() (driver.Value, error) {
+	return localDateTimeString(dt), nil
+}
@@ -1,6 +1,7 @@
 package dt
 
 import (
+	"encoding"
 	"encoding/xml"
 	"testing"
 	"time"
@@ -343,3 +344,42 @@ func TestDateTimeMarshalXML(t *testing.T) {
 		assert.Equal(tc.st, st)
 	}
 }
+
+func TestLocalDateTimeAppend(t *testing.T) {
+	assert := assert.New(t)
+	var _ encoding.TextAppender = LocalDateTime{}
+	tests := []struct {
+		dateTime LocalDateTime
+		text     string
+	}{
+		{dateTime: DateTime(2026, time.October, 17, 9, 5, 3), text: "2026-10-17T09:05:03"},
+		{dateTime: DateTime(1, time.January, 1, 0, 0, 0), text: "0001-01-01T00:00:00"},
+		{dateTime: DateTime(-44, time.March, 15, 23, 59, 59), text: "-0044-03-15T23:59:59"},
+	}
+	for _, tt := range tests {
+		b, err := tt.dateTime.AppendText([]byte("at "))
+		assert.NoError(err)
+		assert.Equal("at "+tt.text, string(b))
+		b, err = tt.dateTime.MarshalText()
+		assert.NoError(err)
+		assert.Equal(tt.text, string(b))
+		b, err = tt.dateTime.MarshalJSON()
+		assert.NoError(err)
+		assert.Equal(`"`+tt.text+`"`, string(b))
+	}
+	dt := DateTime(2026, time.October, 17, 21, 30, 0)
+	assert.Equal("at 9:30 PM", string(dt.AppendFormat([]byte("at "), "h:mm a")))
+
+	buf := make([]byte, 0, 64)
+	assert.Zero(testing.AllocsPerRun(100, func() { dt.AppendText(buf[:0]) }))
+	assert.Zero(testing.AllocsPerRun(100, func() { dt.AppendFormat(buf[:0], "EEEE d MMMM y HH:mm:ss") }))
+	assert.LessOrEqual(testing.AllocsPerRun(100, func() { dt.MarshalJSON() }), 1.0)
+}
+
+func BenchmarkLocalDateTimeMarshalJSON(b *testing.B) {
+	b.ReportAllocs()
+	dt := DateTime(2026, time.October, 17, 21, 30, 0)
+	for range b.N {
+		dt.MarshalJSON()
+	}
+}
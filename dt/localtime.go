@@ -0,0 +1,210 @@
+package dt
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// LocalTime represents a time of day without a date or a timezone.
+// Calculations on LocalTime are performed using the standard library's
+// time.Time type. For these calculations the date is January 1, year 1
+// and the timezone is UTC.
+//
+// LocalTime is useful for representing wall-clock times that recur
+// every day, such as shop opening hours or the time of day that a
+// dose of medication should be taken. Unlike LocalDateTime, LocalTime
+// specifies the time to nanosecond accuracy.
+//
+// The zero value of LocalTime is midnight.
+type LocalTime struct {
+	t time.Time
+}
+
+const nanosecondsPerDayDuration = time.Duration(nanosecondsPerDay)
+
+// TimeOfDay returns the LocalTime corresponding to hh:mm:ss.nnnnnnnnn.
+//
+// The hour, minute, second and nanosecond values may be outside their
+// usual ranges and will be normalized during the conversion. Values that
+// overflow a day wrap around midnight. For example, 25:00 converts to 01:00.
+func TimeOfDay(hour int, minute int, second int, nanosecond int) LocalTime {
+	t := time.Date(1, 1, 1, hour, minute, second, nanosecond, time.UTC)
+	return toLocalTime(t)
+}
+
+// toLocalTime converts the time.Time value into a LocalTime, discarding
+// the date and timezone.
+func toLocalTime(t time.Time) LocalTime {
+	hour, minute, second := t.Clock()
+	return LocalTime{
+		t: time.Date(1, 1, 1, hour, minute, second, t.Nanosecond(), time.UTC),
+	}
+}
+
+// After reports whether the local time t is after u.
+func (t LocalTime) After(u LocalTime) bool {
+	return t.t.After(u.t)
+}
+
+// Before reports whether the local time t is before u.
+func (t LocalTime) Before(u LocalTime) bool {
+	return t.t.Before(u.t)
+}
+
+// Equal reports whether t and u represent the same local time.
+func (t LocalTime) Equal(u LocalTime) bool {
+	return t.t.Equal(u.t)
+}
+
+// IsZero reports whether t represents the zero local time, midnight.
+func (t LocalTime) IsZero() bool {
+	return t.t.IsZero()
+}
+
+// Clock returns the hour, minute and second specified by t.
+func (t LocalTime) Clock() (hour int, minute int, second int) {
+	return t.t.Clock()
+}
+
+// Hour returns the hour specified by t, in the range [0, 23].
+func (t LocalTime) Hour() int {
+	return t.t.Hour()
+}
+
+// Minute returns the minute specified by t, in the range [0, 59].
+func (t LocalTime) Minute() int {
+	return t.t.Minute()
+}
+
+// Second returns the second specified by t, in the range [0, 59].
+func (t LocalTime) Second() int {
+	return t.t.Second()
+}
+
+// Nanosecond returns the nanosecond offset within the second specified by t,
+// in the range [0, 999999999].
+func (t LocalTime) Nanosecond() int {
+	return t.t.Nanosecond()
+}
+
+// Add returns the local time t + duration. The result wraps around
+// midnight, so adding two hours to 23:00 gives 01:00.
+func (t LocalTime) Add(duration time.Duration) LocalTime {
+	return toLocalTime(t.t.Add(duration % nanosecondsPerDayDuration))
+}
+
+// Sub returns the duration t-u, which is the time elapsed from u
+// until the next occurrence of t. The result wraps around midnight
+// and is in the range [0, 24h). For example, 01:00 minus 23:00 is two
+// hours, and 23:00 minus 01:00 is twenty-two hours.
+func (t LocalTime) Sub(u LocalTime) time.Duration {
+	duration := t.t.Sub(u.t)
+	if duration < 0 {
+		duration += nanosecondsPerDayDuration
+	}
+	return duration
+}
+
+// String returns a string representation of t. The time format
+// returned is compatible with ISO 8601: hh:mm:ss, followed by a
+// fraction of a second if the nanosecond component is not zero.
+func (t LocalTime) String() string {
+	return localTimeString(t)
+}
+
+// localTimeString returns the string representation of the time.
+func localTimeString(t LocalTime) string {
+	return t.t.Format("15:04:05.999999999")
+}
+
+// localTimeQuotedString returns the string representation of the time in quotation marks.
+func localTimeQuotedString(t LocalTime) string {
+	return fmt.Sprintf(`"%s"`, localTimeString(t))
+}
+
+// At returns the local date-time at which the time t occurs on the date d.
+// Because LocalDateTime only specifies the time to second accuracy, any
+// fraction of a second in t is discarded.
+func (d LocalDate) At(t LocalTime) LocalDateTime {
+	year, month, day := d.Date()
+	hour, minute, second := t.Clock()
+	return DateTime(year, month, day, hour, minute, second)
+}
+
+// Time returns the local time of day specified by dt.
+func (dt LocalDateTime) Time() LocalTime {
+	return toLocalTime(dt.t)
+}
+
+// LocalDate returns the local date specified by dt.
+func (dt LocalDateTime) LocalDate() LocalDate {
+	return toLocalDate(dt.t)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The time is a quoted string in an ISO 8601 format (hh:mm:ss).
+func (t LocalTime) MarshalJSON() ([]byte, error) {
+	return []byte(localTimeQuotedString(t)), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The time is expected to be a quoted string in an ISO 8601
+// format (extended or basic).
+// By convention, a JSON null is a no-op.
+func (t *LocalTime) UnmarshalJSON(data []byte) (err error) {
+	if isNullJSON(data) {
+		return nil
+	}
+	*t, err = parseTime(data, false)
+	return
+}
+
+// MarshalText implements the encoding.TextMarshaller interface.
+// The time format is hh:mm:ss.
+func (t LocalTime) MarshalText() ([]byte, error) {
+	return []byte(localTimeString(t)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaller interface.
+// The time is expected to be in an ISO 8601 format (extended or basic).
+func (t *LocalTime) UnmarshalText(data []byte) (err error) {
+	*t, err = parseTime(data, false)
+	return
+}
+
+func (t *LocalTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	e.EncodeElement(localTimeString(*t), start)
+	return nil
+}
+
+func (t *LocalTime) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var s string
+
+	if err := decoder.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+
+	if lt, err := ParseTime(s); err != nil {
+		return err
+	} else {
+		*t = lt
+	}
+	return nil
+}
+
+func (t *LocalTime) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{
+		Name:  name,
+		Value: t.String(),
+	}, nil
+}
+
+func (t *LocalTime) UnmarshalXMLAttr(attr xml.Attr) error {
+	if lt, err := ParseTime(attr.Value); err != nil {
+		return err
+	} else {
+		*t = lt
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package dt
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeOfDay(t *testing.T) {
+	assert := assert.New(t)
+	for hour := 0; hour < 24; hour++ {
+		for minute := 0; minute < 60; minute += 7 {
+			second := (hour + minute) % 60
+			tod := TimeOfDay(hour, minute, second, 0)
+			assert.Equal(hour, tod.Hour())
+			assert.Equal(minute, tod.Minute())
+			assert.Equal(second, tod.Second())
+			assert.Equal(0, tod.Nanosecond())
+
+			text := tod.String()
+			tod2, err := ParseTime(text)
+			assert.NoError(err, text)
+			assert.True(tod.Equal(tod2), text)
+		}
+	}
+}
+
+func TestTimeOfDayNormalized(t *testing.T) {
+	testCases := []struct {
+		Hour, Minute, Second, Nanosecond int
+		Text                             string
+	}{
+		{24, 0, 0, 0, "00:00:00"},
+		{25, 30, 0, 0, "01:30:00"},
+		{-1, 0, 0, 0, "23:00:00"},
+		{10, 61, 0, 0, "11:01:00"},
+		{10, 0, 0, 1500000000, "10:00:01.5"},
+	}
+	for _, tc := range testCases {
+		tod := TimeOfDay(tc.Hour, tc.Minute, tc.Second, tc.Nanosecond)
+		assert.Equal(t, tc.Text, tod.String())
+	}
+}
+
+func TestLocalTimeArithmetic(t *testing.T) {
+	testCases := []struct {
+		Time     string
+		Duration time.Duration
+		Expected string
+	}{
+		{"23:00", 2 * time.Hour, "01:00:00"},
+		{"01:00", -2 * time.Hour, "23:00:00"},
+		{"12:00", 49 * time.Hour, "13:00:00"},
+		{"12:00", -49 * time.Hour, "11:00:00"},
+		{"12:00:00.25", 750 * time.Millisecond, "12:00:01"},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		tod := MustParseTime(tc.Time)
+		actual := tod.Add(tc.Duration)
+		assert.Equal(tc.Expected, actual.String(), tc.Time)
+
+		// Sub is the inverse of Add, modulo one day
+		expected := tc.Duration % (24 * time.Hour)
+		if expected < 0 {
+			expected += 24 * time.Hour
+		}
+		assert.Equal(expected, actual.Sub(tod), tc.Time)
+	}
+
+	assert.Equal(2*time.Hour, MustParseTime("01:00").Sub(MustParseTime("23:00")))
+	assert.Equal(22*time.Hour, MustParseTime("23:00").Sub(MustParseTime("01:00")))
+	assert.Equal(time.Duration(0), MustParseTime("09:00").Sub(MustParseTime("09:00")))
+}
+
+func TestParseTime(t *testing.T) {
+	testCases := []struct {
+		Text       string
+		Valid      bool
+		Hour       int
+		Minute     int
+		Second     int
+		Nanosecond int
+	}{
+		{Text: "10:11:12", Valid: true, Hour: 10, Minute: 11, Second: 12},
+		{Text: "1:2:3", Valid: true, Hour: 1, Minute: 2, Second: 3},
+		{Text: "12:39", Valid: true, Hour: 12, Minute: 39},
+		{Text: "030211", Valid: true, Hour: 3, Minute: 2, Second: 11},
+		{Text: "1147", Valid: true, Hour: 11, Minute: 47},
+		{Text: "T10:11", Valid: true, Hour: 10, Minute: 11},
+		{Text: `"10:11:12.123456789"`, Valid: true, Hour: 10, Minute: 11, Second: 12, Nanosecond: 123456789},
+		{Text: "121110.1234", Valid: true, Hour: 12, Minute: 11, Second: 10, Nanosecond: 123400000},
+		{Text: "001122.", Valid: true, Minute: 11, Second: 22},
+		{Text: "00:00:00.1234567891", Valid: true, Nanosecond: 123456789},
+		{Text: "", Valid: false},
+		{Text: "10", Valid: false},
+		{Text: "10:11:12Z", Valid: false},
+		{Text: "2095-09-30T10:11:12", Valid: false},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		tod, err := ParseTime(tc.Text)
+		if tc.Valid {
+			assert.NoError(err, tc.Text)
+			assert.Equal(tc.Hour, tod.Hour(), tc.Text)
+			assert.Equal(tc.Minute, tod.Minute(), tc.Text)
+			assert.Equal(tc.Second, tod.Second(), tc.Text)
+			assert.Equal(tc.Nanosecond, tod.Nanosecond(), tc.Text)
+		} else {
+			assert.Error(err, tc.Text)
+		}
+	}
+}
+
+func TestLocalTimeCombine(t *testing.T) {
+	assert := assert.New(t)
+	date := Date(2095, time.September, 30)
+	tod := TimeOfDay(10, 11, 12, 500)
+
+	datetime := date.At(tod)
+	assert.Equal("2095-09-30T10:11:12", datetime.String())
+	assert.Equal("10:11:12", datetime.Time().String())
+	assert.True(date.Equal(datetime.LocalDate()))
+}
+
+func TestLocalTimeMarshal(t *testing.T) {
+	assert := assert.New(t)
+	type testStruct struct {
+		XMLName   xml.Name  `xml:"TestCase" json:"-"`
+		Element   LocalTime `json:"element"`
+		Attribute LocalTime `xml:",attr" json:"attribute"`
+	}
+
+	st := testStruct{
+		Element:   MustParseTime("09:30"),
+		Attribute: MustParseTime("17:45:01.25"),
+	}
+
+	b, err := json.Marshal(&st)
+	assert.NoError(err)
+	assert.Equal(`{"element":"09:30:00","attribute":"17:45:01.25"}`, string(b))
+	var st2 testStruct
+	assert.NoError(json.Unmarshal(b, &st2))
+	assert.Equal(st, st2)
+
+	b, err = xml.Marshal(&st)
+	assert.NoError(err)
+	assert.Equal(`<TestCase Attribute="17:45:01.25"><Element>09:30:00</Element></TestCase>`, string(b))
+	var st3 testStruct
+	assert.NoError(xml.Unmarshal(b, &st3))
+	st3.XMLName = xml.Name{}
+	assert.Equal(st, st3)
+
+	b, err = st.Element.MarshalText()
+	assert.NoError(err)
+	var tod LocalTime
+	assert.NoError(tod.UnmarshalText(b))
+	assert.True(st.Element.Equal(tod))
+}
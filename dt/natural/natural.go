@@ -0,0 +1,456 @@
+// Package natural parses dates written in English relative to a reference
+// date, such as "tomorrow", "next friday" or "2 business days ago".
+//
+// The grammar is deterministic, so the same input and reference date always
+// give the same result. Words are not case sensitive, and commas are ignored.
+// The following forms are recognised, where N is a number of one or more
+// digits, a number word from "one" to "twelve", or "a" or "an", and the
+// numbers in a date add up to no more than 10000:
+//
+//	today, now, tomorrow, yesterday
+//	the day after tomorrow, the day before yesterday
+//	friday, next friday      the first Friday after the reference date
+//	last friday              the last Friday before the reference date
+//	this friday              the Friday in the current week
+//	this week, next week, last week    the first day of the week
+//	this month, next month, last month the first day of the month
+//	this year, next year, last year    the first day of the year
+//	next business day, previous business day, last business day
+//	first day of next month, last day of this month
+//	start of next week, end of the month, beginning of last year
+//	first monday of next month, last friday of this month
+//	in N days, in N weeks, in N months, in N years, in N business days
+//	N days ago, N weeks from now, N business days after next monday,
+//	N days before the end of the month
+//	2026-10-17               any date accepted by dt.ParseDate
+//
+// Adding months or years to a day that does not exist in the resulting
+// month gives the last day of that month, so "in 1 month" from the 31st of
+// January is the last day of February.
+package natural
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+)
+
+// Parser parses relative dates. The zero value is ready to use, with weeks
+// that start on Sunday and a business calendar with a Saturday and Sunday
+// weekend and no holidays.
+type Parser struct {
+	// WeekStart is the first day of the week, which determines the dates
+	// of "this week", "next week" and "this friday".
+	WeekStart time.Weekday
+
+	// Calendar determines which days are business days.
+	Calendar dt.BusinessCalendar
+}
+
+// Parse parses a relative date using the zero value of Parser.
+func Parse(s string, ref dt.LocalDate) (dt.LocalDate, error) {
+	return Parser{}.Parse(s, ref)
+}
+
+// Parse parses a date relative to the reference date ref, which is usually
+// today's date.
+func (p Parser) Parse(s string, ref dt.LocalDate) (dt.LocalDate, error) {
+	st := &state{
+		Parser: p,
+		ref:    ref,
+		input:  s,
+		words:  strings.Fields(strings.ToLower(strings.ReplaceAll(s, ",", " "))),
+	}
+	d, err := st.date()
+	if err != nil {
+		return dt.LocalDate{}, err
+	}
+	if st.pos < len(st.words) {
+		return dt.LocalDate{}, st.expected("end of date")
+	}
+	return d, nil
+}
+
+// MustParse is like Parse, but panics if s cannot be parsed.
+func (p Parser) MustParse(s string, ref dt.LocalDate) dt.LocalDate {
+	d, err := p.Parse(s, ref)
+	if err != nil {
+		panic(err.Error())
+	}
+	return d
+}
+
+// unit is a unit of an amount of time.
+type unit int
+
+const (
+	days unit = iota
+	weeks
+	months
+	years
+	businessDays
+)
+
+// state is the state of parsing one input string.
+type state struct {
+	Parser
+	ref   dt.LocalDate
+	input string
+	words []string
+	pos   int
+	total int // the sum of the amounts parsed so far
+}
+
+var (
+	weekdays = map[string]time.Weekday{
+		"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday,
+		"wednesday": time.Wednesday, "thursday": time.Thursday, "friday": time.Friday,
+		"saturday": time.Saturday,
+		"sun":      time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+		"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+	}
+	numbers = map[string]int{
+		"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+		"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	}
+	ordinals = map[string]int{
+		"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5,
+	}
+)
+
+// peek returns the next word, or "" at the end of the input.
+func (st *state) peek() string {
+	return st.peekAt(0)
+}
+
+// peekAt returns the word n words after the next word.
+func (st *state) peekAt(n int) string {
+	if st.pos+n < len(st.words) {
+		return st.words[st.pos+n]
+	}
+	return ""
+}
+
+// next returns the next word and advances past it.
+func (st *state) next() string {
+	w := st.peek()
+	if w != "" {
+		st.pos++
+	}
+	return w
+}
+
+// accept advances past the next word if it is one of the words specified.
+func (st *state) accept(words ...string) bool {
+	for _, w := range words {
+		if st.peek() == w {
+			st.pos++
+			return true
+		}
+	}
+	return false
+}
+
+// expect advances past the next word, which must be the word specified.
+func (st *state) expect(w string) error {
+	if !st.accept(w) {
+		return st.expected(fmt.Sprintf("%q", w))
+	}
+	return nil
+}
+
+// expected returns an error describing what was expected at the current
+// position.
+func (st *state) expected(what string) error {
+	if st.pos >= len(st.words) {
+		return fmt.Errorf("expected %s at end of %q", what, st.input)
+	}
+	return fmt.Errorf("expected %s, found %q in %q", what, st.words[st.pos], st.input)
+}
+
+// date parses a date.
+func (st *state) date() (dt.LocalDate, error) {
+	st.accept("the")
+	w := st.peek()
+	if weekday, ok := weekdays[w]; ok {
+		st.pos++
+		return nextWeekday(st.ref, weekday), nil
+	}
+	if _, ok := ordinals[w]; ok || w == "last" && st.peekAt(2) == "of" {
+		return st.ordinal()
+	}
+	switch w {
+	case "":
+		return dt.LocalDate{}, st.expected("a date")
+	case "today", "now":
+		st.pos++
+		return st.ref, nil
+	case "tomorrow":
+		st.pos++
+		return st.ref.AddDate(0, 0, 1), nil
+	case "yesterday":
+		st.pos++
+		return st.ref.AddDate(0, 0, -1), nil
+	case "day":
+		st.pos++
+		if st.accept("after") {
+			return st.ref.AddDate(0, 0, 2), st.expect("tomorrow")
+		}
+		if st.accept("before") {
+			return st.ref.AddDate(0, 0, -2), st.expect("yesterday")
+		}
+		return dt.LocalDate{}, st.expected(`"after" or "before"`)
+	case "in":
+		st.pos++
+		n, u, err := st.amount()
+		if err != nil {
+			return dt.LocalDate{}, err
+		}
+		return st.add(st.ref, n, u), nil
+	case "next", "last", "previous", "this":
+		return st.relative()
+	case "start", "beginning", "end":
+		st.pos++
+		if err := st.expect("of"); err != nil {
+			return dt.LocalDate{}, err
+		}
+		start, end, err := st.period()
+		if w == "end" {
+			return end, err
+		}
+		return start, err
+	}
+	if _, isNumber := st.number(w); isNumber {
+		return st.offset()
+	}
+	if d, err := dt.ParseDate(w); err == nil {
+		st.pos++
+		return d, nil
+	}
+	return dt.LocalDate{}, st.expected("a date")
+}
+
+// relative parses a date that starts with "next", "last", "previous" or
+// "this".
+func (st *state) relative() (dt.LocalDate, error) {
+	which := st.next()
+	direction := map[string]int{"next": 1, "last": -1, "previous": -1, "this": 0}[which]
+	w := st.peek()
+	if weekday, ok := weekdays[w]; ok {
+		st.pos++
+		switch direction {
+		case 1:
+			return nextWeekday(st.ref, weekday), nil
+		case -1:
+			return prevWeekday(st.ref, weekday), nil
+		}
+		start := st.weekStart(st.ref)
+		return start.AddDate(0, 0, (int(weekday)-int(start.Weekday())+7)%7), nil
+	}
+	if w == "business" && direction != 0 {
+		st.pos++
+		if err := st.expect("day"); err != nil {
+			return dt.LocalDate{}, err
+		}
+		return st.Calendar.AddBusinessDays(st.ref, direction), nil
+	}
+	st.pos--
+	start, _, err := st.period()
+	return start, err
+}
+
+// ordinal parses "first day of", "last day of", "second monday of" or
+// "last friday of" followed by a period.
+func (st *state) ordinal() (dt.LocalDate, error) {
+	w := st.next()
+	n := ordinals[w]
+	if w == "last" {
+		n = -1
+	}
+	day := st.peek()
+	weekday, isWeekday := weekdays[day]
+	if !isWeekday && day != "day" {
+		return dt.LocalDate{}, st.expected(`"day" or a day of the week`)
+	}
+	st.pos++
+	if err := st.expect("of"); err != nil {
+		return dt.LocalDate{}, err
+	}
+	start, end, err := st.period()
+	if err != nil {
+		return dt.LocalDate{}, err
+	}
+
+	var d dt.LocalDate
+	switch {
+	case !isWeekday && n > 0:
+		d = start.AddDate(0, 0, n-1)
+	case !isWeekday:
+		d = end
+	case n > 0:
+		d = nextWeekday(start.AddDate(0, 0, -1), weekday).AddDate(0, 0, 7*(n-1))
+	default:
+		d = prevWeekday(end.AddDate(0, 0, 1), weekday)
+	}
+	if d.After(end) {
+		return dt.LocalDate{}, fmt.Errorf("there is no %s %s of the period in %q", w, day, st.input)
+	}
+	return d, nil
+}
+
+// period parses a week, month or year, such as "this month", "next week"
+// or "the year", and returns its first and last days.
+func (st *state) period() (start dt.LocalDate, end dt.LocalDate, err error) {
+	st.accept("the")
+	direction := 0
+	switch {
+	case st.accept("next"):
+		direction = 1
+	case st.accept("last", "previous"):
+		direction = -1
+	default:
+		st.accept("this")
+	}
+	switch st.peek() {
+	case "week":
+		start = st.weekStart(st.ref).AddDate(0, 0, 7*direction)
+		end = start.AddDate(0, 0, 6)
+	case "month":
+		start = dt.Date(st.ref.Year(), st.ref.Month()+time.Month(direction), 1)
+		end = start.AddDate(0, 1, -1)
+	case "year":
+		start = dt.Date(st.ref.Year()+direction, time.January, 1)
+		end = dt.Date(start.Year(), time.December, 31)
+	default:
+		return start, end, st.expected(`"week", "month" or "year"`)
+	}
+	st.pos++
+	return start, end, nil
+}
+
+// offset parses an amount of time followed by "ago", "from now", or
+// "after", "before" or "from" and a date.
+func (st *state) offset() (dt.LocalDate, error) {
+	n, u, err := st.amount()
+	if err != nil {
+		return dt.LocalDate{}, err
+	}
+	switch st.peek() {
+	case "ago":
+		st.pos++
+		return st.add(st.ref, -n, u), nil
+	case "after", "from":
+		st.pos++
+		if st.accept("now") {
+			return st.add(st.ref, n, u), nil
+		}
+		d, err := st.date()
+		return st.add(d, n, u), err
+	case "before":
+		st.pos++
+		d, err := st.date()
+		return st.add(d, -n, u), err
+	}
+	return dt.LocalDate{}, st.expected(`"ago", "from", "after" or "before"`)
+}
+
+// maxAmount is the largest total number of units that can be added to a
+// date, including amounts added to dates that are themselves offsets, such
+// as "2 days after 3 weeks from now". It keeps dates in the range of years
+// that can be represented, and limits the time spent counting business days.
+const maxAmount = 10000
+
+// amount parses a number followed by a unit, such as "3 weeks".
+func (st *state) amount() (int, unit, error) {
+	w := st.peek()
+	n, ok := st.number(w)
+	if !ok {
+		return 0, 0, st.expected("a number")
+	}
+	if n > maxAmount {
+		return 0, 0, fmt.Errorf("number %s is more than %d in %q", w, maxAmount, st.input)
+	}
+	if st.total += n; st.total > maxAmount {
+		return 0, 0, fmt.Errorf("numbers add up to more than %d in %q", maxAmount, st.input)
+	}
+	st.pos++
+	var u unit
+	switch st.peek() {
+	case "day", "days":
+		u = days
+	case "week", "weeks":
+		u = weeks
+	case "month", "months":
+		u = months
+	case "year", "years":
+		u = years
+	case "business", "working":
+		st.pos++
+		if w := st.peek(); w != "day" && w != "days" {
+			return 0, 0, st.expected(`"days"`)
+		}
+		u = businessDays
+	default:
+		return 0, 0, st.expected("a unit of time")
+	}
+	st.pos++
+	return n, u, nil
+}
+
+// number returns the value of a number word or digits.
+func (st *state) number(w string) (int, bool) {
+	if n, ok := numbers[w]; ok {
+		return n, true
+	}
+	if w == "" || strings.Trim(w, "0123456789") != "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(w)
+	if err != nil {
+		// too many digits for an int
+		return math.MaxInt, true
+	}
+	return n, true
+}
+
+// add adds n units to d.
+func (st *state) add(d dt.LocalDate, n int, u unit) dt.LocalDate {
+	switch u {
+	case weeks:
+		return d.AddDate(0, 0, 7*n)
+	case months:
+		return addMonths(d, n)
+	case years:
+		return addMonths(d, 12*n)
+	case businessDays:
+		return st.Calendar.AddBusinessDays(d, n)
+	}
+	return d.AddDate(0, 0, n)
+}
+
+// weekStart returns the first day of the week that contains d.
+func (st *state) weekStart(d dt.LocalDate) dt.LocalDate {
+	return d.AddDate(0, 0, -((int(d.Weekday())-int(st.WeekStart))+7)%7)
+}
+
+// addMonths adds months to d, keeping the day of the month unless the
+// resulting month is shorter.
+func addMonths(d dt.LocalDate, n int) dt.LocalDate {
+	first := dt.Date(d.Year(), d.Month()+time.Month(n), 1)
+	last := first.AddDate(0, 1, -1)
+	return first.AddDate(0, 0, min(d.Day(), last.Day())-1)
+}
+
+// nextWeekday returns the first date after d that falls on weekday.
+func nextWeekday(d dt.LocalDate, weekday time.Weekday) dt.LocalDate {
+	return d.AddDate(0, 0, (int(weekday)-int(d.Weekday())+6)%7+1)
+}
+
+// prevWeekday returns the last date before d that falls on weekday.
+func prevWeekday(d dt.LocalDate, weekday time.Weekday) dt.LocalDate {
+	return d.AddDate(0, 0, -((int(d.Weekday())-int(weekday)+6)%7 + 1))
+}
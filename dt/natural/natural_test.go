@@ -0,0 +1,157 @@
+package natural
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	// a Saturday
+	ref := dt.Date(2026, 10, 17)
+	tests := []struct {
+		text string
+		want dt.LocalDate
+	}{
+		{"today", ref},
+		{"Now", ref},
+		{"tomorrow", dt.Date(2026, 10, 18)},
+		{"yesterday", dt.Date(2026, 10, 16)},
+		{"the day after tomorrow", dt.Date(2026, 10, 19)},
+		{"day before yesterday", dt.Date(2026, 10, 15)},
+		{"friday", dt.Date(2026, 10, 23)},
+		{"saturday", dt.Date(2026, 10, 24)},
+		{"next friday", dt.Date(2026, 10, 23)},
+		{"next sat", dt.Date(2026, 10, 24)},
+		{"last friday", dt.Date(2026, 10, 16)},
+		{"previous saturday", dt.Date(2026, 10, 10)},
+		{"this monday", dt.Date(2026, 10, 12)},
+		{"this saturday", dt.Date(2026, 10, 17)},
+		{"this week", dt.Date(2026, 10, 11)},
+		{"next week", dt.Date(2026, 10, 18)},
+		{"last week", dt.Date(2026, 10, 4)},
+		{"this month", dt.Date(2026, 10, 1)},
+		{"next month", dt.Date(2026, 11, 1)},
+		{"last month", dt.Date(2026, 9, 1)},
+		{"next year", dt.Date(2027, 1, 1)},
+		{"last year", dt.Date(2025, 1, 1)},
+		{"next business day", dt.Date(2026, 10, 19)},
+		{"previous business day", dt.Date(2026, 10, 16)},
+		{"last business day", dt.Date(2026, 10, 16)},
+		{"first day of next month", dt.Date(2026, 11, 1)},
+		{"last day of this month", dt.Date(2026, 10, 31)},
+		{"last day of last month", dt.Date(2026, 9, 30)},
+		{"the last day of the year", dt.Date(2026, 12, 31)},
+		{"third day of next week", dt.Date(2026, 10, 20)},
+		{"start of next week", dt.Date(2026, 10, 18)},
+		{"end of the month", dt.Date(2026, 10, 31)},
+		{"beginning of last year", dt.Date(2025, 1, 1)},
+		{"first monday of next month", dt.Date(2026, 11, 2)},
+		{"second tuesday of this month", dt.Date(2026, 10, 13)},
+		{"last friday of this month", dt.Date(2026, 10, 30)},
+		{"last sunday of the year", dt.Date(2026, 12, 27)},
+		{"in 3 days", dt.Date(2026, 10, 20)},
+		{"in 3 weeks", dt.Date(2026, 11, 7)},
+		{"in a month", dt.Date(2026, 11, 17)},
+		{"in two years", dt.Date(2028, 10, 17)},
+		{"in 1 business day", dt.Date(2026, 10, 19)},
+		{"2 business days ago", dt.Date(2026, 10, 15)},
+		{"10 days ago", dt.Date(2026, 10, 7)},
+		{"a week from now", dt.Date(2026, 10, 24)},
+		{"3 working days after next monday", dt.Date(2026, 10, 22)},
+		{"2 days before the end of the month", dt.Date(2026, 10, 29)},
+		{"1 week from tomorrow", dt.Date(2026, 10, 25)},
+		{"  Next   Friday, ", dt.Date(2026, 10, 23)},
+		{"2026-12-25", dt.Date(2026, 12, 25)},
+		{"2 days after 2026-12-25", dt.Date(2026, 12, 27)},
+		{"2 days after 3 weeks from now", dt.Date(2026, 11, 9)},
+		{"5000 years after 5000 days after today", dt.Date(7040, 6, 25)},
+		{"in 10000 years", dt.Date(12026, 10, 17)},
+		{"10000 days ago", dt.Date(1999, 6, 1)},
+	}
+	for _, tt := range tests {
+		d, err := Parse(tt.text, ref)
+		if assert.NoError(t, err, tt.text) {
+			assert.Equal(t, tt.want, d, tt.text)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	ref := dt.Date(2026, 10, 17)
+	tests := []struct {
+		text    string
+		errText string
+	}{
+		{"", `expected a date at end of ""`},
+		{"someday", `expected a date, found "someday" in "someday"`},
+		{"in", `expected a number at end of "in"`},
+		{"in three", `expected a unit of time at end of "in three"`},
+		{"in 3 fortnights", `expected a unit of time, found "fortnights" in "in 3 fortnights"`},
+		{"in 3 business hours", `expected "days", found "hours" in "in 3 business hours"`},
+		{"3 days", `expected "ago", "from", "after" or "before" at end of "3 days"`},
+		{"today please", `expected end of date, found "please" in "today please"`},
+		{"next decade", `expected "week", "month" or "year", found "decade" in "next decade"`},
+		{"end of march", `expected "week", "month" or "year", found "march" in "end of march"`},
+		{"day after today", `expected "tomorrow", found "today" in "day after today"`},
+		{"first week of next month", `expected "day" or a day of the week, found "week" in "first week of next month"`},
+		{"fifth monday of this month", `there is no fifth monday of the period in "fifth monday of this month"`},
+		{"this business day", `expected "week", "month" or "year", found "business" in "this business day"`},
+		{"in 9223372036854775807 days", `number 9223372036854775807 is more than 10000 in "in 9223372036854775807 days"`},
+		{"in 99999999999999999999 days", `number 99999999999999999999 is more than 10000 in "in 99999999999999999999 days"`},
+		{"in 9999999999999 years", `number 9999999999999 is more than 10000 in "in 9999999999999 years"`},
+		{"99999999 business days ago", `number 99999999 is more than 10000 in "99999999 business days ago"`},
+		{"10001 weeks after next monday", `number 10001 is more than 10000 in "10001 weeks after next monday"`},
+		{"5000 years after 5001 days after today", `numbers add up to more than 10000 in "5000 years after 5001 days after today"`},
+		{strings.Repeat("10000 business days after ", 2000) + "today", `numbers add up to more than 10000 in "` + strings.Repeat("10000 business days after ", 2000) + `today"`},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.text, ref)
+		if assert.Error(t, err, tt.text) {
+			assert.Equal(t, tt.errText, err.Error(), tt.text)
+		}
+	}
+}
+
+func TestParser(t *testing.T) {
+	ref := dt.Date(2026, 10, 17)
+	p := Parser{
+		WeekStart: time.Monday,
+		Calendar:  dt.NewBusinessCalendar(dt.NewHolidaySet(dt.Date(2026, 10, 19))),
+	}
+	tests := []struct {
+		text string
+		want dt.LocalDate
+	}{
+		{"this week", dt.Date(2026, 10, 12)},
+		{"next week", dt.Date(2026, 10, 19)},
+		{"end of this week", dt.Date(2026, 10, 18)},
+		{"this sunday", dt.Date(2026, 10, 18)},
+		{"next business day", dt.Date(2026, 10, 20)},
+		{"in 2 business days", dt.Date(2026, 10, 21)},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, p.MustParse(tt.text, ref), tt.text)
+	}
+	assert.Panics(t, func() { p.MustParse("whenever", ref) })
+}
+
+func TestAddMonths(t *testing.T) {
+	ref := dt.Date(2026, 1, 31)
+	tests := []struct {
+		text string
+		want dt.LocalDate
+	}{
+		{"in 1 month", dt.Date(2026, 2, 28)},
+		{"in 2 months", dt.Date(2026, 3, 31)},
+		{"1 month ago", dt.Date(2025, 12, 31)},
+		{"in 13 months", dt.Date(2027, 2, 28)},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Parser{}.MustParse(tt.text, ref), tt.text)
+	}
+	assert.Equal(t, dt.Date(2023, 2, 28), Parser{}.MustParse("1 year ago", dt.Date(2024, 2, 29)))
+}
@@ -0,0 +1,280 @@
+package dt
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/xml"
+)
+
+// NullLocalDate represents a LocalDate that may be null.
+// NullLocalDate implements the sql.Scanner interface so
+// it can be used as a scan destination, similar to sql.NullTime.
+//
+// NullLocalDate also implements the JSON, XML and text marshaling
+// interfaces. A null value is encoded as a JSON null, as an empty
+// string in text, and is omitted from XML. The zero value of
+// NullLocalDate is null, and IsZero reports true for it, so the
+// encoding/json "omitzero" option omits null values.
+type NullLocalDate struct {
+	LocalDate LocalDate
+	Valid     bool // Valid is true if LocalDate is not NULL
+}
+
+// NewNullLocalDate returns a NullLocalDate for d. The zero local date
+// is treated as absent, so if d is zero the result is null.
+func NewNullLocalDate(d LocalDate) NullLocalDate {
+	return NullLocalDate{LocalDate: d, Valid: !d.IsZero()}
+}
+
+// IsZero reports whether n is null.
+func (n NullLocalDate) IsZero() bool {
+	return !n.Valid
+}
+
+// String returns a string representation of n. A null date
+// is represented by an empty string.
+func (n NullLocalDate) String() string {
+	if !n.Valid {
+		return ""
+	}
+	return toString(n.LocalDate)
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullLocalDate) Scan(src interface{}) error {
+	if src == nil {
+		n.LocalDate, n.Valid = LocalDate{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.LocalDate.Scan(src)
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullLocalDate) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.LocalDate.Value()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// A null date is encoded as null, otherwise the date is encoded
+// in the same way as LocalDate.
+func (n NullLocalDate) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.LocalDate.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both null and the empty string are decoded as a null date.
+func (n *NullLocalDate) UnmarshalJSON(data []byte) error {
+	if isNullJSON(data) {
+		n.LocalDate, n.Valid = LocalDate{}, false
+		return nil
+	}
+	return n.UnmarshalText(data)
+}
+
+// MarshalText implements the encoding.TextMarshaller interface.
+// A null date is encoded as an empty string.
+func (n NullLocalDate) MarshalText() ([]byte, error) {
+	return []byte(n.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaller interface.
+// An empty string is decoded as a null date.
+func (n *NullLocalDate) UnmarshalText(data []byte) error {
+	return n.parse(string(data))
+}
+
+func (n NullLocalDate) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !n.Valid {
+		return nil
+	}
+	return e.EncodeElement(toString(n.LocalDate), start)
+}
+
+func (n *NullLocalDate) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var s string
+
+	if err := decoder.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	return n.parse(s)
+}
+
+func (n NullLocalDate) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !n.Valid {
+		return xml.Attr{}, nil
+	}
+	return xml.Attr{
+		Name:  name,
+		Value: toString(n.LocalDate),
+	}, nil
+}
+
+func (n *NullLocalDate) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.parse(attr.Value)
+}
+
+// parse sets n from the text s, which is null if it is empty.
+func (n *NullLocalDate) parse(s string) error {
+	if isNullText(s) {
+		n.LocalDate, n.Valid = LocalDate{}, false
+		return nil
+	}
+	d, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	n.LocalDate, n.Valid = d, true
+	return nil
+}
+
+// NullLocalDateTime represents a LocalDateTime that may be null.
+// NullLocalDateTime implements the sql.Scanner interface so
+// it can be used as a scan destination, similar to sql.NullTime.
+//
+// NullLocalDateTime also implements the JSON, XML and text marshaling
+// interfaces in the same way as NullLocalDate.
+type NullLocalDateTime struct {
+	LocalDateTime LocalDateTime
+	Valid         bool // Valid is true if LocalDateTime is not NULL
+}
+
+// NewNullLocalDateTime returns a NullLocalDateTime for dt. The zero local
+// date-time is treated as absent, so if dt is zero the result is null.
+func NewNullLocalDateTime(dt LocalDateTime) NullLocalDateTime {
+	return NullLocalDateTime{LocalDateTime: dt, Valid: !dt.IsZero()}
+}
+
+// IsZero reports whether n is null.
+func (n NullLocalDateTime) IsZero() bool {
+	return !n.Valid
+}
+
+// String returns a string representation of n. A null date-time
+// is represented by an empty string.
+func (n NullLocalDateTime) String() string {
+	if !n.Valid {
+		return ""
+	}
+	return localDateTimeString(n.LocalDateTime)
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullLocalDateTime) Scan(src interface{}) error {
+	if src == nil {
+		n.LocalDateTime, n.Valid = LocalDateTime{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.LocalDateTime.Scan(src)
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullLocalDateTime) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.LocalDateTime.Value()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// A null date-time is encoded as null, otherwise the date-time
+// is encoded in the same way as LocalDateTime.
+func (n NullLocalDateTime) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.LocalDateTime.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both null and the empty string are decoded as a null date-time.
+func (n *NullLocalDateTime) UnmarshalJSON(data []byte) error {
+	if isNullJSON(data) {
+		n.LocalDateTime, n.Valid = LocalDateTime{}, false
+		return nil
+	}
+	return n.UnmarshalText(data)
+}
+
+// MarshalText implements the encoding.TextMarshaller interface.
+// A null date-time is encoded as an empty string.
+func (n NullLocalDateTime) MarshalText() ([]byte, error) {
+	return []byte(n.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaller interface.
+// An empty string is decoded as a null date-time.
+func (n *NullLocalDateTime) UnmarshalText(data []byte) error {
+	return n.parse(string(data))
+}
+
+func (n NullLocalDateTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !n.Valid {
+		return nil
+	}
+	return e.EncodeElement(localDateTimeString(n.LocalDateTime), start)
+}
+
+func (n *NullLocalDateTime) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var s string
+
+	if err := decoder.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	return n.parse(s)
+}
+
+func (n NullLocalDateTime) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !n.Valid {
+		return xml.Attr{}, nil
+	}
+	return xml.Attr{
+		Name:  name,
+		Value: localDateTimeString(n.LocalDateTime),
+	}, nil
+}
+
+func (n *NullLocalDateTime) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.parse(attr.Value)
+}
+
+// parse sets n from the text s, which is null if it is empty.
+func (n *NullLocalDateTime) parse(s string) error {
+	if isNullText(s) {
+		n.LocalDateTime, n.Valid = LocalDateTime{}, false
+		return nil
+	}
+	dt, err := ParseDateTime(s)
+	if err != nil {
+		return err
+	}
+	n.LocalDateTime, n.Valid = dt, true
+	return nil
+}
+
+// isNullJSON reports whether data is the JSON null literal.
+func isNullJSON(data []byte) bool {
+	return string(bytes.TrimSpace(data)) == "null"
+}
+
+// isNullText reports whether the text s represents a null value,
+// which is the case if it is empty once the leading and trailing
+// space and quotation marks that the parse functions ignore are
+// removed.
+func isNullText(s string) bool {
+	for _, c := range s {
+		switch c {
+		case ' ', '\t', '"', '\'':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+package dt
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullLocalDateScan(t *testing.T) {
+	assert := assert.New(t)
+
+	var n NullLocalDate
+	assert.NoError(n.Scan(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)))
+	assert.True(n.Valid)
+	assert.Equal("2021-01-02", n.LocalDate.String())
+
+	assert.NoError(n.Scan(nil))
+	assert.False(n.Valid)
+	assert.True(n.LocalDate.IsZero())
+
+	assert.NoError(n.Scan([]byte("2021-01-03")))
+	assert.True(n.Valid)
+	assert.Equal("2021-01-03", n.LocalDate.String())
+
+	assert.Error(n.Scan(3.14))
+}
+
+func TestNullLocalDateValue(t *testing.T) {
+	assert := assert.New(t)
+
+	v, err := NullLocalDate{}.Value()
+	assert.NoError(err)
+	assert.Nil(v)
+
+	v, err = NullLocalDate{LocalDate: Date(2021, time.January, 2), Valid: true}.Value()
+	assert.NoError(err)
+	assert.Equal("2021-01-02", v)
+}
+
+func TestNullJSON(t *testing.T) {
+	assert := assert.New(t)
+	type testStruct struct {
+		Date         NullLocalDate     `json:"date"`
+		DateTime     NullLocalDateTime `json:"dateTime"`
+		OptDate      NullLocalDate     `json:"optDate,omitzero"`
+		OptDateTime  NullLocalDateTime `json:"optDateTime,omitzero"`
+		PlainDate    LocalDate         `json:"plainDate,omitzero"`
+		PlainPointer *LocalDate        `json:"plainPointer"`
+	}
+
+	var st testStruct
+	b, err := json.Marshal(&st)
+	assert.NoError(err)
+	assert.Equal(`{"date":null,"dateTime":null,"plainPointer":null}`, string(b))
+
+	var st2 testStruct
+	assert.NoError(json.Unmarshal(b, &st2))
+	assert.Equal(st, st2)
+
+	st = testStruct{
+		Date:        NewNullLocalDate(Date(2021, time.January, 2)),
+		DateTime:    NewNullLocalDateTime(DateTime(2021, time.January, 2, 3, 4, 5)),
+		OptDate:     NewNullLocalDate(Date(2021, time.January, 3)),
+		OptDateTime: NewNullLocalDateTime(DateTime(2021, time.January, 3, 4, 5, 6)),
+		PlainDate:   Date(2021, time.January, 4),
+	}
+	b, err = json.Marshal(&st)
+	assert.NoError(err)
+	assert.Equal(`{"date":"2021-01-02","dateTime":"2021-01-02T03:04:05",`+
+		`"optDate":"2021-01-03","optDateTime":"2021-01-03T04:05:06",`+
+		`"plainDate":"2021-01-04","plainPointer":null}`, string(b))
+
+	st2 = testStruct{}
+	assert.NoError(json.Unmarshal(b, &st2))
+	assert.Equal(st, st2)
+
+	// null and the empty string both decode as null
+	st2 = testStruct{}
+	assert.NoError(json.Unmarshal([]byte(`{"date":"","dateTime":null,"plainDate":null}`), &st2))
+	assert.False(st2.Date.Valid)
+	assert.False(st2.DateTime.Valid)
+	assert.True(st2.PlainDate.IsZero())
+
+	assert.Error(json.Unmarshal([]byte(`{"date":"xxx"}`), &st2))
+}
+
+func TestNewNullZero(t *testing.T) {
+	assert := assert.New(t)
+	assert.False(NewNullLocalDate(LocalDate{}).Valid)
+	assert.False(NewNullLocalDateTime(LocalDateTime{}).Valid)
+	assert.True(NewNullLocalDate(Date(1, time.January, 2)).Valid)
+	assert.True(NullLocalDate{}.IsZero())
+	assert.True(NullLocalDateTime{}.IsZero())
+}
+
+func TestNullText(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NullLocalDate{}.MarshalText()
+	assert.NoError(err)
+	assert.Equal("", string(b))
+
+	var n NullLocalDate
+	assert.NoError(n.UnmarshalText([]byte("2021-01-02")))
+	assert.True(n.Valid)
+	assert.Equal("2021-01-02", n.String())
+	assert.NoError(n.UnmarshalText(nil))
+	assert.False(n.Valid)
+
+	var ndt NullLocalDateTime
+	assert.NoError(ndt.UnmarshalText([]byte("2021-01-02T03:04:05")))
+	assert.True(ndt.Valid)
+	b, err = ndt.MarshalText()
+	assert.NoError(err)
+	assert.Equal("2021-01-02T03:04:05", string(b))
+	assert.NoError(ndt.UnmarshalText([]byte("")))
+	assert.False(ndt.Valid)
+}
+
+func TestNullXML(t *testing.T) {
+	assert := assert.New(t)
+	type testStruct struct {
+		XMLName      xml.Name          `xml:"TestCase"`
+		Date         NullLocalDate     `xml:"date"`
+		DateTime     NullLocalDateTime `xml:"dateTime"`
+		DateAttr     NullLocalDate     `xml:"dateAttr,attr"`
+		DateTimeAttr NullLocalDateTime `xml:"dateTimeAttr,attr"`
+	}
+
+	testCases := []struct {
+		st  testStruct
+		xml string
+	}{
+		{
+			st:  testStruct{},
+			xml: `<TestCase></TestCase>`,
+		},
+		{
+			st: testStruct{
+				Date:         NewNullLocalDate(Date(2021, time.January, 2)),
+				DateTime:     NewNullLocalDateTime(DateTime(2021, time.January, 2, 3, 4, 5)),
+				DateAttr:     NewNullLocalDate(Date(2021, time.January, 3)),
+				DateTimeAttr: NewNullLocalDateTime(DateTime(2021, time.January, 3, 4, 5, 6)),
+			},
+			xml: `<TestCase dateAttr="2021-01-03" dateTimeAttr="2021-01-03T04:05:06">` +
+				`<date>2021-01-02</date><dateTime>2021-01-02T03:04:05</dateTime></TestCase>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		b, err := xml.Marshal(&tc.st)
+		assert.NoError(err)
+		assert.Equal(tc.xml, string(b))
+		var st testStruct
+		err = xml.Unmarshal([]byte(tc.xml), &st)
+		assert.NoError(err)
+		st.XMLName = xml.Name{}
+		assert.Equal(tc.st, st)
+	}
+
+	// empty elements and attributes are null
+	var st testStruct
+	err := xml.Unmarshal([]byte(`<TestCase dateAttr=""><date></date><dateTime/></TestCase>`), &st)
+	assert.NoError(err)
+	assert.False(st.Date.Valid)
+	assert.False(st.DateTime.Valid)
+	assert.False(st.DateAttr.Valid)
+}
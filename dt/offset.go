@@ -0,0 +1,239 @@
+package dt
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OffsetDateTime represents a local date-time with a fixed offset from UTC,
+// such as "2024-05-01T09:00:00+10:00". Unlike LocalDateTime, an OffsetDateTime
+// identifies a unique instant in time. Unlike ZonedDateTime, it has no
+// timezone rules, so date arithmetic always uses the same offset.
+//
+// OffsetDateTime is useful for exchanging timestamps with other systems
+// using RFC 3339, because it preserves the offset that the timestamp was
+// received with.
+//
+// Like LocalDateTime, OffsetDateTime only specifies the time to second
+// accuracy. The zero value of OffsetDateTime is midnight, January 1, year 1 UTC.
+type OffsetDateTime struct {
+	t time.Time
+}
+
+var (
+	errInvalidOffsetDateTimeFormat = errors.New("invalid offset date-time format")
+)
+
+// OffsetDateTimeOf returns the offset date-time for the instant t, using
+// the offset from UTC of t in its location. Any fraction of a second is discarded.
+func OffsetDateTimeOf(t time.Time) OffsetDateTime {
+	_, offset := t.Zone()
+	return OffsetDateTime{t: t.Truncate(time.Second).In(time.FixedZone("", offset))}
+}
+
+// AtOffset returns the offset date-time for the local date-time dt with the
+// given offset in seconds east of UTC.
+func (dt LocalDateTime) AtOffset(offset int) OffsetDateTime {
+	return OffsetDateTime{t: time.Unix(dt.Unix()-int64(offset), 0).In(time.FixedZone("", offset))}
+}
+
+// Time returns the instant in time represented by o, in a fixed location
+// with the offset of o.
+func (o OffsetDateTime) Time() time.Time {
+	return o.t
+}
+
+// LocalDateTime returns the local date-time of o.
+func (o OffsetDateTime) LocalDateTime() LocalDateTime {
+	return toLocalDateTime(o.t)
+}
+
+// LocalDate returns the local date of o.
+func (o OffsetDateTime) LocalDate() LocalDate {
+	return toLocalDate(o.t)
+}
+
+// Offset returns the offset of o in seconds east of UTC.
+func (o OffsetDateTime) Offset() int {
+	_, offset := o.t.Zone()
+	return offset
+}
+
+// WithOffset returns the offset date-time for the same instant as o,
+// with the given offset in seconds east of UTC.
+func (o OffsetDateTime) WithOffset(offset int) OffsetDateTime {
+	return OffsetDateTime{t: o.t.In(time.FixedZone("", offset))}
+}
+
+// InZone returns the zoned date-time for the same instant as o in the location loc.
+func (o OffsetDateTime) InZone(loc *time.Location) ZonedDateTime {
+	return ZonedDateTime{t: o.t.In(loc)}
+}
+
+// IsZero reports whether o represents the zero offset date-time,
+// midnight, January 1, year 1 UTC.
+func (o OffsetDateTime) IsZero() bool {
+	return o.t.IsZero()
+}
+
+// After reports whether the instant o is after u.
+func (o OffsetDateTime) After(u OffsetDateTime) bool {
+	return o.t.After(u.t)
+}
+
+// Before reports whether the instant o is before u.
+func (o OffsetDateTime) Before(u OffsetDateTime) bool {
+	return o.t.Before(u.t)
+}
+
+// Equal reports whether o and u represent the same local date-time and offset.
+// To compare instants regardless of the offset, compare o.Time() and u.Time().
+func (o OffsetDateTime) Equal(u OffsetDateTime) bool {
+	return o.t.Equal(u.t) && o.Offset() == u.Offset()
+}
+
+// Add returns the offset date-time o + duration.
+func (o OffsetDateTime) Add(duration time.Duration) OffsetDateTime {
+	return OffsetDateTime{t: o.t.Add(toSeconds(duration))}
+}
+
+// Sub returns the elapsed duration o-u.
+func (o OffsetDateTime) Sub(u OffsetDateTime) time.Duration {
+	return o.t.Sub(u.t)
+}
+
+// AddDate returns the offset date-time corresponding to adding the given number of
+// years, months and days to o. The offset is unchanged.
+func (o OffsetDateTime) AddDate(years int, months int, days int) OffsetDateTime {
+	return OffsetDateTime{t: o.t.AddDate(years, months, days)}
+}
+
+// AddPeriod returns the offset date-time corresponding to adding the period p
+// to o. It is equivalent to o.AddDate(p.Years(), p.Months(), p.Days()).
+func (o OffsetDateTime) AddPeriod(p Period) OffsetDateTime {
+	return o.AddDate(p.years, p.months, p.days)
+}
+
+// String returns a string representation of o in the RFC 3339 format,
+// for example "2024-05-01T09:00:00+10:00". An offset of zero is
+// represented as "Z".
+func (o OffsetDateTime) String() string {
+	return offsetDateTimeString(o)
+}
+
+// offsetDateTimeString returns the string representation of the offset date-time.
+func offsetDateTimeString(o OffsetDateTime) string {
+	offset := o.Offset()
+	if offset == 0 {
+		return localDateTimeString(o.LocalDateTime()) + "Z"
+	}
+	return localDateTimeString(o.LocalDateTime()) + offsetString(offset)
+}
+
+// ParseOffsetDateTime attempts to parse a string into an offset date-time.
+// Leading and trailing space and quotation marks are ignored. The string
+// consists of a date-time in any of the formats accepted by ParseDateTime,
+// followed by a UTC offset, which is required. The offset is "Z" or in one
+// of the ISO 8601 forms ±hh, ±hhmm or ±hh:mm. For example,
+// "2024-05-01T09:00:00Z", "2024-05-01T09:00+10" and "20240501T090000+1000"
+// are all valid.
+func ParseOffsetDateTime(s string) (OffsetDateTime, error) {
+	s = strings.Trim(s, " \t\"'")
+	datetimeText, offsetText := splitOffset(s)
+	if offsetText == "" {
+		return OffsetDateTime{}, errInvalidOffsetDateTimeFormat
+	}
+
+	dt, err := ParseDateTime(datetimeText)
+	if err != nil {
+		return OffsetDateTime{}, err
+	}
+
+	var offset int
+	if offsetText != "Z" && offsetText != "z" {
+		if offset, err = parseOffset(offsetText); err != nil {
+			return OffsetDateTime{}, err
+		}
+	}
+	return dt.AtOffset(offset), nil
+}
+
+// MustParseOffsetDateTime is similar to ParseOffsetDateTime, but instead of returning
+// an error it will panic if s is not in the expected format.
+func MustParseOffsetDateTime(s string) OffsetDateTime {
+	o, err := ParseOffsetDateTime(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return o
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The offset date-time is a quoted string in the RFC 3339 format.
+func (o OffsetDateTime) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + offsetDateTimeString(o) + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The offset date-time is expected to be a quoted string in an
+// RFC 3339 or ISO 8601 format.
+// By convention, a JSON null is a no-op.
+func (o *OffsetDateTime) UnmarshalJSON(data []byte) (err error) {
+	if isNullJSON(data) {
+		return nil
+	}
+	s := string(data)
+	*o, err = ParseOffsetDateTime(s)
+	return
+}
+
+// MarshalText implements the encoding.TextMarshaller interface.
+// The offset date-time is in the RFC 3339 format.
+func (o OffsetDateTime) MarshalText() ([]byte, error) {
+	return []byte(offsetDateTimeString(o)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaller interface.
+// The offset date-time is expected to be in an RFC 3339 or ISO 8601 format.
+func (o *OffsetDateTime) UnmarshalText(data []byte) (err error) {
+	s := string(data)
+	*o, err = ParseOffsetDateTime(s)
+	return
+}
+
+// Scan implements the sql.Scanner interface. The value is expected to be a
+// time.Time, in which case the offset is taken from the time's location, or
+// a string or []byte in one of the formats recognised by ParseOffsetDateTime.
+// The date and time may also be separated by a space instead of a "T".
+func (o *OffsetDateTime) Scan(src interface{}) (err error) {
+	switch v := src.(type) {
+	case time.Time:
+		*o = OffsetDateTimeOf(v)
+	case string:
+		*o, err = parseSQLOffsetDateTime(v)
+	case []byte:
+		*o, err = parseSQLOffsetDateTime(string(v))
+	case nil:
+		err = errors.New("cannot scan NULL into OffsetDateTime")
+	default:
+		err = fmt.Errorf("cannot scan %T into OffsetDateTime", src)
+	}
+	return
+}
+
+// parseSQLOffsetDateTime parses an offset date-time returned by a database driver
+// as text, where the date and time are commonly separated by a space.
+func parseSQLOffsetDateTime(s string) (OffsetDateTime, error) {
+	s = strings.Trim(s, " \t\"'")
+	return ParseOffsetDateTime(strings.Replace(s, " ", "T", 1))
+}
+
+// Value implements the driver.Valuer interface. The offset date-time is
+// passed to the database as a string in the RFC 3339 format, which is
+// accepted by TIMESTAMP WITH TIME ZONE columns.
+func (o OffsetDateTime) Value() (driver.Value, error) {
+	return offsetDateTimeString(o), nil
+}
@@ -0,0 +1,122 @@
+package dt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOffsetDateTime(t *testing.T) {
+	testCases := []struct {
+		Text     string
+		Valid    bool
+		Expected string
+		Offset   int
+	}{
+		{Text: "2024-05-01T09:00:00Z", Valid: true, Expected: "2024-05-01T09:00:00Z"},
+		{Text: "2024-05-01T09:00:00+00:00", Valid: true, Expected: "2024-05-01T09:00:00Z"},
+		{Text: "2024-05-01T09:00:00+10:00", Valid: true, Expected: "2024-05-01T09:00:00+10:00", Offset: 36000},
+		{Text: "2024-05-01T09:00+10", Valid: true, Expected: "2024-05-01T09:00:00+10:00", Offset: 36000},
+		{Text: "20240501T090000+1000", Valid: true, Expected: "2024-05-01T09:00:00+10:00", Offset: 36000},
+		{Text: "2024-05-01T09:00:00.123456-05:30", Valid: true, Expected: "2024-05-01T09:00:00-05:30", Offset: -19800},
+		{Text: "2024-122T09:00:00-0930", Valid: true, Expected: "2024-05-01T09:00:00-09:30", Offset: -34200},
+		{Text: `"2024-05-01T09:00:00+10:00"`, Valid: true, Expected: "2024-05-01T09:00:00+10:00", Offset: 36000},
+		{Text: "2024-05-01T09:00:00", Valid: false},
+		{Text: "2024-05-01", Valid: false},
+		{Text: "2024-05-01T09:00:00+1", Valid: false},
+		{Text: "2024-05-01T09:00:00+10:60", Valid: false},
+		{Text: "2024-05-01T09:00:00+24:00", Valid: false},
+		{Text: "2024-05-01T09:00:00ZZ", Valid: false},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		o, err := ParseOffsetDateTime(tc.Text)
+		if tc.Valid {
+			assert.NoError(err, tc.Text)
+			assert.Equal(tc.Expected, o.String(), tc.Text)
+			assert.Equal(tc.Offset, o.Offset(), tc.Text)
+		} else {
+			assert.Error(err, tc.Text)
+		}
+	}
+}
+
+func TestOffsetDateTimeConversion(t *testing.T) {
+	assert := assert.New(t)
+	sydney := mustLoadLocation(t, "Australia/Sydney")
+
+	tm := time.Date(2024, 5, 1, 9, 0, 0, 500, sydney)
+	o := OffsetDateTimeOf(tm)
+	assert.Equal("2024-05-01T09:00:00+10:00", o.String())
+	assert.True(tm.Truncate(time.Second).Equal(o.Time()))
+	assert.Equal("2024-05-01T09:00:00", o.LocalDateTime().String())
+	assert.Equal("2024-05-01", o.LocalDate().String())
+
+	utc := o.WithOffset(0)
+	assert.Equal("2024-04-30T23:00:00Z", utc.String())
+	assert.False(o.Equal(utc))
+	assert.True(o.Time().Equal(utc.Time()))
+	assert.False(o.Before(utc))
+	assert.False(o.After(utc))
+
+	z := o.InZone(sydney)
+	assert.Equal("2024-05-01T09:00:00+10:00[Australia/Sydney]", z.String())
+
+	o2 := MustParseDateTime("2024-05-01T09:00:00").AtOffset(10 * 3600)
+	assert.True(o.Equal(o2))
+
+	assert.True(OffsetDateTime{}.IsZero())
+	assert.Equal("0001-01-01T00:00:00Z", OffsetDateTime{}.String())
+}
+
+func TestOffsetDateTimeArithmetic(t *testing.T) {
+	assert := assert.New(t)
+	o := MustParseOffsetDateTime("2024-10-05T09:00:00+10:00")
+
+	// fixed offset, so no daylight saving time adjustments
+	assert.Equal("2024-10-06T09:00:00+10:00", o.AddDate(0, 0, 1).String())
+	assert.Equal("2024-10-06T09:00:00+10:00", o.Add(24*time.Hour).String())
+	assert.Equal("2025-11-06T09:00:00+10:00", o.AddPeriod(MustParsePeriod("P1Y1M1D")).String())
+	assert.Equal(time.Hour, o.Add(time.Hour+time.Millisecond).Sub(o))
+}
+
+func TestOffsetDateTimeMarshal(t *testing.T) {
+	assert := assert.New(t)
+	type testStruct struct {
+		Received OffsetDateTime `json:"received"`
+	}
+	st := testStruct{
+		Received: MustParseOffsetDateTime("2024-05-01T09:00:00+0530"),
+	}
+
+	b, err := json.Marshal(&st)
+	assert.NoError(err)
+	assert.Equal(`{"received":"2024-05-01T09:00:00+05:30"}`, string(b))
+
+	var st2 testStruct
+	assert.NoError(json.Unmarshal(b, &st2))
+	assert.True(st.Received.Equal(st2.Received))
+	assert.Equal(19800, st2.Received.Offset())
+
+	v, err := st.Received.Value()
+	assert.NoError(err)
+	assert.Equal("2024-05-01T09:00:00+05:30", v)
+
+	var o OffsetDateTime
+	assert.NoError(o.Scan(v))
+	assert.True(st.Received.Equal(o))
+	assert.NoError(o.Scan([]byte("2024-05-01 09:00:00+05:30")))
+	assert.True(st.Received.Equal(o))
+	assert.NoError(o.Scan(st.Received.Time()))
+	assert.True(st.Received.Equal(o))
+	assert.Error(o.Scan(nil))
+	assert.Error(o.Scan(1.5))
+
+	assert.NoError(o.UnmarshalText([]byte("2024-05-01T09:00:00-04")))
+	b, err = o.MarshalText()
+	assert.NoError(err)
+	assert.Equal("2024-05-01T09:00:00-04:00", string(b))
+}
@@ -0,0 +1,212 @@
+package dt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateOrder is the order of the day, month and year in a numeric date,
+// such as "03/04/2026", which is the 3rd of April in DMY order and the 4th
+// of March in MDY order.
+type DateOrder int
+
+// Date orders.
+const (
+	DMY DateOrder = iota + 1 // day, month, year
+	MDY                      // month, day, year
+	YMD                      // year, month, day
+)
+
+// dateOrders are the date orders in the order they are tried.
+var dateOrders = []DateOrder{DMY, MDY, YMD}
+
+// String returns "DMY", "MDY" or "YMD".
+func (o DateOrder) String() string {
+	switch o {
+	case DMY:
+		return "DMY"
+	case MDY:
+		return "MDY"
+	case YMD:
+		return "YMD"
+	}
+	return fmt.Sprintf("DateOrder(%d)", int(o))
+}
+
+// DateOrderParser parses numeric dates in a particular order, such as
+// "03/04/2026", "3-4-26", "03.04.2026" or "3 4 2026". The day, month and
+// year are separated by slashes, hyphens, dots or spaces, and both
+// separators must be the same. The day and month have one or two digits,
+// and the year has two or four digits.
+type DateOrderParser struct {
+	// Order is the order of the day, month and year. If zero, DMY is used.
+	Order DateOrder
+
+	// PivotYear is the first year of the 100 year window that contains
+	// the years written with two digits. For example, if PivotYear is
+	// 1960, then "60" is 1960 and "59" is 2059. If zero, two-digit years
+	// are in the years 1950 to 2049.
+	PivotYear int
+}
+
+// Parse parses a numeric date. Leading and trailing space is ignored. The
+// date must exist: "31/04/2026" is an error.
+func (p DateOrderParser) Parse(s string) (LocalDate, error) {
+	fields, ok := splitNumericDate(s)
+	if !ok {
+		return LocalDate{}, errInvalidDateFormat
+	}
+	var year, month, day string
+	switch p.Order {
+	case DMY, 0:
+		day, month, year = fields[0], fields[1], fields[2]
+	case MDY:
+		month, day, year = fields[0], fields[1], fields[2]
+	case YMD:
+		year, month, day = fields[0], fields[1], fields[2]
+	default:
+		return LocalDate{}, fmt.Errorf("invalid date order %v", p.Order)
+	}
+	if len(year) != 2 && len(year) != 4 || len(month) > 2 || len(day) > 2 {
+		return LocalDate{}, errInvalidDateFormat
+	}
+
+	// no error checking here because the fields contain only digits
+	y, _ := strconv.Atoi(year)
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+	if len(year) == 2 {
+		y = p.fullYear(y)
+	}
+	if m < 1 || m > 12 || d < 1 || d > daysIn(time.Month(m), y) {
+		return LocalDate{}, errInvalidDateFormat
+	}
+	return Date(y, time.Month(m), d), nil
+}
+
+// MustParse is like Parse, but panics if s cannot be parsed.
+func (p DateOrderParser) MustParse(s string) LocalDate {
+	d, err := p.Parse(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return d
+}
+
+// fullYear returns the year in the pivot window for a two-digit year.
+func (p DateOrderParser) fullYear(yy int) int {
+	pivot := p.PivotYear
+	if pivot == 0 {
+		pivot = 1950
+	}
+	year := pivot - mod(pivot, 100) + yy
+	if year < pivot {
+		year += 100
+	}
+	return year
+}
+
+// mod returns the non-negative remainder of a divided by b.
+func mod(a, b int) int {
+	return (a%b + b) % b
+}
+
+// daysIn returns the number of days in a month.
+func daysIn(month time.Month, year int) int {
+	return Date(year, month+1, 0).Day()
+}
+
+// splitNumericDate splits a date into three fields of digits separated by
+// the same separator.
+func splitNumericDate(s string) ([]string, bool) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	if i <= 0 {
+		return nil, false
+	}
+	var fields []string
+	switch sep := s[i]; sep {
+	case '/', '-', '.':
+		fields = strings.Split(s, string(sep))
+	case ' ':
+		fields = strings.Fields(s)
+	default:
+		return nil, false
+	}
+	if len(fields) != 3 {
+		return nil, false
+	}
+	for _, field := range fields {
+		if field == "" || strings.Trim(field, "0123456789") != "" {
+			return nil, false
+		}
+	}
+	return fields, true
+}
+
+// DateOrderError is returned by InferDateOrder when the values do not
+// determine a single date order.
+type DateOrderError struct {
+	// Orders contains the orders that parse all of the values. If it is
+	// empty, no order parses all of the values. If it has more than one
+	// order, the values are ambiguous.
+	Orders []DateOrder
+
+	// Rejected contains, for each order that does not parse all of the
+	// values, the first value that it cannot parse.
+	Rejected map[DateOrder]string
+}
+
+// Error implements the error interface.
+func (e *DateOrderError) Error() string {
+	if len(e.Orders) > 1 {
+		return fmt.Sprintf("ambiguous date order: values parse as %s", joinOrders(e.Orders))
+	}
+	var reasons []string
+	for _, order := range dateOrders {
+		if value, ok := e.Rejected[order]; ok {
+			reasons = append(reasons, fmt.Sprintf("%q is not %v", value, order))
+		}
+	}
+	return "conflicting date orders: " + strings.Join(reasons, ", ")
+}
+
+func joinOrders(orders []DateOrder) string {
+	names := make([]string, len(orders))
+	for i, order := range orders {
+		names[i] = order.String()
+	}
+	return strings.Join(names, " or ")
+}
+
+// InferDateOrder returns the date order that parses all of the values, such
+// as the values in one column of a spreadsheet. Blank values are ignored.
+// If no order parses all of the values, or if more than one order does,
+// the error is a *DateOrderError. The pivot year is used for two-digit
+// years, as described in DateOrderParser.
+func InferDateOrder(values []string, pivotYear int) (DateOrder, error) {
+	e := &DateOrderError{Rejected: make(map[DateOrder]string)}
+	for _, order := range dateOrders {
+		p := DateOrderParser{Order: order, PivotYear: pivotYear}
+		ok := true
+		for _, value := range values {
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+			if _, err := p.Parse(value); err != nil {
+				e.Rejected[order] = value
+				ok = false
+				break
+			}
+		}
+		if ok {
+			e.Orders = append(e.Orders, order)
+		}
+	}
+	if len(e.Orders) != 1 {
+		return 0, e
+	}
+	return e.Orders[0], nil
+}
@@ -0,0 +1,99 @@
+package dt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDateOrderParser(t *testing.T) {
+	tests := []struct {
+		order   DateOrder
+		pivot   int
+		text    string
+		want    LocalDate
+		invalid bool
+	}{
+		{order: DMY, text: "03/04/2026", want: Date(2026, 4, 3)},
+		{order: MDY, text: "03/04/2026", want: Date(2026, 3, 4)},
+		{order: YMD, text: "2026/04/03", want: Date(2026, 4, 3)},
+		{order: 0, text: "3/4/2026", want: Date(2026, 4, 3)},
+		{order: DMY, text: "3-4-2026", want: Date(2026, 4, 3)},
+		{order: DMY, text: "03.04.2026", want: Date(2026, 4, 3)},
+		{order: DMY, text: " 03 04  2026 ", want: Date(2026, 4, 3)},
+		{order: DMY, text: "31/12/49", want: Date(2049, 12, 31)},
+		{order: DMY, text: "01/01/50", want: Date(1950, 1, 1)},
+		{order: DMY, pivot: 1960, text: "1/1/60", want: Date(1960, 1, 1)},
+		{order: DMY, pivot: 1960, text: "1/1/59", want: Date(2059, 1, 1)},
+		{order: DMY, pivot: 2000, text: "1/1/99", want: Date(2099, 1, 1)},
+		{order: YMD, text: "26-10-17", want: Date(2026, 10, 17)},
+		{order: DMY, text: "29/02/2024", want: Date(2024, 2, 29)},
+		{order: DMY, text: "29/02/2026", invalid: true},
+		{order: DMY, text: "31/04/2026", invalid: true},
+		{order: MDY, text: "13/04/2026", invalid: true},
+		{order: DMY, text: "03/04-2026", invalid: true},
+		{order: DMY, text: "03/04/202", invalid: true},
+		{order: DMY, text: "003/04/2026", invalid: true},
+		{order: DMY, text: "03//2026", invalid: true},
+		{order: DMY, text: "03/04", invalid: true},
+		{order: DMY, text: "03/04/2026/1", invalid: true},
+		{order: DMY, text: "3rd/04/2026", invalid: true},
+		{order: DMY, text: "", invalid: true},
+	}
+	for _, tt := range tests {
+		p := DateOrderParser{Order: tt.order, PivotYear: tt.pivot}
+		d, err := p.Parse(tt.text)
+		if tt.invalid {
+			assert.Equal(t, errInvalidDateFormat, err, tt.text)
+			continue
+		}
+		if assert.NoError(t, err, tt.text) {
+			assert.Equal(t, tt.want, d, tt.text)
+		}
+	}
+
+	_, err := DateOrderParser{Order: DateOrder(9)}.Parse("03/04/2026")
+	assert.EqualError(t, err, "invalid date order DateOrder(9)")
+	assert.Equal(t, Date(2026, 3, 4), DateOrderParser{Order: MDY}.MustParse("03/04/2026"))
+	assert.Panics(t, func() { DateOrderParser{Order: MDY}.MustParse("13/04/2026") })
+}
+
+func TestInferDateOrder(t *testing.T) {
+	tests := []struct {
+		values  []string
+		want    DateOrder
+		errText string
+	}{
+		{values: []string{"03/04/2026", "25/12/2026", ""}, want: DMY},
+		{values: []string{"03/04/2026", "12/25/2026"}, want: MDY},
+		{values: []string{"2026-04-03", "2026-12-25"}, want: YMD},
+		{values: []string{"03/04/2026", "04/05/2026"}, errText: "ambiguous date order: values parse as DMY or MDY"},
+		{values: []string{}, errText: "ambiguous date order: values parse as DMY or MDY or YMD"},
+		{
+			values:  []string{"25/12/2026", "12/25/2026"},
+			errText: `conflicting date orders: "12/25/2026" is not DMY, "25/12/2026" is not MDY, "25/12/2026" is not YMD`,
+		},
+	}
+	for _, tt := range tests {
+		order, err := InferDateOrder(tt.values, 0)
+		if tt.errText != "" {
+			if assert.Error(t, err) {
+				assert.Equal(t, tt.errText, err.Error())
+				var orderErr *DateOrderError
+				assert.True(t, errors.As(err, &orderErr))
+			}
+			continue
+		}
+		if assert.NoError(t, err, "%v", tt.values) {
+			assert.Equal(t, tt.want, order, "%v", tt.values)
+		}
+	}
+}
+
+func TestDateOrderString(t *testing.T) {
+	assert.Equal(t, "DMY", DMY.String())
+	assert.Equal(t, "MDY", MDY.String())
+	assert.Equal(t, "YMD", YMD.String())
+	assert.Equal(t, "DateOrder(0)", DateOrder(0).String())
+}
@@ -1,110 +1,380 @@
 package dt
 
 import (
-	"regexp"
-	"strconv"
-	"strings"
+	"fmt"
 	"time"
 )
 
-var parseFormats = struct {
-	calendarDates  []string
-	ordinalDates   []string
-	times          []string
-	throwAwayTimes []string
-}{
-	calendarDates: []string{
-		`(-?\d{4})-(\d{1,2})-(\d{1,2})`,
-		`^(-?\d{4})(\d{2})(\d{2})`,
-		// Not ISO 8601, but still unambiguous
-		`(-?\d{4})\.(\d{1,2})\.(\d{1,2})`,
-		`(-?\d{4})/(\d{1,2})/(\d{1,2})`,
-	},
-	ordinalDates: []string{
-		`(-?\d{4})-(\d{3})`,
-		`(-?\d{4})(\d{3})`,
-	},
-	times: []string{
-		`(\d{1,2}):(\d{1,2}):(\d{1,2})(\.\d*)?`,
-		`(\d{1,2}):(\d{1,2})`,
-		`(\d{2})(\d{2})(\d{2})(\.\d*)?`,
-		`(\d{2})(\d{2})`,
-	},
-	throwAwayTimes: []string{
-		`(T[0-9:.zZ+-]*)?`,
-	},
-}
-
-var parseRegexp = struct {
-	calendarDates     []*regexp.Regexp
-	ordinalDates      []*regexp.Regexp
-	calendarDateTimes []*regexp.Regexp
-	ordinalDateTimes  []*regexp.Regexp
-}{}
-
-func init() {
-	for _, cd := range parseFormats.calendarDates {
-		for _, tat := range parseFormats.throwAwayTimes {
-			text := "^" + cd + tat + "$"
-			parseRegexp.calendarDates = append(parseRegexp.calendarDates, regexp.MustCompile(text))
+// The parse functions scan their input in a single pass, without
+// allocating unless there is an error. They recognise the following
+// layouts, where the year has four digits and an optional minus sign, and
+// the month, day, hour, minute and second in the extended layouts have one
+// or two digits:
+//
+//	calendar date   yyyy-mm-dd, yyyymmdd, yyyy.mm.dd, yyyy/mm/dd
+//	ordinal date    yyyy-ddd, yyyyddd
+//	week date       yyyy-Www-d, yyyyWwwd
+//	time            hh:mm:ss[.fff], hh:mm, hhmmss[.fff], hhmm
+//
+// ParseDate accepts and ignores a "T" followed by any of the characters
+// "0123456789:.zZ+-" after the date. ParseDateTime accepts a date, or a date
+// followed by "T" and a time. ParseTime accepts a time with an optional "T"
+// before it.
+
+// text is the type of input that can be parsed.
+type text interface {
+	~string | ~[]byte
+}
+
+// ParseError describes a problem parsing a date or time. The parse
+// functions return a *ParseError, which can be found with errors.As.
+type ParseError struct {
+	// Input is the string being parsed.
+	Input string
+
+	// Field is the field that is invalid, such as "month" or "hour". It
+	// is empty if the input does not match any of the recognised layouts.
+	Field string
+
+	// Offset is the byte offset in Input of the invalid field, or of the
+	// start of the text if the input does not match any layout.
+	Offset int
+
+	// Reason describes the problem, such as "month out of range".
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("parsing %q: %s", e.Input, e.Reason)
+	}
+	return fmt.Sprintf("parsing %q: %s at offset %d", e.Input, e.Reason, e.Offset)
+}
+
+// scanned contains the fields of a date, time or date-time, and their
+// offsets in the input.
+type scanned struct {
+	year, month, day, yearDay int
+	week, weekday             int
+	ordinal, weekDate         bool
+
+	hour, minute, second, nanosecond int
+
+	monthAt, dayAt, weekAt, weekdayAt int
+	hourAt, minuteAt, secondAt        int
+}
+
+// trimInput removes leading and trailing space and quotation marks, and
+// returns the offset of the trimmed text in the input.
+func trimInput[T text](input T) (T, int) {
+	start, end := 0, len(input)
+	for start < end && isTrimmed(input[start]) {
+		start++
+	}
+	for end > start && isTrimmed(input[end-1]) {
+		end--
+	}
+	return input[start:end], start
+}
+
+func isTrimmed(c byte) bool {
+	return c == ' ' || c == '\t' || c == '"' || c == '\''
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// digits returns the number of decimal digits in s starting at i, and
+// their value. At most nine digits are read.
+func digits[T text](s T, i int) (int, int) {
+	n, value := 0, 0
+	for i+n < len(s) && isDigit(s[i+n]) && n < 9 {
+		value = value*10 + int(s[i+n]-'0')
+		n++
+	}
+	return n, value
+}
+
+// scanDate scans a calendar, ordinal or week date at the start of s and
+// returns the offset of the text that follows it, or -1 if there is no date.
+func scanDate[T text](s T, f *scanned) int {
+	i := 0
+	negative := len(s) > 0 && s[0] == '-'
+	if negative {
+		i++
+	}
+	n, year := digits(s, i)
+	if n < 4 {
+		return -1
+	}
+	// a year has exactly four digits, but yyyymmdd and yyyyddd continue
+	// with the month or day of the year
+	n, rest := n-4, 0
+	for j := i + 4; j < i+4+n; j++ {
+		rest = rest*10 + int(s[j]-'0')
+	}
+	for range n {
+		year /= 10
+	}
+	if negative {
+		year = -year
+	}
+	f.year = year
+	i += 4
+
+	switch {
+	case n == 4:
+		// yyyymmdd
+		f.month, f.day = rest/100, rest%100
+		f.monthAt, f.dayAt = i, i+2
+		return i + 4
+	case n == 3:
+		// yyyyddd
+		f.ordinal, f.yearDay, f.dayAt = true, rest, i
+		return i + 3
+	case n != 0 || i >= len(s):
+		return -1
+	}
+
+	sep := s[i]
+	if sep == 'W' {
+		// yyyyWwwd
+		return scanWeek(s, i+1, false, f)
+	}
+	if sep != '-' && sep != '.' && sep != '/' {
+		return -1
+	}
+	i++
+	if sep == '-' && i < len(s) && s[i] == 'W' {
+		// yyyy-Www-d
+		return scanWeek(s, i+1, true, f)
+	}
+	n, value := digits(s, i)
+	if sep == '-' && n == 3 {
+		// yyyy-ddd
+		f.ordinal, f.yearDay, f.dayAt = true, value, i
+		return i + 3
+	}
+	if n < 1 || n > 2 {
+		return -1
+	}
+	f.month, f.monthAt = value, i
+	i += n
+	if i >= len(s) || s[i] != sep {
+		return -1
+	}
+	i++
+	if n, f.day = digits(s, i); n < 1 || n > 2 {
+		return -1
+	}
+	f.dayAt = i
+	return i + n
+}
+
+// scanWeek scans the week and day of the week of a week date, which start
+// at offset i, and returns the offset of the text that follows them, or -1
+// if they are not valid.
+func scanWeek[T text](s T, i int, extended bool, f *scanned) int {
+	f.weekDate = true
+	n, value := digits(s, i)
+	if extended {
+		if n != 2 || i+2 >= len(s) || s[i+2] != '-' {
+			return -1
+		}
+		f.week, f.weekAt = value, i
+		i += 3
+		if n, f.weekday = digits(s, i); n != 1 {
+			return -1
 		}
+		f.weekdayAt = i
+		return i + 1
+	}
+	if n != 3 {
+		return -1
+	}
+	f.week, f.weekday = value/10, value%10
+	f.weekAt, f.weekdayAt = i, i+2
+	return i + 3
+}
 
-		text := "^" + cd + "$"
-		parseRegexp.calendarDateTimes = append(parseRegexp.calendarDateTimes, regexp.MustCompile(text))
+// scanClock scans a time of day that starts at offset i and continues to
+// the end of s, and reports whether it is valid.
+func scanClock[T text](s T, i int, f *scanned) bool {
+	n, value := digits(s, i)
+	switch {
+	case n >= 1 && n <= 2 && i+n < len(s) && s[i+n] == ':':
+		// hh:mm or hh:mm:ss[.fff]
+		f.hour, f.hourAt = value, i
+		i += n + 1
+		if n, f.minute = digits(s, i); n < 1 || n > 2 {
+			return false
+		}
+		f.minuteAt = i
+		i += n
+		if i == len(s) {
+			return true
+		}
+		if s[i] != ':' {
+			return false
+		}
+		i++
+		if n, f.second = digits(s, i); n < 1 || n > 2 {
+			return false
+		}
+		f.secondAt = i
+		i += n
+	case n == 4:
+		// hhmm
+		f.hour, f.minute = value/100, value%100
+		f.hourAt, f.minuteAt = i, i+2
+		return i+4 == len(s)
+	case n == 6:
+		// hhmmss[.fff]
+		f.hour, f.minute, f.second = value/10000, value/100%100, value%100
+		f.hourAt, f.minuteAt, f.secondAt = i, i+2, i+4
+		i += 6
+	default:
+		return false
+	}
 
-		for _, tod := range parseFormats.times {
-			text = "^" + cd + "T" + tod + "$"
-			parseRegexp.calendarDateTimes = append(parseRegexp.calendarDateTimes, regexp.MustCompile(text))
+	// an optional fraction of a second, of which digits beyond nanosecond
+	// precision are ignored
+	if i == len(s) {
+		return true
+	}
+	if s[i] != '.' {
+		return false
+	}
+	scale := 100000000
+	for i++; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
 		}
+		f.nanosecond += int(s[i]-'0') * scale
+		scale /= 10
 	}
+	return true
+}
 
-	for _, od := range parseFormats.ordinalDates {
-		for _, tat := range parseFormats.throwAwayTimes {
-			text := "^" + od + tat + "$"
-			parseRegexp.ordinalDates = append(parseRegexp.ordinalDates, regexp.MustCompile(text))
+// isThrowAwayTime reports whether s is a "T" followed by characters that
+// could be part of a time and UTC offset.
+func isThrowAwayTime[T text](s T) bool {
+	if len(s) == 0 || s[0] != 'T' {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case isDigit(c), c == ':', c == '.', c == 'z', c == 'Z', c == '+', c == '-':
+		default:
+			return false
 		}
+	}
+	return true
+}
 
-		text := "^" + od + "$"
-		parseRegexp.ordinalDateTimes = append(parseRegexp.ordinalDateTimes, regexp.MustCompile(text))
+// date returns the date of the scanned fields.
+func (f *scanned) date() LocalDate {
+	if f.weekDate {
+		return ISOWeekDate(f.year, f.week, time.Monday).AddDate(0, 0, f.weekday-1)
+	}
+	if f.ordinal {
+		duration := time.Duration(f.yearDay-1) * nanosecondsPerDay
+		return Date(f.year, 1, 1).Add(duration)
+	}
+	return Date(f.year, time.Month(f.month), f.day)
+}
+
+// dateTime returns the date-time of the scanned fields.
+func (f *scanned) dateTime() LocalDateTime {
+	if f.weekDate {
+		year, month, day := f.date().Date()
+		return DateTime(year, month, day, f.hour, f.minute, f.second)
+	}
+	if f.ordinal {
+		duration := time.Duration(f.yearDay-1) * nanosecondsPerDay
+		return DateTime(f.year, 1, 1, f.hour, f.minute, f.second).Add(duration)
+	}
+	return DateTime(f.year, time.Month(f.month), f.day, f.hour, f.minute, f.second)
+}
 
-		for _, tod := range parseFormats.times {
-			text = "^" + od + "T" + tod + "$"
-			parseRegexp.ordinalDateTimes = append(parseRegexp.ordinalDateTimes, regexp.MustCompile(text))
+// checkDate returns an error if a field of the scanned date is out of range.
+func checkDate[T text](input T, offset int, f *scanned) error {
+	switch {
+	case f.weekDate:
+		if _, weeks := Date(f.year, time.December, 28).ISOWeek(); f.week < 1 || f.week > weeks {
+			return rangeError(input, offset+f.weekAt, "week", "week out of range for year")
+		}
+		if f.weekday < 1 || f.weekday > 7 {
+			return rangeError(input, offset+f.weekdayAt, "day of week", "day of week out of range")
 		}
+	case f.ordinal:
+		if f.yearDay < 1 || f.yearDay > Date(f.year, time.December, 31).YearDay() {
+			return rangeError(input, offset+f.dayAt, "day of year", "day of year out of range")
+		}
+	case f.month < 1 || f.month > 12:
+		return rangeError(input, offset+f.monthAt, "month", "month out of range")
+	case f.day < 1 || f.day > daysIn(time.Month(f.month), f.year):
+		return rangeError(input, offset+f.dayAt, "day", "day out of range for month")
+	}
+	return nil
+}
+
+// checkClock returns an error if a field of the scanned time is out of range.
+func checkClock[T text](input T, offset int, f *scanned) error {
+	switch {
+	case f.hour > 23:
+		return rangeError(input, offset+f.hourAt, "hour", "hour out of range")
+	case f.minute > 59:
+		return rangeError(input, offset+f.minuteAt, "minute", "minute out of range")
+	case f.second > 59:
+		return rangeError(input, offset+f.secondAt, "second", "second out of range")
 	}
+	return nil
+}
+
+// rangeError returns an error for a field that is out of range.
+func rangeError[T text](input T, offset int, field string, reason string) error {
+	return &ParseError{Input: string(input), Field: field, Offset: offset, Reason: reason}
+}
+
+// layoutError returns an error for input that does not match a layout.
+func layoutError[T text](input T, offset int, reason string) error {
+	return &ParseError{Input: string(input), Offset: offset, Reason: reason}
 }
 
 // ParseDate attempts to parse a string into a local date. Leading
 // and trailing space and quotation marks are ignored. The following
 // date formates are recognised: yyyy-mm-dd, yyyymmdd, yyyy.mm.dd,
-// yyyy/mm/dd, yyyy-ddd, yyyyddd.
+// yyyy/mm/dd, yyyy-ddd, yyyyddd, yyyy-Www-d, yyyyWwwd.
+//
+// A month or day that is out of range is normalized, so "2026-02-30"
+// is the 2nd of March. Use ParseDateStrict to reject it instead. If s
+// cannot be parsed, the error is a *ParseError.
 func ParseDate(s string) (LocalDate, error) {
-	s = strings.Trim(s, " \t\"'")
-	for _, regexp := range parseRegexp.calendarDates {
-		match := regexp.FindStringSubmatch(s)
-		if match != nil {
-			// no error checking here because matching the regexp
-			// guarantees that parsing the strings will succeed.
-			year, _ := strconv.ParseInt(match[1], 10, 0)
-			month, _ := strconv.ParseInt(match[2], 10, 0)
-			day, _ := strconv.ParseInt(match[3], 10, 0)
-			return Date(int(year), time.Month(month), int(day)), nil
-		}
-	}
+	return parseDate(s, false)
+}
+
+// ParseDateStrict is like ParseDate, but returns a *ParseError if the
+// month, day or day of the year is out of range.
+func ParseDateStrict(s string) (LocalDate, error) {
+	return parseDate(s, true)
+}
 
-	for _, regexp := range parseRegexp.ordinalDates {
-		match := regexp.FindStringSubmatch(s)
-		if match != nil {
-			// no error checking here because matching the regexp
-			// guarantees that parsing the strings will succeed.
-			year, _ := strconv.ParseInt(match[1], 10, 0)
-			dayOfYear, _ := strconv.ParseInt(match[2], 10, 0)
-			duration := time.Duration((dayOfYear - 1) * nanosecondsPerDay)
-			return Date(int(year), 1, 1).Add(duration), nil
+func parseDate[T text](input T, strict bool) (LocalDate, error) {
+	s, offset := trimInput(input)
+	var f scanned
+	i := scanDate(s, &f)
+	if i < 0 || (i < len(s) && !isThrowAwayTime(s[i:])) {
+		return LocalDate{}, layoutError(input, offset, "unknown date layout")
+	}
+	if strict {
+		if err := checkDate(input, offset, &f); err != nil {
+			return LocalDate{}, err
 		}
 	}
-
-	return LocalDate{}, errInvalidDateFormat
+	return f.date(), nil
 }
 
 // MustParseDate is similar to ParseDate, but instead of returning an error it will
@@ -120,59 +390,40 @@ func MustParseDate(s string) LocalDate {
 // ParseDateTime attempts to parse a string into a local date-time. Leading
 // and trailing space and quotation marks are ignored. The following
 // date formates are recognised: yyyy-mm-dd, yyyymmdd, yyyy.mm.dd,
-// yyyy/mm/dd, yyyy-ddd, yyyyddd. The following time formats are recognised:
-// HH:MM:SS, HH:MM, HHMMSS, HHMM.
+// yyyy/mm/dd, yyyy-ddd, yyyyddd, yyyy-Www-d, yyyyWwwd. The following time
+// formats are recognised: HH:MM:SS, HH:MM, HHMMSS, HHMM. A UTC offset is
+// not accepted: use ParseOffsetDateTime for date-times that include an
+// offset.
+//
+// Fields that are out of range are normalized, so "2026-01-01T24:00" is
+// midnight on the 2nd of January. Use ParseDateTimeStrict to reject them
+// instead. If s cannot be parsed, the error is a *ParseError.
 func ParseDateTime(s string) (LocalDateTime, error) {
-	s = strings.Trim(s, " \t\"'")
-	for _, regexp := range parseRegexp.calendarDateTimes {
-		match := regexp.FindStringSubmatch(s)
-		if match != nil {
-			// no error checking here because matching the regexp
-			// guarantees that parsing the strings will succeed.
-			year, _ := strconv.ParseInt(match[1], 10, 0)
-			month, _ := strconv.ParseInt(match[2], 10, 0)
-			day, _ := strconv.ParseInt(match[3], 10, 0)
-
-			var hour, minute, second int64
-			if len(match) > 4 {
-				hour, _ = strconv.ParseInt(match[4], 10, 0)
-			}
-			if len(match) > 5 {
-				minute, _ = strconv.ParseInt(match[5], 10, 0)
-			}
-			if len(match) > 6 {
-				second, _ = strconv.ParseInt(match[6], 10, 0)
-			}
-
-			return DateTime(int(year), time.Month(month), int(day), int(hour), int(minute), int(second)), nil
-		}
-	}
+	return parseDateTime(s, false)
+}
+
+// ParseDateTimeStrict is like ParseDateTime, but returns a *ParseError if
+// any field is out of range.
+func ParseDateTimeStrict(s string) (LocalDateTime, error) {
+	return parseDateTime(s, true)
+}
 
-	for _, regexp := range parseRegexp.ordinalDateTimes {
-		match := regexp.FindStringSubmatch(s)
-		if match != nil {
-			// no error checking here because matching the regexp
-			// guarantees that parsing the strings will succeed.
-			year, _ := strconv.ParseInt(match[1], 10, 0)
-			dayOfYear, _ := strconv.ParseInt(match[2], 10, 0)
-
-			var hour, minute, second int64
-			if len(match) > 3 {
-				hour, _ = strconv.ParseInt(match[3], 10, 0)
-			}
-			if len(match) > 4 {
-				minute, _ = strconv.ParseInt(match[4], 10, 0)
-			}
-			if len(match) > 5 {
-				second, _ = strconv.ParseInt(match[5], 10, 0)
-			}
-
-			duration := time.Duration((dayOfYear - 1) * nanosecondsPerDay)
-			return DateTime(int(year), 1, 1, int(hour), int(minute), int(second)).Add(duration), nil
+func parseDateTime[T text](input T, strict bool) (LocalDateTime, error) {
+	s, offset := trimInput(input)
+	var f scanned
+	i := scanDate(s, &f)
+	if i < 0 || (i < len(s) && (s[i] != 'T' || !scanClock(s, i+1, &f))) {
+		return LocalDateTime{}, layoutError(input, offset, "unknown date-time layout")
+	}
+	if strict {
+		if err := checkDate(input, offset, &f); err != nil {
+			return LocalDateTime{}, err
+		}
+		if err := checkClock(input, offset, &f); err != nil {
+			return LocalDateTime{}, err
 		}
 	}
-
-	return LocalDateTime{}, errInvalidDateFormat
+	return f.dateTime(), nil
 }
 
 // MustParseDate is similar to ParseDate, but instead of returning an error it will
@@ -184,3 +435,50 @@ func MustParseDateTime(s string) LocalDateTime {
 	}
 	return dt
 }
+
+// ParseTime attempts to parse a string into a local time. Leading
+// and trailing space and quotation marks are ignored, as is an ISO 8601
+// time designator ("T") at the start of the string. The following
+// time formats are recognised: HH:MM:SS, HH:MM, HHMMSS, HHMM. The seconds
+// may be followed by a decimal fraction.
+//
+// Fields that are out of range are normalized, so "10:75" is 11:15. Use
+// ParseTimeStrict to reject them instead. If s cannot be parsed, the error
+// is a *ParseError.
+func ParseTime(s string) (LocalTime, error) {
+	return parseTime(s, false)
+}
+
+// ParseTimeStrict is like ParseTime, but returns a *ParseError if the hour,
+// minute or second is out of range.
+func ParseTimeStrict(s string) (LocalTime, error) {
+	return parseTime(s, true)
+}
+
+func parseTime[T text](input T, strict bool) (LocalTime, error) {
+	s, offset := trimInput(input)
+	var f scanned
+	i := 0
+	if len(s) > 0 && s[0] == 'T' {
+		i++
+	}
+	if !scanClock(s, i, &f) {
+		return LocalTime{}, layoutError(input, offset, "unknown time layout")
+	}
+	if strict {
+		if err := checkClock(input, offset, &f); err != nil {
+			return LocalTime{}, err
+		}
+	}
+	return TimeOfDay(f.hour, f.minute, f.second, f.nanosecond), nil
+}
+
+// MustParseTime is similar to ParseTime, but instead of returning an error it will
+// panic if s is not in one of the expected formats.
+func MustParseTime(s string) LocalTime {
+	t, err := ParseTime(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return t
+}
@@ -0,0 +1,332 @@
+package dt
+
+import (
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The regexp tables below are the implementation that the byte scanner in
+// parse.go replaced. They are kept here as the reference for the grammar
+// the scanner must accept. Week dates were added to the scanner later, so
+// the inputs compared with the regexps do not contain a "W".
+
+var regexpFormats = struct {
+	calendarDates  []string
+	ordinalDates   []string
+	times          []string
+	throwAwayTimes []string
+}{
+	calendarDates: []string{
+		`(-?\d{4})-(\d{1,2})-(\d{1,2})`,
+		`^(-?\d{4})(\d{2})(\d{2})`,
+		`(-?\d{4})\.(\d{1,2})\.(\d{1,2})`,
+		`(-?\d{4})/(\d{1,2})/(\d{1,2})`,
+	},
+	ordinalDates: []string{
+		`(-?\d{4})-(\d{3})`,
+		`(-?\d{4})(\d{3})`,
+	},
+	times: []string{
+		`(\d{1,2}):(\d{1,2}):(\d{1,2})(\.\d*)?`,
+		`(\d{1,2}):(\d{1,2})`,
+		`(\d{2})(\d{2})(\d{2})(\.\d*)?`,
+		`(\d{2})(\d{2})`,
+	},
+	throwAwayTimes: []string{
+		`(T[0-9:.zZ+-]*)?`,
+	},
+}
+
+var regexpTables = struct {
+	calendarDates     []*regexp.Regexp
+	ordinalDates      []*regexp.Regexp
+	calendarDateTimes []*regexp.Regexp
+	ordinalDateTimes  []*regexp.Regexp
+	times             []*regexp.Regexp
+}{}
+
+func init() {
+	for _, cd := range regexpFormats.calendarDates {
+		for _, tat := range regexpFormats.throwAwayTimes {
+			regexpTables.calendarDates = append(regexpTables.calendarDates, regexp.MustCompile("^"+cd+tat+"$"))
+		}
+		regexpTables.calendarDateTimes = append(regexpTables.calendarDateTimes, regexp.MustCompile("^"+cd+"$"))
+		for _, tod := range regexpFormats.times {
+			regexpTables.calendarDateTimes = append(regexpTables.calendarDateTimes, regexp.MustCompile("^"+cd+"T"+tod+"$"))
+		}
+	}
+	for _, od := range regexpFormats.ordinalDates {
+		for _, tat := range regexpFormats.throwAwayTimes {
+			regexpTables.ordinalDates = append(regexpTables.ordinalDates, regexp.MustCompile("^"+od+tat+"$"))
+		}
+		regexpTables.ordinalDateTimes = append(regexpTables.ordinalDateTimes, regexp.MustCompile("^"+od+"$"))
+		for _, tod := range regexpFormats.times {
+			regexpTables.ordinalDateTimes = append(regexpTables.ordinalDateTimes, regexp.MustCompile("^"+od+"T"+tod+"$"))
+		}
+	}
+	for _, tod := range regexpFormats.times {
+		regexpTables.times = append(regexpTables.times, regexp.MustCompile("^T?"+tod+"$"))
+	}
+}
+
+type regexpMatch struct {
+	input  string
+	text   string
+	offset int
+	index  []int
+}
+
+func newRegexpMatch(re *regexp.Regexp, input string) (regexpMatch, bool) {
+	const cutset = " \t\"'"
+	text := strings.TrimLeft(input, cutset)
+	offset := len(input) - len(text)
+	text = strings.TrimRight(text, cutset)
+	index := re.FindStringSubmatchIndex(text)
+	return regexpMatch{input: input, text: text, offset: offset, index: index}, index != nil
+}
+
+func (m regexpMatch) str(i int) string {
+	if 2*i+1 >= len(m.index) || m.index[2*i] < 0 {
+		return ""
+	}
+	return m.text[m.index[2*i]:m.index[2*i+1]]
+}
+
+func (m regexpMatch) int(i int) int {
+	n, _ := strconv.Atoi(m.str(i))
+	return n
+}
+
+func (m regexpMatch) rangeError(i int, field string, reason string) error {
+	return &ParseError{Input: m.input, Field: field, Offset: m.offset + m.index[2*i], Reason: reason}
+}
+
+func (m regexpMatch) checkDate(year, month, day int) error {
+	if month < 1 || month > 12 {
+		return m.rangeError(2, "month", "month out of range")
+	}
+	if day < 1 || day > daysIn(time.Month(month), year) {
+		return m.rangeError(3, "day", "day out of range for month")
+	}
+	return nil
+}
+
+func (m regexpMatch) checkOrdinalDate(year, dayOfYear int) error {
+	if dayOfYear < 1 || dayOfYear > Date(year, time.December, 31).YearDay() {
+		return m.rangeError(2, "day of year", "day of year out of range")
+	}
+	return nil
+}
+
+func (m regexpMatch) checkClock(i int, hour, minute, second int) error {
+	if hour > 23 {
+		return m.rangeError(i, "hour", "hour out of range")
+	}
+	if minute > 59 {
+		return m.rangeError(i+1, "minute", "minute out of range")
+	}
+	if second > 59 {
+		return m.rangeError(i+2, "second", "second out of range")
+	}
+	return nil
+}
+
+func regexpLayoutError(input string, reason string) error {
+	const cutset = " \t\"'"
+	offset := len(input) - len(strings.TrimLeft(input, cutset))
+	return &ParseError{Input: input, Offset: offset, Reason: reason}
+}
+
+func regexpParseDate(input string, strict bool) (LocalDate, error) {
+	for _, re := range regexpTables.calendarDates {
+		if m, ok := newRegexpMatch(re, input); ok {
+			year, month, day := m.int(1), m.int(2), m.int(3)
+			if strict {
+				if err := m.checkDate(year, month, day); err != nil {
+					return LocalDate{}, err
+				}
+			}
+			return Date(year, time.Month(month), day), nil
+		}
+	}
+	for _, re := range regexpTables.ordinalDates {
+		if m, ok := newRegexpMatch(re, input); ok {
+			year, dayOfYear := m.int(1), m.int(2)
+			if strict {
+				if err := m.checkOrdinalDate(year, dayOfYear); err != nil {
+					return LocalDate{}, err
+				}
+			}
+			return Date(year, 1, 1).Add(time.Duration((dayOfYear - 1) * nanosecondsPerDay)), nil
+		}
+	}
+	return LocalDate{}, regexpLayoutError(input, "unknown date layout")
+}
+
+func regexpParseDateTime(input string, strict bool) (LocalDateTime, error) {
+	for _, re := range regexpTables.calendarDateTimes {
+		if m, ok := newRegexpMatch(re, input); ok {
+			year, month, day := m.int(1), m.int(2), m.int(3)
+			hour, minute, second := m.int(4), m.int(5), m.int(6)
+			if strict {
+				if err := m.checkDate(year, month, day); err != nil {
+					return LocalDateTime{}, err
+				}
+				if err := m.checkClock(4, hour, minute, second); err != nil {
+					return LocalDateTime{}, err
+				}
+			}
+			return DateTime(year, time.Month(month), day, hour, minute, second), nil
+		}
+	}
+	for _, re := range regexpTables.ordinalDateTimes {
+		if m, ok := newRegexpMatch(re, input); ok {
+			year, dayOfYear := m.int(1), m.int(2)
+			hour, minute, second := m.int(3), m.int(4), m.int(5)
+			if strict {
+				if err := m.checkOrdinalDate(year, dayOfYear); err != nil {
+					return LocalDateTime{}, err
+				}
+				if err := m.checkClock(3, hour, minute, second); err != nil {
+					return LocalDateTime{}, err
+				}
+			}
+			duration := time.Duration((dayOfYear - 1) * nanosecondsPerDay)
+			return DateTime(year, 1, 1, hour, minute, second).Add(duration), nil
+		}
+	}
+	return LocalDateTime{}, regexpLayoutError(input, "unknown date-time layout")
+}
+
+func regexpParseTime(input string, strict bool) (LocalTime, error) {
+	for _, re := range regexpTables.times {
+		if m, ok := newRegexpMatch(re, input); ok {
+			hour, minute, second := m.int(1), m.int(2), m.int(3)
+			if strict {
+				if err := m.checkClock(1, hour, minute, second); err != nil {
+					return LocalTime{}, err
+				}
+			}
+			return TimeOfDay(hour, minute, second, regexpFraction(m.str(4))), nil
+		}
+	}
+	return LocalTime{}, regexpLayoutError(input, "unknown time layout")
+}
+
+func regexpFraction(s string) int {
+	var nanosecond int
+	digits := 0
+	for _, c := range strings.TrimPrefix(s, ".") {
+		if digits == 9 {
+			break
+		}
+		nanosecond = nanosecond*10 + int(c-'0')
+		digits++
+	}
+	for ; digits < 9; digits++ {
+		nanosecond *= 10
+	}
+	return nanosecond
+}
+
+// parseInputs returns inputs that exercise every layout, fields of every
+// width, values that are out of range, and random mutations of them.
+func parseInputs() []string {
+	dates := []string{
+		"2026-10-17", "2026-1-7", "-0044-03-15", "20261017", "-00440315",
+		"2026.10.17", "2026.1.7", "2026/10/17", "2026/1/7", "2026-290",
+		"2026290", "-0044-074", "2024-366", "2026-366", "2026-000",
+		"2026-13-01", "2026-02-30", "2026-00-00", "99999-01-01", "226-01-01",
+		"2026-001-01", "2026-10-170", "2026.290", "2026/290", "202610",
+		"2026-10", "2026-10-17-", "2026-10.17", "2026.10/17", "202610170",
+		"--2026-10-17", "2026--10-17", "",
+	}
+	times := []string{
+		"", "T", "T12", "T12:34", "T12:34:56", "T1:2:3", "T12:34:56.",
+		"T12:34:56.789", "T12:34:56.123456789012", "T1234", "T123456",
+		"T123456.5", "T24:00", "T23:60", "T23:59:60", "T99:99:99",
+		"T12:34:567", "T123:45", "T12345", "T1234567", "T12:34Z",
+		"T12:34:56+10:00", "T12:34:56-0500", "T12:34:56z", "T12:34.5",
+		"T1234.5", "T12:34:", "T:34", "T12::34", "Tx", "t12:34",
+	}
+	var inputs []string
+	for _, d := range dates {
+		for _, tm := range times {
+			inputs = append(inputs, d+tm, tm+d)
+		}
+		inputs = append(inputs, " "+d, `"`+d+`"`, "'"+d+"' \t", d+" ")
+	}
+	for _, tm := range times {
+		inputs = append(inputs, strings.TrimPrefix(tm, "T"), ` "`+tm+`" `)
+	}
+
+	const alphabet = "0123456789-./:TtZz+ \"'\tx"
+	rnd := rand.New(rand.NewSource(1))
+	for range 20000 {
+		b := []byte(inputs[rnd.Intn(len(inputs))])
+		for range 1 + rnd.Intn(3) {
+			switch op := rnd.Intn(3); {
+			case op == 0 && len(b) > 0:
+				i := rnd.Intn(len(b))
+				b[i] = alphabet[rnd.Intn(len(alphabet))]
+			case op == 1 && len(b) > 0:
+				i := rnd.Intn(len(b))
+				b = append(b[:i], b[i+1:]...)
+			default:
+				i := rnd.Intn(len(b) + 1)
+				b = append(b[:i], append([]byte{alphabet[rnd.Intn(len(alphabet))]}, b[i:]...)...)
+			}
+		}
+		inputs = append(inputs, string(b))
+	}
+	return inputs
+}
+
+func TestParseMatchesRegexp(t *testing.T) {
+	assert := assert.New(t)
+	for _, input := range parseInputs() {
+		for _, strict := range []bool{false, true} {
+			wantDate, wantErr := regexpParseDate(input, strict)
+			gotDate, gotErr := parseDate(input, strict)
+			assert.Equal(wantDate, gotDate, "ParseDate %q strict=%v", input, strict)
+			assert.Equal(wantErr, gotErr, "ParseDate %q strict=%v", input, strict)
+			gotDate, gotErr = parseDate([]byte(input), strict)
+			assert.Equal(wantDate, gotDate, "ParseDate []byte %q strict=%v", input, strict)
+			assert.Equal(wantErr, gotErr, "ParseDate []byte %q strict=%v", input, strict)
+
+			wantDateTime, wantErr := regexpParseDateTime(input, strict)
+			gotDateTime, gotErr := parseDateTime(input, strict)
+			assert.Equal(wantDateTime, gotDateTime, "ParseDateTime %q strict=%v", input, strict)
+			assert.Equal(wantErr, gotErr, "ParseDateTime %q strict=%v", input, strict)
+
+			wantTime, wantErr := regexpParseTime(input, strict)
+			gotTime, gotErr := parseTime(input, strict)
+			assert.Equal(wantTime, gotTime, "ParseTime %q strict=%v", input, strict)
+			assert.Equal(wantErr, gotErr, "ParseTime %q strict=%v", input, strict)
+		}
+	}
+}
+
+func BenchmarkParseDateRegexp(b *testing.B) {
+	for range b.N {
+		regexpParseDate("2026-10-17", false)
+	}
+}
+
+func BenchmarkParseDateTimeRegexp(b *testing.B) {
+	for range b.N {
+		regexpParseDateTime("2026-10-17T12:34:56", false)
+	}
+}
+
+func BenchmarkParseTimeRegexp(b *testing.B) {
+	for range b.N {
+		regexpParseTime("12:34:56.789", false)
+	}
+}
@@ -0,0 +1,203 @@
+package dt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		parse  func(string) error
+		input  string
+		field  string
+		offset int
+		reason string
+	}{
+		{parse: tryParseDateStrict, input: "2026-13-01", field: "month", offset: 5, reason: "month out of range"},
+		{parse: tryParseDateStrict, input: "2026-00-01", field: "month", offset: 5, reason: "month out of range"},
+		{parse: tryParseDateStrict, input: "2026-02-30", field: "day", offset: 8, reason: "day out of range for month"},
+		{parse: tryParseDateStrict, input: ` "2026-04-31"`, field: "day", offset: 10, reason: "day out of range for month"},
+		{parse: tryParseDateStrict, input: "20260230", field: "day", offset: 6, reason: "day out of range for month"},
+		{parse: tryParseDateStrict, input: "2026-366", field: "day of year", offset: 5, reason: "day of year out of range"},
+		{parse: tryParseDateStrict, input: "2026-000", field: "day of year", offset: 5, reason: "day of year out of range"},
+		{parse: tryParseDateStrict, input: "2026/4/31", field: "day", offset: 7, reason: "day out of range for month"},
+		{parse: tryParseDateStrict, input: " next week", offset: 1, reason: "unknown date layout"},
+		{parse: tryParseDate, input: "2026-1-1x", reason: "unknown date layout"},
+		{parse: tryParseDateTimeStrict, input: "2026-01-01T24:00", field: "hour", offset: 11, reason: "hour out of range"},
+		{parse: tryParseDateTimeStrict, input: "2026-01-01T23:60", field: "minute", offset: 14, reason: "minute out of range"},
+		{parse: tryParseDateTimeStrict, input: "2026-01-01T23:59:60", field: "second", offset: 17, reason: "second out of range"},
+		{parse: tryParseDateTimeStrict, input: "2026-02-29T12:00", field: "day", offset: 8, reason: "day out of range for month"},
+		{parse: tryParseDateTimeStrict, input: "2026367T1200", field: "day of year", offset: 4, reason: "day of year out of range"},
+		{parse: tryParseDateTimeStrict, input: "2026001T2500", field: "hour", offset: 8, reason: "hour out of range"},
+		{parse: tryParseDateTime, input: "2026-01-01 12:00", reason: "unknown date-time layout"},
+		{parse: tryParseDateStrict, input: "2025-W53-1", field: "week", offset: 6, reason: "week out of range for year"},
+		{parse: tryParseDateStrict, input: "2026W000", field: "week", offset: 5, reason: "week out of range for year"},
+		{parse: tryParseDateStrict, input: "2026-W42-8", field: "day of week", offset: 9, reason: "day of week out of range"},
+		{parse: tryParseDateTimeStrict, input: "2026W420T1200", field: "day of week", offset: 7, reason: "day of week out of range"},
+		{parse: tryParseDate, input: "2026-W426", reason: "unknown date layout"},
+		{parse: tryParseTimeStrict, input: "T24:00", field: "hour", offset: 1, reason: "hour out of range"},
+		{parse: tryParseTimeStrict, input: "1275", field: "minute", offset: 2, reason: "minute out of range"},
+		{parse: tryParseTimeStrict, input: "12:00:61.5", field: "second", offset: 6, reason: "second out of range"},
+		{parse: tryParseTime, input: "noon", reason: "unknown time layout"},
+	}
+	for _, tt := range tests {
+		err := tt.parse(tt.input)
+		var parseErr *ParseError
+		if assert.True(t, errors.As(err, &parseErr), tt.input) {
+			assert.Equal(t, tt.input, parseErr.Input)
+			assert.Equal(t, tt.field, parseErr.Field, tt.input)
+			assert.Equal(t, tt.offset, parseErr.Offset, tt.input)
+			assert.Equal(t, tt.reason, parseErr.Reason, tt.input)
+		}
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	_, err := ParseDateStrict("2026-13-01")
+	assert.EqualError(t, err, `parsing "2026-13-01": month out of range at offset 5`)
+	_, err = ParseDate("13/01/2026")
+	assert.EqualError(t, err, `parsing "13/01/2026": unknown date layout`)
+
+	// errors.As works through wrapping
+	wrapped := fmt.Errorf("row 3: %w", err)
+	var parseErr *ParseError
+	assert.True(t, errors.As(wrapped, &parseErr))
+}
+
+func TestParseLenient(t *testing.T) {
+	// without strict mode, out of range fields are normalized
+	assert.Equal(t, Date(2026, 3, 2), MustParseDate("2026-02-30"))
+	assert.Equal(t, Date(2027, 1, 1), MustParseDate("2026-13-01"))
+	assert.Equal(t, DateTime(2026, 1, 2, 0, 0, 0), MustParseDateTime("2026-01-01T24:00"))
+	assert.Equal(t, TimeOfDay(11, 15, 0, 0), MustParseTime("10:75"))
+}
+
+func TestParseWeekDate(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		text string
+		date LocalDate
+	}{
+		{text: "2026-W42-6", date: Date(2026, time.October, 17)},
+		{text: "2026W426", date: Date(2026, time.October, 17)},
+		{text: ` "2026-W42-6" `, date: Date(2026, time.October, 17)},
+		{text: "2026-W42-6T12:00Z", date: Date(2026, time.October, 17)},
+		{text: "2025-W01-1", date: Date(2024, time.December, 30)},
+		{text: "2020W537", date: Date(2021, time.January, 3)},
+		{text: "-0001-W01-1", date: Date(-1, time.January, 4)},
+	}
+	for _, tt := range tests {
+		d, err := ParseDateStrict(tt.text)
+		if assert.NoError(err, tt.text) {
+			assert.Equal(tt.date, d, tt.text)
+		}
+	}
+
+	assert.Equal(DateTime(2026, time.October, 17, 9, 30, 0), MustParseDateTime("2026-W42-6T09:30"))
+	assert.Equal(DateTime(2026, time.October, 17, 9, 30, 15), MustParseDateTime("2026W426T093015"))
+
+	// without strict mode, out of range fields are normalized
+	assert.Equal(Date(2025, time.December, 29), MustParseDate("2025-W53-1"))
+	assert.Equal(Date(2026, time.October, 19), MustParseDate("2026-W42-8"))
+
+	for _, text := range []string{"2026-W42", "2026W42", "2026-W4-6", "2026W42-6", "2026-W42-67", "2026W4267", "2026-w42-6", "2026.W42.6"} {
+		_, err := ParseDate(text)
+		assert.Error(err, text)
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	d, err := ParseDateStrict("2024-02-29")
+	assert.NoError(t, err)
+	assert.Equal(t, Date(2024, 2, 29), d)
+
+	d, err = ParseDateStrict("2024-366T10:00:00")
+	assert.NoError(t, err)
+	assert.Equal(t, Date(2024, 12, 31), d)
+
+	dt, err := ParseDateTimeStrict("2026-10-17T23:59:59")
+	assert.NoError(t, err)
+	assert.Equal(t, DateTime(2026, 10, 17, 23, 59, 59), dt)
+
+	tm, err := ParseTimeStrict("23:59:59.5")
+	assert.NoError(t, err)
+	assert.Equal(t, TimeOfDay(23, 59, 59, 500000000), tm)
+}
+
+func tryParseDate(s string) error {
+	_, err := ParseDate(s)
+	return err
+}
+
+func tryParseDateStrict(s string) error {
+	_, err := ParseDateStrict(s)
+	return err
+}
+
+func tryParseDateTime(s string) error {
+	_, err := ParseDateTime(s)
+	return err
+}
+
+func tryParseDateTimeStrict(s string) error {
+	_, err := ParseDateTimeStrict(s)
+	return err
+}
+
+func tryParseTime(s string) error {
+	_, err := ParseTime(s)
+	return err
+}
+
+func tryParseTimeStrict(s string) error {
+	_, err := ParseTimeStrict(s)
+	return err
+}
+
+func TestParseAllocs(t *testing.T) {
+	data := []byte(`"2026-10-17T12:34:56"`)
+	tests := []struct {
+		name  string
+		parse func()
+	}{
+		{name: "ParseDate", parse: func() { ParseDate("2026-10-17") }},
+		{name: "ParseDateTime", parse: func() { ParseDateTime("2026-10-17T12:34:56") }},
+		{name: "ParseTime", parse: func() { ParseTime("12:34:56.789") }},
+		{name: "LocalDate.UnmarshalJSON", parse: func() { new(LocalDate).UnmarshalJSON(data) }},
+		{name: "LocalDateTime.UnmarshalJSON", parse: func() { new(LocalDateTime).UnmarshalJSON(data) }},
+		{name: "LocalTime.UnmarshalText", parse: func() { new(LocalTime).UnmarshalText(data[12:20]) }},
+	}
+	for _, tt := range tests {
+		assert.Zero(t, testing.AllocsPerRun(100, tt.parse), tt.name)
+	}
+}
+
+func BenchmarkParseDate(b *testing.B) {
+	for range b.N {
+		ParseDate("2026-10-17")
+	}
+}
+
+func BenchmarkParseDateTime(b *testing.B) {
+	for range b.N {
+		ParseDateTime("2026-10-17T12:34:56")
+	}
+}
+
+func BenchmarkParseTime(b *testing.B) {
+	for range b.N {
+		ParseTime("12:34:56.789")
+	}
+}
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	data := []byte(`"2026-10-17T12:34:56"`)
+	var dt LocalDateTime
+	for range b.N {
+		dt.UnmarshalJSON(data)
+	}
+}
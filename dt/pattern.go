@@ -0,0 +1,733 @@
+package dt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Date patterns use the field letters of the Unicode LDML specification
+// (https://unicode.org/reports/tr35/tr35-dates.html#Date_Field_Symbol_Table).
+// A field is a run of the same letter, and the number of letters determines
+// the width of the field. Text in single quotes is copied literally, and two
+// single quotes represent a single quote. Characters that are not ASCII
+// letters are copied literally.
+//
+// The supported fields are:
+//
+//	G     era: AD; GGGG Anno Domini; GGGGG A
+//	y     year of era: y 2024; yy 24; yyyy 2024
+//	u     extended year, which is negative before 1 AD
+//	      (y and u are the week-based year in a week date pattern, which has
+//	      a w field and no M, L, d or D field)
+//	Y     ISO week-based year
+//	Q, q  quarter: Q 3; QQ 03; QQQ Q3; QQQQ 3rd quarter
+//	M, L  month: M 9; MM 09; MMM Sep; MMMM September; MMMMM S
+//	w     ISO week of the week-based year: w 5; ww 05
+//	d     day of month: d 5; dd 05
+//	D     day of year: D 5; DDD 005
+//	E     day of week: E Tue; EEEE Tuesday; EEEEE T; EEEEEE Tu
+//	e, c  day of week number, where Monday is 1: e 2; ee 02; eee Tue
+//	a     AM or PM
+//	h     hour 1-12
+//	H     hour 0-23
+//	K     hour 0-11
+//	k     hour 1-24
+//	m     minute
+//	s     second
+//	S     fraction of a second, which is always zero: SSS 000
+//
+// Names are in English. Narrow names, such as "S" for September, are
+// ambiguous, and cannot be parsed.
+
+var (
+	errPatternNoYear = errors.New("date pattern has no year")
+
+	// patternCache contains compiled patterns, keyed by pattern string.
+	// Patterns can come from user input, so no more than maxCachedPatterns
+	// are cached, and other patterns are compiled each time they are used.
+	patternCache      sync.Map
+	patternCacheCount atomic.Int64
+)
+
+// maxCachedPatterns is the largest number of patterns in patternCache.
+const maxCachedPatterns = 1000
+
+// patternToken is a literal or a field of a compiled pattern. A field has a
+// non-zero letter.
+type patternToken struct {
+	letter  byte
+	count   int
+	literal string
+}
+
+// compiledPattern is a pattern that has been split into tokens.
+type compiledPattern struct {
+	tokens   []patternToken
+	err      error // the first unsupported field or unterminated quote
+	weekDate bool  // has a week of year field, and no month or day fields
+}
+
+const patternLetters = "GyuYQqMLwdDEecahHKkmsS"
+
+// compilePattern returns the tokens of a pattern. Unsupported letters are
+// treated as literals, but err is set so that parsing fails.
+func compilePattern(pattern string) *compiledPattern {
+	if cp, ok := patternCache.Load(pattern); ok {
+		return cp.(*compiledPattern)
+	}
+	cp := &compiledPattern{}
+	addLiteral := func(s string) {
+		if n := len(cp.tokens); n > 0 && cp.tokens[n-1].letter == 0 {
+			cp.tokens[n-1].literal += s
+		} else {
+			cp.tokens = append(cp.tokens, patternToken{literal: s})
+		}
+	}
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		switch {
+		case c == '\'':
+			if i+1 < len(pattern) && pattern[i+1] == '\'' {
+				addLiteral("'")
+				i += 2
+				continue
+			}
+			var sb strings.Builder
+			i++
+			for {
+				if i >= len(pattern) {
+					if cp.err == nil {
+						cp.err = fmt.Errorf("unterminated quote in date pattern %q", pattern)
+					}
+					break
+				}
+				if pattern[i] == '\'' {
+					if i+1 < len(pattern) && pattern[i+1] == '\'' {
+						sb.WriteByte('\'')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				sb.WriteByte(pattern[i])
+				i++
+			}
+			addLiteral(sb.String())
+		case ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z'):
+			n := 1
+			for i+n < len(pattern) && pattern[i+n] == c {
+				n++
+			}
+			if strings.IndexByte(patternLetters, c) < 0 {
+				if cp.err == nil {
+					cp.err = fmt.Errorf("unsupported field %q in date pattern %q", pattern[i:i+n], pattern)
+				}
+				addLiteral(pattern[i : i+n])
+			} else {
+				cp.tokens = append(cp.tokens, patternToken{letter: c, count: n})
+			}
+			i += n
+		default:
+			addLiteral(pattern[i : i+1])
+			i++
+		}
+	}
+	cp.weekDate = cp.has('w') && !cp.has('M') && !cp.has('L') && !cp.has('d') && !cp.has('D')
+	if patternCacheCount.Load() < maxCachedPatterns {
+		if actual, loaded := patternCache.LoadOrStore(pattern, cp); loaded {
+			return actual.(*compiledPattern)
+		}
+		patternCacheCount.Add(1)
+	}
+	return cp
+}
+
+// has reports whether the pattern has a field with the letter.
+func (cp *compiledPattern) has(letter byte) bool {
+	for _, tok := range cp.tokens {
+		if tok.letter == letter {
+			return true
+		}
+	}
+	return false
+}
+
+// Format returns a textual representation of the date using an LDML date
+// pattern, such as "dd/MM/yyyy" or "EEE d MMM y". Time of day fields are
+// formatted as midnight. Letters that are not supported fields are copied
+// unchanged, so literal text in a pattern should be quoted.
+func (d LocalDate) Format(pattern string) string {
+	return string(d.AppendFormat(nil, pattern))
+}
+
+// AppendFormat is like Format but appends the textual representation to b
+// and returns the extended buffer.
+func (d LocalDate) AppendFormat(b []byte, pattern string) []byte {
+	return appendPattern(b, compilePattern(pattern), d.At(LocalTime{}))
+}
+
+// Format returns a textual representation of the date-time using an LDML
+// date pattern, such as "dd/MM/yyyy HH:mm" or "EEE d MMM y h:mm a".
+// Letters that are not supported fields are copied unchanged, so literal
+// text in a pattern should be quoted.
+func (dt LocalDateTime) Format(pattern string) string {
+	return string(dt.AppendFormat(nil, pattern))
+}
+
+// AppendFormat is like Format but appends the textual representation to b
+// and returns the extended buffer.
+func (dt LocalDateTime) AppendFormat(b []byte, pattern string) []byte {
+	return appendPattern(b, compilePattern(pattern), dt)
+}
+
+// appendPattern appends the date-time formatted using a compiled pattern
+// to b.
+func appendPattern(b []byte, cp *compiledPattern, dt LocalDateTime) []byte {
+	year, month, day, hour, minute, second := dt.DateTime()
+	isoYear, isoWeek := dt.ISOWeek()
+	if cp.weekDate {
+		// the year of a week date is the week-based year, as when parsing
+		year = isoYear
+	}
+	weekday := dt.Weekday()
+	quarter := (int(month)-1)/3 + 1
+	for _, tok := range cp.tokens {
+		n := tok.count
+		switch tok.letter {
+		case 0:
+			b = append(b, tok.literal...)
+		case 'G':
+			era := 1
+			if year <= 0 {
+				era = 0
+			}
+			b = append(b, textWidth(n, englishSymbols.eras[era], englishSymbols.eraNames[era], englishSymbols.eraNames[era][:1], "")...)
+		case 'y':
+			y := year
+			if y <= 0 {
+				y = 1 - y
+			}
+			if n == 2 {
+				b = appendNumber(b, y%100, 2)
+			} else {
+				b = appendNumber(b, y, n)
+			}
+		case 'u':
+			b = appendNumber(b, year, n)
+		case 'Y':
+			if n == 2 {
+				b = appendNumber(b, isoYear%100, 2)
+			} else {
+				b = appendNumber(b, isoYear, n)
+			}
+		case 'Q', 'q':
+			if n <= 2 {
+				b = appendNumber(b, quarter, n)
+			} else {
+				b = append(b, textWidth(n, englishSymbols.shortQuarters[quarter-1], englishSymbols.quarters[quarter-1], englishSymbols.shortQuarters[quarter-1][1:], "")...)
+			}
+		case 'M', 'L':
+			if n <= 2 {
+				b = appendNumber(b, int(month), n)
+			} else {
+				name := englishSymbols.months[month-1]
+				b = append(b, textWidth(n, englishSymbols.shortMonths[month-1], name, name[:1], "")...)
+			}
+		case 'w':
+			b = appendNumber(b, isoWeek, n)
+		case 'd':
+			b = appendNumber(b, day, n)
+		case 'D':
+			b = appendNumber(b, dt.YearDay(), n)
+		case 'E':
+			b = append(b, weekdayText(weekday, max(n, 3))...)
+		case 'e', 'c':
+			if n <= 2 {
+				b = appendNumber(b, isoWeekday(weekday), n)
+			} else {
+				b = append(b, weekdayText(weekday, n)...)
+			}
+		case 'a':
+			b = append(b, englishSymbols.dayPeriods[hour/12]...)
+		case 'h':
+			h := hour % 12
+			if h == 0 {
+				h = 12
+			}
+			b = appendNumber(b, h, n)
+		case 'H':
+			b = appendNumber(b, hour, n)
+		case 'K':
+			b = appendNumber(b, hour%12, n)
+		case 'k':
+			h := hour
+			if h == 0 {
+				h = 24
+			}
+			b = appendNumber(b, h, n)
+		case 'm':
+			b = appendNumber(b, minute, n)
+		case 's':
+			b = appendNumber(b, second, n)
+		case 'S':
+			// local date-times do not have fractions of a second
+			for range n {
+				b = append(b, '0')
+			}
+		}
+	}
+	return b
+}
+
+// appendNumber appends n with at least width digits to b.
+func appendNumber(b []byte, n int, width int) []byte {
+	if n < 0 {
+		b = append(b, '-')
+		n = -n
+	}
+	digits := 1
+	for m := n; m >= 10; m /= 10 {
+		digits++
+	}
+	for ; digits < width; digits++ {
+		b = append(b, '0')
+	}
+	return strconv.AppendInt(b, int64(n), 10)
+}
+
+// textWidth chooses the abbreviated, wide, narrow or short form of a name,
+// for a field of three, four, five or six letters.
+func textWidth(n int, abbreviated, wide, narrow, short string) string {
+	switch {
+	case n <= 3:
+		return abbreviated
+	case n == 4:
+		return wide
+	case n == 5 || short == "":
+		return narrow
+	}
+	return short
+}
+
+func weekdayText(weekday time.Weekday, n int) string {
+	name := englishSymbols.weekdays[weekday]
+	return textWidth(n, name[:3], name, name[:1], name[:2])
+}
+
+// isoWeekday returns the ISO day of the week, where Monday is 1 and
+// Sunday is 7.
+func isoWeekday(weekday time.Weekday) int {
+	if weekday == time.Sunday {
+		return 7
+	}
+	return int(weekday)
+}
+
+// dateSymbols contains the names used by date patterns.
+type dateSymbols struct {
+	months        [12]string
+	shortMonths   [12]string
+	weekdays      [7]string // starting with Sunday
+	quarters      [4]string
+	shortQuarters [4]string
+	eras          [2]string // BC, AD
+	eraNames      [2]string
+	dayPeriods    [2]string
+}
+
+var englishSymbols = dateSymbols{
+	months:        [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	shortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	quarters:      [4]string{"1st quarter", "2nd quarter", "3rd quarter", "4th quarter"},
+	shortQuarters: [4]string{"Q1", "Q2", "Q3", "Q4"},
+	eras:          [2]string{"BC", "AD"},
+	eraNames:      [2]string{"Before Christ", "Anno Domini"},
+	dayPeriods:    [2]string{"AM", "PM"},
+}
+
+// ParseDatePattern parses a date using an LDML date pattern, such as
+// "dd/MM/yyyy" or "EEE d MMM y" (see LocalDate.Format). The whole of s must
+// match the pattern. Numeric fields of two or more letters must have exactly
+// that number of digits, except for years, and names are matched without
+// regard to case. Fields must be in range, and fields that are redundant,
+// such as the day of the week, must agree with the date. Time of day fields
+// are parsed and then ignored.
+//
+// A pattern with a week of year field ("w") and no month or day is a week
+// date, in which the year is the ISO week-based year, whether the field is
+// "Y", "y" or "u". Format writes the week-based year for these patterns too,
+// so that the result parses to the same date. For example, the pattern
+// "YYYY-'W'ww-e" parses "2026-W42-6".
+func ParseDatePattern(pattern string, s string) (LocalDate, error) {
+	dt, err := parsePattern(pattern, s)
+	if err != nil {
+		return LocalDate{}, err
+	}
+	return dt.LocalDate(), nil
+}
+
+// MustParseDatePattern is like ParseDatePattern, but panics if s cannot
+// be parsed.
+func MustParseDatePattern(pattern string, s string) LocalDate {
+	d, err := ParseDatePattern(pattern, s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return d
+}
+
+// ParseDateTimePattern parses a date-time using an LDML date pattern,
+// such as "dd/MM/yyyy HH:mm". See ParseDatePattern for how fields are
+// matched. Time of day fields that are not in the pattern are zero, and
+// fractions of a second are ignored.
+func ParseDateTimePattern(pattern string, s string) (LocalDateTime, error) {
+	return parsePattern(pattern, s)
+}
+
+// MustParseDateTimePattern is like ParseDateTimePattern, but panics if s
+// cannot be parsed.
+func MustParseDateTimePattern(pattern string, s string) LocalDateTime {
+	dt, err := ParseDateTimePattern(pattern, s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return dt
+}
+
+// unset is the value of a field that is not in a pattern.
+const unset = math.MinInt32
+
+// patternFields are the values parsed from a string.
+type patternFields struct {
+	era, year, extendedYear, weekYear           int
+	quarter, month, week, day, yearDay, weekday int
+	dayPeriod, hour, hour24, hour0, hour12      int
+	minute, second                              int
+}
+
+func parsePattern(pattern string, s string) (LocalDateTime, error) {
+	cp := compilePattern(pattern)
+	if cp.err != nil {
+		return LocalDateTime{}, cp.err
+	}
+	f := patternFields{
+		era: unset, year: unset, extendedYear: unset, weekYear: unset,
+		quarter: unset, month: unset, week: unset, day: unset, yearDay: unset, weekday: unset,
+		dayPeriod: unset, hour: unset, hour24: unset, hour0: unset, hour12: unset,
+		minute: unset, second: unset,
+	}
+	for i, tok := range cp.tokens {
+		if tok.letter == 0 {
+			if !strings.HasPrefix(s, tok.literal) {
+				return LocalDateTime{}, errInvalidDateFormat
+			}
+			s = s[len(tok.literal):]
+			continue
+		}
+		if isNarrowField(tok) {
+			return LocalDateTime{}, fmt.Errorf("narrow field %q in date pattern %q cannot be parsed",
+				strings.Repeat(string(tok.letter), tok.count), pattern)
+		}
+
+		// a numeric field followed by another numeric field must have
+		// exactly the number of digits in the pattern
+		adjacent := i+1 < len(cp.tokens) && isNumericField(cp.tokens[i+1])
+		var ok bool
+		if s, ok = f.parseField(tok, s, adjacent); !ok {
+			return LocalDateTime{}, errInvalidDateFormat
+		}
+	}
+	if s != "" {
+		return LocalDateTime{}, errInvalidDateFormat
+	}
+	return f.resolve()
+}
+
+// isNumericField reports whether a token is a field with a numeric value.
+func isNumericField(tok patternToken) bool {
+	switch tok.letter {
+	case 0, 'G', 'E', 'a':
+		return false
+	case 'Q', 'q', 'M', 'L', 'e', 'c':
+		return tok.count <= 2
+	}
+	return true
+}
+
+// isNarrowField reports whether a token is a narrow name, such as "S"
+// for September, which is ambiguous.
+func isNarrowField(tok patternToken) bool {
+	switch tok.letter {
+	case 'G', 'Q', 'q', 'M', 'L':
+		return tok.count >= 5
+	case 'E', 'e', 'c':
+		return tok.count == 5
+	}
+	return false
+}
+
+// parseField parses the value of a field at the start of s, and returns
+// the rest of s.
+func (f *patternFields) parseField(tok patternToken, s string, adjacent bool) (string, bool) {
+	n := tok.count
+	var value int
+	ok := true
+	switch tok.letter {
+	case 'G':
+		if n <= 3 {
+			value, s, ok = parseName(s, englishSymbols.eras[:])
+		} else {
+			value, s, ok = parseName(s, englishSymbols.eraNames[:])
+		}
+		f.era = value
+	case 'y', 'Y', 'u':
+		sign := 1
+		if tok.letter == 'u' && strings.HasPrefix(s, "-") {
+			sign, s = -1, s[1:]
+		}
+		if n == 2 {
+			// two digit years are from 2000 to 2099
+			value, s, ok = parseDigits(s, 2, 2)
+			value += 2000
+		} else {
+			maxDigits := 9
+			if adjacent {
+				maxDigits = n
+			}
+			value, s, ok = parseDigits(s, n, maxDigits)
+		}
+		switch tok.letter {
+		case 'y':
+			f.year = value
+		case 'Y':
+			f.weekYear = value
+		default:
+			f.extendedYear = sign * value
+		}
+	case 'Q', 'q':
+		switch n {
+		case 1, 2:
+			value, s, ok = parseNumber(s, n, adjacent, 1, 4)
+		case 3:
+			value, s, ok = parseName(s, []string{"Q1", "Q2", "Q3", "Q4"})
+			value++
+		default:
+			value, s, ok = parseName(s, englishSymbols.quarters[:])
+			value++
+		}
+		f.quarter = value
+	case 'M', 'L':
+		switch n {
+		case 1, 2:
+			value, s, ok = parseNumber(s, n, adjacent, 1, 12)
+		case 3:
+			value, s, ok = parseName(s, englishSymbols.shortMonths[:])
+			value++
+		default:
+			value, s, ok = parseName(s, englishSymbols.months[:])
+			value++
+		}
+		f.month = value
+	case 'w':
+		value, s, ok = parseNumber(s, n, adjacent, 1, 53)
+		f.week = value
+	case 'd':
+		value, s, ok = parseNumber(s, n, adjacent, 1, 31)
+		f.day = value
+	case 'D':
+		value, s, ok = parseNumber(s, n, adjacent, 1, 366)
+		f.yearDay = value
+	case 'E', 'e', 'c':
+		if tok.letter != 'E' && n <= 2 {
+			value, s, ok = parseNumber(s, n, adjacent, 1, 7)
+			value %= 7
+		} else {
+			names := make([]string, 7)
+			for i := range names {
+				names[i] = weekdayText(time.Weekday(i), max(n, 3))
+			}
+			value, s, ok = parseName(s, names)
+		}
+		f.weekday = value
+	case 'a':
+		value, s, ok = parseName(s, englishSymbols.dayPeriods[:])
+		f.dayPeriod = value
+	case 'h':
+		value, s, ok = parseNumber(s, n, adjacent, 1, 12)
+		f.hour12 = value
+	case 'H':
+		value, s, ok = parseNumber(s, n, adjacent, 0, 23)
+		f.hour = value
+	case 'K':
+		value, s, ok = parseNumber(s, n, adjacent, 0, 11)
+		f.hour0 = value
+	case 'k':
+		value, s, ok = parseNumber(s, n, adjacent, 1, 24)
+		f.hour24 = value
+	case 'm':
+		value, s, ok = parseNumber(s, n, adjacent, 0, 59)
+		f.minute = value
+	case 's':
+		value, s, ok = parseNumber(s, n, adjacent, 0, 59)
+		f.second = value
+	case 'S':
+		_, s, ok = parseDigits(s, n, n)
+	}
+	return s, ok
+}
+
+// parseNumber parses a numeric field. A field of one letter has one or two
+// digits, unless it is adjacent to another numeric field, and a field of
+// more letters has exactly that number of digits.
+func parseNumber(s string, n int, adjacent bool, min, max int) (int, string, bool) {
+	maxDigits := n
+	if n == 1 && !adjacent {
+		maxDigits = len(strconv.Itoa(max))
+	}
+	value, rest, ok := parseDigits(s, n, maxDigits)
+	if !ok || value < min || value > max {
+		return 0, s, false
+	}
+	return value, rest, true
+}
+
+// parseDigits parses between minDigits and maxDigits decimal digits.
+func parseDigits(s string, minDigits, maxDigits int) (int, string, bool) {
+	value, i := 0, 0
+	for i < len(s) && i < maxDigits && '0' <= s[i] && s[i] <= '9' {
+		value = value*10 + int(s[i]-'0')
+		i++
+	}
+	if i < minDigits {
+		return 0, s, false
+	}
+	return value, s[i:], true
+}
+
+// parseName returns the index of the longest name that s starts with,
+// ignoring case.
+func parseName(s string, names []string) (int, string, bool) {
+	index, length := -1, 0
+	for i, name := range names {
+		if len(name) > length && len(s) >= len(name) && strings.EqualFold(s[:len(name)], name) {
+			index, length = i, len(name)
+		}
+	}
+	if index < 0 {
+		return 0, s, false
+	}
+	return index, s[length:], true
+}
+
+// resolve returns the date-time for the parsed fields, and checks
+// that redundant fields agree.
+func (f *patternFields) resolve() (LocalDateTime, error) {
+	year := unset
+	switch {
+	case f.extendedYear != unset:
+		year = f.extendedYear
+	case f.year != unset:
+		year = f.year
+		if f.era == 0 {
+			year = 1 - year
+		}
+	}
+
+	var d LocalDate
+	if f.week != unset && f.month == unset && f.day == unset && f.yearDay == unset {
+		// a week date, where the year is the week-based year
+		if f.weekYear == unset {
+			f.weekYear = year
+		}
+		if f.weekYear == unset {
+			return LocalDateTime{}, errPatternNoYear
+		}
+		weekday := f.weekday
+		if weekday == unset {
+			weekday = int(time.Monday)
+		}
+		d = ISOWeekDate(f.weekYear, f.week, time.Weekday(weekday))
+	} else {
+		if year == unset {
+			year = f.weekYear
+		}
+		if year == unset {
+			return LocalDateTime{}, errPatternNoYear
+		}
+		if f.yearDay != unset && f.month == unset {
+			d = Date(year, time.January, f.yearDay)
+		} else {
+			month, day := f.month, max(f.day, 1)
+			if month == unset {
+				month = 1
+				if f.quarter != unset {
+					month = (f.quarter-1)*3 + 1
+				}
+			}
+			d = Date(year, time.Month(month), day)
+			if d.Day() != day {
+				return LocalDateTime{}, errInvalidDateFormat
+			}
+		}
+		if d.Year() != year {
+			return LocalDateTime{}, errInvalidDateFormat
+		}
+	}
+
+	isoYear, isoWeek := d.ISOWeek()
+	if (f.weekday != unset && d.Weekday() != time.Weekday(f.weekday)) ||
+		(f.quarter != unset && (int(d.Month())-1)/3+1 != f.quarter) ||
+		(f.yearDay != unset && d.YearDay() != f.yearDay) ||
+		(f.week != unset && isoWeek != f.week) ||
+		(f.weekYear != unset && isoYear != f.weekYear) {
+		return LocalDateTime{}, errInvalidDateFormat
+	}
+
+	hour, ok := f.resolveHour()
+	if !ok {
+		return LocalDateTime{}, errInvalidDateFormat
+	}
+	return d.At(TimeOfDay(hour, max(f.minute, 0), max(f.second, 0), 0)), nil
+}
+
+// resolveHour returns the hour from the hour fields and the day period,
+// and reports whether they agree.
+func (f *patternFields) resolveHour() (int, bool) {
+	pm := 0
+	if f.dayPeriod == 1 {
+		pm = 12
+	}
+	hour := unset
+	for _, h := range []struct {
+		value  int
+		offset int
+	}{
+		{value: f.hour},
+		{value: f.hour24 % 24},
+		{value: f.hour0, offset: pm},
+		{value: f.hour12 % 12, offset: pm},
+	} {
+		if h.value < 0 {
+			continue
+		}
+		if hour != unset && hour != h.value+h.offset {
+			return 0, false
+		}
+		hour = h.value + h.offset
+	}
+	if hour == unset {
+		return 0, f.dayPeriod == unset
+	}
+	if f.dayPeriod != unset && (hour >= 12) != (pm == 12) {
+		return 0, false
+	}
+	return hour, true
+}
@@ -0,0 +1,208 @@
+package dt
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalDateFormat(t *testing.T) {
+	tests := []struct {
+		date    LocalDate
+		pattern string
+		want    string
+	}{
+		{Date(2024, 3, 5), "dd/MM/yyyy", "05/03/2024"},
+		{Date(2024, 3, 5), "d/M/yy", "5/3/24"},
+		{Date(2024, 3, 5), "EEE d MMM y", "Tue 5 Mar 2024"},
+		{Date(2024, 3, 5), "EEEE, MMMM d, y", "Tuesday, March 5, 2024"},
+		{Date(2024, 3, 5), "EEEEE EEEEEE MMMMM", "T Tu M"},
+		{Date(2026, 10, 17), "yyyy-'W'ww-e", "2026-W42-6"},
+		{Date(2024, 12, 30), "YYYY-'W'ww-e", "2025-W01-1"},
+		{Date(2024, 12, 30), "yyyy-DDD", "2024-365"},
+		{Date(2024, 8, 1), "Q QQ QQQ QQQQ", "3 03 Q3 3rd quarter"},
+		{Date(2024, 8, 1), "G GGGG", "AD Anno Domini"},
+		{Date(0, 1, 1), "y G u", "1 BC 0"},
+		{Date(2024, 3, 5), "'week' w 'of' y", "week 10 of 2024"},
+		{Date(2024, 3, 5), "d 'o''clock' ''", "5 o'clock '"},
+		{Date(2024, 3, 5), "HH:mm:ss a", "00:00:00 AM"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.date.Format(tt.pattern), tt.pattern)
+	}
+}
+
+func TestLocalDateTimeFormat(t *testing.T) {
+	tests := []struct {
+		dt      LocalDateTime
+		pattern string
+		want    string
+	}{
+		{DateTime(2024, 3, 5, 14, 7, 9), "dd/MM/yyyy HH:mm:ss", "05/03/2024 14:07:09"},
+		{DateTime(2024, 3, 5, 14, 7, 9), "h:mm a", "2:07 PM"},
+		{DateTime(2024, 3, 5, 0, 7, 9), "h:mm a|K k", "12:07 AM|0 24"},
+		{DateTime(2024, 3, 5, 12, 0, 0), "hh a KK kk", "12 PM 00 12"},
+		{DateTime(2024, 3, 5, 14, 7, 9), "yyyyMMdd'T'HHmmss.SSS", "20240305T140709.000"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.dt.Format(tt.pattern), tt.pattern)
+	}
+}
+
+func TestParseDatePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+		want    LocalDate
+	}{
+		{"dd/MM/yyyy", "05/03/2024", Date(2024, 3, 5)},
+		{"d/M/y", "5/3/2024", Date(2024, 3, 5)},
+		{"d/M/y", "15/12/2024", Date(2024, 12, 15)},
+		{"d/M/yy", "5/3/24", Date(2024, 3, 5)},
+		{"yyyyMMdd", "20240305", Date(2024, 3, 5)},
+		{"EEE d MMM y", "Tue 5 Mar 2024", Date(2024, 3, 5)},
+		{"EEE d MMM y", "tue 5 MAR 2024", Date(2024, 3, 5)},
+		{"EEEE, MMMM d, y", "Tuesday, March 5, 2024", Date(2024, 3, 5)},
+		{"yyyy-'W'ww-e", "2026-W42-6", Date(2026, 10, 17)},
+		{"YYYY-'W'ww-e", "2025-W01-1", Date(2024, 12, 30)},
+		{"YYYY-'W'ww-EEE", "2020-W53-Sun", Date(2021, 1, 3)},
+		{"YYYY'W'ww", "2026W42", Date(2026, 10, 12)},
+		{"yyyy-DDD", "2024-366", Date(2024, 12, 31)},
+		{"y G", "44 BC", Date(-43, 1, 1)},
+		{"u-MM-dd", "-0043-03-15", Date(-43, 3, 15)},
+		{"QQQ yyyy", "Q3 2024", Date(2024, 7, 1)},
+		{"d 'de' MMMM y", "5 de March 2024", Date(2024, 3, 5)},
+		{"dd/MM/yyyy HH:mm", "05/03/2024 14:07", Date(2024, 3, 5)},
+	}
+	for _, tt := range tests {
+		d, err := ParseDatePattern(tt.pattern, tt.text)
+		if assert.NoError(t, err, tt.text) {
+			assert.Equal(t, tt.want, d, tt.text)
+		}
+	}
+}
+
+func TestParseDatePatternErrors(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+		errText string
+	}{
+		{"dd/MM/yyyy", "05-03-2024", "invalid date format"},
+		{"dd/MM/yyyy", "5/03/2024", "invalid date format"},
+		{"dd/MM/yyyy", "05/03/2024 ", "invalid date format"},
+		{"dd/MM/yyyy", "31/02/2024", "invalid date format"},
+		{"dd/MM/yyyy", "05/13/2024", "invalid date format"},
+		{"EEE d MMM y", "Wed 5 Mar 2024", "invalid date format"},
+		{"EEE d MMM y", "Tue 5 Mrz 2024", "invalid date format"},
+		{"yyyy-'W'ww-e", "2026-W42-8", "invalid date format"},
+		{"YYYY-'W'ww", "2025-W53", "invalid date format"},
+		{"Q yyyy-MM-dd", "2 2024-08-01", "invalid date format"},
+		{"yyyy-DDD", "2023-366", "invalid date format"},
+		{"dd/MM", "05/03", "date pattern has no year"},
+		{"dd/MM/yyyy ii", "05/03/2024 ii", `unsupported field "ii" in date pattern "dd/MM/yyyy ii"`},
+		{"dd/MM/yyyy 'at", "05/03/2024 at", `unterminated quote in date pattern "dd/MM/yyyy 'at"`},
+		{"MMMMM y", "M 2024", `narrow field "MMMMM" in date pattern "MMMMM y" cannot be parsed`},
+	}
+	for _, tt := range tests {
+		_, err := ParseDatePattern(tt.pattern, tt.text)
+		if assert.Error(t, err, tt.text) {
+			assert.Equal(t, tt.errText, err.Error(), tt.text)
+		}
+	}
+}
+
+func TestParseDateTimePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+		want    LocalDateTime
+		errText string
+	}{
+		{pattern: "dd/MM/yyyy HH:mm:ss", text: "05/03/2024 14:07:09", want: DateTime(2024, 3, 5, 14, 7, 9)},
+		{pattern: "d MMM y h:mm a", text: "5 Mar 2024 2:07 pm", want: DateTime(2024, 3, 5, 14, 7, 0)},
+		{pattern: "d MMM y h:mm a", text: "5 Mar 2024 12:30 AM", want: DateTime(2024, 3, 5, 0, 30, 0)},
+		{pattern: "d MMM y K a", text: "5 Mar 2024 0 PM", want: DateTime(2024, 3, 5, 12, 0, 0)},
+		{pattern: "d MMM y kk", text: "5 Mar 2024 24", want: DateTime(2024, 3, 5, 0, 0, 0)},
+		{pattern: "yyyyMMdd'T'HHmmss.SSS", text: "20240305T140709.123", want: DateTime(2024, 3, 5, 14, 7, 9)},
+		{pattern: "d MMM y HH a", text: "5 Mar 2024 14 PM", want: DateTime(2024, 3, 5, 14, 0, 0)},
+		{pattern: "d MMM y HH a", text: "5 Mar 2024 14 AM", errText: "invalid date format"},
+		{pattern: "d MMM y h:mm", text: "5 Mar 2024 13:00", errText: "invalid date format"},
+		{pattern: "d MMM y HH:mm", text: "5 Mar 2024 12:60", errText: "invalid date format"},
+	}
+	for _, tt := range tests {
+		dt, err := ParseDateTimePattern(tt.pattern, tt.text)
+		if tt.errText != "" {
+			if assert.Error(t, err, tt.text) {
+				assert.Equal(t, tt.errText, err.Error(), tt.text)
+			}
+			continue
+		}
+		if assert.NoError(t, err, tt.text) {
+			assert.Equal(t, tt.want, dt, tt.text)
+		}
+	}
+}
+
+func TestPatternRoundTrip(t *testing.T) {
+	patterns := []string{
+		"dd/MM/yyyy HH:mm:ss",
+		"EEEE d MMMM y h:mm:ss a",
+		"YYYY-'W'ww-e HH:mm:ss",
+		"yyyy-DDD'T'kk:mm:ss",
+		"G y-MM-dd KK:mm:ss a",
+	}
+	d := DateTime(1999, 12, 27, 0, 59, 30)
+	for i := range 400 {
+		dt := d.Add(time.Duration(i) * 25 * time.Hour)
+		for _, pattern := range patterns {
+			text := dt.Format(pattern)
+			got, err := ParseDateTimePattern(pattern, text)
+			if assert.NoError(t, err, text) {
+				assert.Equal(t, dt, got, text)
+			}
+		}
+	}
+}
+
+func TestWeekDatePatternRoundTrip(t *testing.T) {
+	// in a week date pattern, y is the week-based year when formatting as
+	// well as when parsing, so dates near the start and end of the year
+	// round trip
+	patterns := []string{"yyyy-'W'ww-e", "YYYY-'W'ww-e", "u'W'wwe", "y-'W'ww-EEE G"}
+	for _, year := range []int{2020, 2024, 2026, 2027} {
+		for day := range 14 {
+			d := Date(year, time.December, 25+day)
+			for _, pattern := range patterns {
+				text := d.Format(pattern)
+				got, err := ParseDatePattern(pattern, text)
+				if assert.NoError(t, err, text) {
+					assert.Equal(t, d, got, "%s %s", pattern, text)
+				}
+			}
+		}
+	}
+	assert.Equal(t, "2025-W01-1", Date(2024, 12, 30).Format("yyyy-'W'ww-e"))
+	assert.Equal(t, "2020-W53-7", Date(2021, 1, 3).Format("yyyy-'W'ww-e"))
+
+	// y is the calendar year when the pattern also has a month or day
+	assert.Equal(t, "2024-12-30 W01", Date(2024, 12, 30).Format("yyyy-MM-dd 'W'ww"))
+}
+
+func TestPatternCacheLimit(t *testing.T) {
+	d := Date(2024, 3, 5)
+	for i := range 2 * maxCachedPatterns {
+		pattern := "y-MM-dd '" + strconv.Itoa(i) + "'"
+		assert.Equal(t, "2024-03-05 "+strconv.Itoa(i), d.Format(pattern))
+	}
+	assert.LessOrEqual(t, patternCacheCount.Load(), int64(maxCachedPatterns))
+}
+
+func TestMustParseDatePattern(t *testing.T) {
+	assert.Equal(t, Date(2024, 3, 5), MustParseDatePattern("d/M/y", "5/3/2024"))
+	assert.Panics(t, func() { MustParseDatePattern("d/M/y", "5/3") })
+	assert.Equal(t, DateTime(2024, 3, 5, 9, 30, 0), MustParseDateTimePattern("d/M/y H:mm", "5/3/2024 9:30"))
+	assert.Panics(t, func() { MustParseDateTimePattern("d/M/y H:mm", "5/3/2024") })
+}
@@ -0,0 +1,230 @@
+package dt
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Period represents an amount of time in years, months and days,
+// such as "1 year, 2 months and 3 days". It is the date-based
+// counterpart of time.Duration.
+//
+// A Period does not represent a fixed number of days, because the
+// length of a month or a year depends on the date that the period is
+// added to. Adding a period to a date is the same as calling AddDate
+// with the years, months and days of the period.
+//
+// The zero value of Period is a period of zero days.
+type Period struct {
+	years  int
+	months int
+	days   int
+}
+
+var (
+	errInvalidPeriodFormat = errors.New("invalid period format")
+)
+
+var periodRegexp = regexp.MustCompile(`^([-+]?)P(?:([-+]?\d+)Y)?(?:([-+]?\d+)M)?(?:([-+]?\d+)W)?(?:([-+]?\d+)D)?$`)
+
+// NewPeriod returns a period of the given number of years, months and days.
+// No normalization is performed, so a period of 14 months remains 14 months.
+func NewPeriod(years int, months int, days int) Period {
+	return Period{
+		years:  years,
+		months: months,
+		days:   days,
+	}
+}
+
+// PeriodBetween returns the period of time between the local dates a and b,
+// in years, months and days. The start date a is included in the period
+// and the end date b is not.
+//
+// The result is exact, in that a.AddPeriod(PeriodBetween(a, b)) is always
+// equal to b. The years and months are as large as possible, and the
+// days component is the remainder. If b is before a, all components of
+// the result are zero or negative.
+func PeriodBetween(a, b LocalDate) Period {
+	ay, am, _ := a.Date()
+	by, bm, _ := b.Date()
+	months := (by*12 + int(bm)) - (ay*12 + int(am))
+
+	if b.Before(a) {
+		for a.AddDate(0, months, 0).Before(b) {
+			months++
+		}
+	} else {
+		for a.AddDate(0, months, 0).After(b) {
+			months--
+		}
+	}
+
+	days := int(b.Sub(a.AddDate(0, months, 0)) / nanosecondsPerDay)
+	return NewPeriod(months/12, months%12, days)
+}
+
+// Years returns the years component of the period p.
+func (p Period) Years() int {
+	return p.years
+}
+
+// Months returns the months component of the period p.
+func (p Period) Months() int {
+	return p.months
+}
+
+// Days returns the days component of the period p.
+func (p Period) Days() int {
+	return p.days
+}
+
+// IsZero reports whether all components of the period p are zero.
+func (p Period) IsZero() bool {
+	return p.years == 0 && p.months == 0 && p.days == 0
+}
+
+// Equal reports whether p and q have the same years, months and days.
+// Periods are not normalized before comparison, so a period of one year
+// is not equal to a period of twelve months.
+func (p Period) Equal(q Period) bool {
+	return p == q
+}
+
+// Negated returns the period p with each component negated.
+func (p Period) Negated() Period {
+	return NewPeriod(-p.years, -p.months, -p.days)
+}
+
+// Normalize returns the period p with whole multiples of twelve months
+// converted into years. For example, "P1Y14M" is normalized to "P2Y2M".
+// The days component is unchanged, because the number of days in a
+// month is not fixed. The years and months components of the result
+// have the same sign.
+func (p Period) Normalize() Period {
+	totalMonths := p.years*12 + p.months
+	return NewPeriod(totalMonths/12, totalMonths%12, p.days)
+}
+
+// AddPeriod returns the local date corresponding to adding the period p to d.
+// It is equivalent to d.AddDate(p.Years(), p.Months(), p.Days()).
+func (d LocalDate) AddPeriod(p Period) LocalDate {
+	return d.AddDate(p.years, p.months, p.days)
+}
+
+// AddPeriod returns the local date-time corresponding to adding the period p to dt.
+// It is equivalent to dt.AddDate(p.Years(), p.Months(), p.Days()).
+func (dt LocalDateTime) AddPeriod(p Period) LocalDateTime {
+	return dt.AddDate(p.years, p.months, p.days)
+}
+
+// String returns a string representation of p in the ISO 8601
+// duration format, for example "P1Y2M3D". Components that are zero
+// are omitted, and the zero period is "P0D". Negative components are
+// preceded by a minus sign, for example "P-1Y-2M".
+func (p Period) String() string {
+	return periodString(p)
+}
+
+// periodString returns the string representation of the period.
+func periodString(p Period) string {
+	if p.IsZero() {
+		return "P0D"
+	}
+	var sb strings.Builder
+	sb.WriteString("P")
+	if p.years != 0 {
+		fmt.Fprintf(&sb, "%dY", p.years)
+	}
+	if p.months != 0 {
+		fmt.Fprintf(&sb, "%dM", p.months)
+	}
+	if p.days != 0 {
+		fmt.Fprintf(&sb, "%dD", p.days)
+	}
+	return sb.String()
+}
+
+// ParsePeriod attempts to parse a string into a period. Leading and
+// trailing space and quotation marks are ignored. The string is
+// expected to be in the ISO 8601 duration format PnYnMnWnD, where any
+// of the components may be omitted but at least one must be present.
+// Weeks are converted to days. The whole period, or any individual
+// component, may be preceded by a sign. For example "P1Y2M3D", "P2W",
+// "-P1M" and "P1Y-2M" are all valid.
+func ParsePeriod(s string) (Period, error) {
+	s = strings.ToUpper(strings.Trim(s, " \t\"'"))
+	match := periodRegexp.FindStringSubmatch(s)
+	if match == nil {
+		return Period{}, errInvalidPeriodFormat
+	}
+
+	var values [4]int
+	var found bool
+	for i, text := range match[2:] {
+		if text == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(text, 10, 0)
+		if err != nil {
+			return Period{}, errInvalidPeriodFormat
+		}
+		values[i] = int(n)
+		found = true
+	}
+	if !found {
+		return Period{}, errInvalidPeriodFormat
+	}
+
+	p := NewPeriod(values[0], values[1], values[3]+values[2]*7)
+	if match[1] == "-" {
+		p = p.Negated()
+	}
+	return p, nil
+}
+
+// MustParsePeriod is similar to ParsePeriod, but instead of returning an error it will
+// panic if s is not in the expected format.
+func MustParsePeriod(s string) Period {
+	p, err := ParsePeriod(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return p
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The period is a quoted string in the ISO 8601 duration format (PnYnMnD).
+func (p Period) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + periodString(p) + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The period is expected to be a quoted string in the ISO 8601
+// duration format.
+// By convention, a JSON null is a no-op.
+func (p *Period) UnmarshalJSON(data []byte) (err error) {
+	if isNullJSON(data) {
+		return nil
+	}
+	s := string(data)
+	*p, err = ParsePeriod(s)
+	return
+}
+
+// MarshalText implements the encoding.TextMarshaller interface.
+// The period format is PnYnMnD.
+func (p Period) MarshalText() ([]byte, error) {
+	return []byte(periodString(p)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaller interface.
+// The period is expected to be in the ISO 8601 duration format.
+func (p *Period) UnmarshalText(data []byte) (err error) {
+	s := string(data)
+	*p, err = ParsePeriod(s)
+	return
+}
@@ -0,0 +1,148 @@
+package dt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePeriod(t *testing.T) {
+	testCases := []struct {
+		Text   string
+		Valid  bool
+		Years  int
+		Months int
+		Days   int
+		String string
+	}{
+		{Text: "P1Y2M3D", Valid: true, Years: 1, Months: 2, Days: 3, String: "P1Y2M3D"},
+		{Text: "P1Y", Valid: true, Years: 1, String: "P1Y"},
+		{Text: "P14M", Valid: true, Months: 14, String: "P14M"},
+		{Text: "P3D", Valid: true, Days: 3, String: "P3D"},
+		{Text: "P2W", Valid: true, Days: 14, String: "P14D"},
+		{Text: "P1W2D", Valid: true, Days: 9, String: "P9D"},
+		{Text: "P0D", Valid: true, String: "P0D"},
+		{Text: "-P1Y2M", Valid: true, Years: -1, Months: -2, String: "P-1Y-2M"},
+		{Text: "P1Y-2M", Valid: true, Years: 1, Months: -2, String: "P1Y-2M"},
+		{Text: "+P1D", Valid: true, Days: 1, String: "P1D"},
+		{Text: "p1y2m3d", Valid: true, Years: 1, Months: 2, Days: 3, String: "P1Y2M3D"},
+		{Text: ` "P1M" `, Valid: true, Months: 1, String: "P1M"},
+		{Text: "", Valid: false},
+		{Text: "P", Valid: false},
+		{Text: "1Y", Valid: false},
+		{Text: "P1D1Y", Valid: false},
+		{Text: "PT1H", Valid: false},
+		{Text: "P1.5Y", Valid: false},
+		{Text: "P99999999999999999999D", Valid: false},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		p, err := ParsePeriod(tc.Text)
+		if tc.Valid {
+			assert.NoError(err, tc.Text)
+			assert.Equal(tc.Years, p.Years(), tc.Text)
+			assert.Equal(tc.Months, p.Months(), tc.Text)
+			assert.Equal(tc.Days, p.Days(), tc.Text)
+			assert.Equal(tc.String, p.String(), tc.Text)
+		} else {
+			assert.Error(err, tc.Text)
+		}
+	}
+}
+
+func TestPeriodNormalize(t *testing.T) {
+	testCases := []struct {
+		Period   string
+		Expected string
+	}{
+		{"P1Y14M", "P2Y2M"},
+		{"P12M40D", "P1Y40D"},
+		{"P1Y-2M", "P10M"},
+		{"P-1Y-14M", "P-2Y-2M"},
+		{"P-1Y2M", "P-10M"},
+		{"P0D", "P0D"},
+	}
+	for _, tc := range testCases {
+		p := MustParsePeriod(tc.Period)
+		assert.Equal(t, tc.Expected, p.Normalize().String(), tc.Period)
+	}
+}
+
+func TestPeriodBetween(t *testing.T) {
+	testCases := []struct {
+		From     string
+		To       string
+		Expected string
+	}{
+		{"2020-01-01", "2020-01-01", "P0D"},
+		{"2020-01-01", "2021-03-04", "P1Y2M3D"},
+		{"2020-01-15", "2020-02-14", "P30D"},
+		{"2020-01-15", "2020-02-15", "P1M"},
+		{"2019-01-31", "2019-03-01", "P29D"},
+		{"2019-01-31", "2019-03-03", "P1M"},
+		{"2020-02-29", "2021-02-28", "P11M30D"},
+		{"2020-02-29", "2021-03-01", "P1Y"},
+		{"2021-03-04", "2020-01-01", "P-1Y-2M-3D"},
+		{"2020-02-14", "2020-01-15", "P-30D"},
+		{"2019-03-01", "2019-01-31", "P-1M-1D"},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		from := MustParseDate(tc.From)
+		to := MustParseDate(tc.To)
+		p := PeriodBetween(from, to)
+		assert.Equal(tc.Expected, p.String(), tc.From+"/"+tc.To)
+		assert.True(to.Equal(from.AddPeriod(p)), tc.From+"/"+tc.To)
+	}
+}
+
+func TestPeriodBetweenExact(t *testing.T) {
+	start := Date(2019, time.December, 1)
+	for i := 0; i < 120; i++ {
+		a := start.AddDate(0, 0, i*3)
+		for j := 0; j < 800; j += 7 {
+			b := a.AddDate(0, 0, j-400)
+			p := PeriodBetween(a, b)
+			if !b.Equal(a.AddPeriod(p)) {
+				t.Fatalf("PeriodBetween(%s, %s) = %s is not exact", a, b, p)
+			}
+		}
+	}
+}
+
+func TestLocalDateTimeAddPeriod(t *testing.T) {
+	dt := DateTime(2020, time.January, 31, 10, 11, 12)
+	actual := dt.AddPeriod(MustParsePeriod("P1Y1M1D"))
+	assert.Equal(t, "2021-03-04T10:11:12", actual.String())
+}
+
+func TestPeriodMarshal(t *testing.T) {
+	assert := assert.New(t)
+	type testStruct struct {
+		Term  Period `json:"term"`
+		Cycle Period `json:"cycle"`
+	}
+
+	st := testStruct{
+		Term:  NewPeriod(2, 0, 0),
+		Cycle: NewPeriod(0, 1, 0),
+	}
+	b, err := json.Marshal(&st)
+	assert.NoError(err)
+	assert.Equal(`{"term":"P2Y","cycle":"P1M"}`, string(b))
+
+	var st2 testStruct
+	assert.NoError(json.Unmarshal(b, &st2))
+	assert.Equal(st, st2)
+
+	var p Period
+	assert.NoError(p.UnmarshalText([]byte("P1Y2M3D")))
+	b, err = p.MarshalText()
+	assert.NoError(err)
+	assert.Equal("P1Y2M3D", string(b))
+	assert.Error(p.UnmarshalText([]byte("1 year")))
+}
@@ -0,0 +1,475 @@
+package recur
+
+import (
+	"iter"
+	"slices"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+)
+
+// maxYear is the last year for which occurrences are calculated. It
+// prevents a rule that can never match, such as the 30th of February,
+// from looping forever.
+const maxYear = 9999
+
+// All returns the occurrences of the rule, starting at start, in ascending
+// order. Occurrences before start are not included, and are not counted
+// towards the rule's Count.
+//
+// Note that start is only an occurrence if it matches the rule. This differs
+// from an iCalendar event, where the start is always the first occurrence:
+// use a Set for that behavior.
+func (r Rule) All(start dt.LocalDateTime) iter.Seq[dt.LocalDateTime] {
+	return r.expand(start, nil)
+}
+
+// Between returns the occurrences of the rule, starting at start, that are
+// on or after from and before to.
+func (r Rule) Between(start, from, to dt.LocalDateTime) iter.Seq[dt.LocalDateTime] {
+	return between(r.All(start), from, to)
+}
+
+// Dates returns the dates of the occurrences of the rule, starting at start.
+// It is intended for rules with a frequency of Daily or longer.
+func (r Rule) Dates(start dt.LocalDate) iter.Seq[dt.LocalDate] {
+	return dates(r.All(start.At(dt.LocalTime{})))
+}
+
+// DatesBetween returns the dates of the occurrences of the rule, starting
+// at start, that are in the date range.
+func (r Rule) DatesBetween(start dt.LocalDate, dr dt.DateRange) iter.Seq[dt.LocalDate] {
+	return datesBetween(r.Dates(start), dr)
+}
+
+// between returns the values of seq that are on or after from and before to.
+// The values of seq must be in ascending order.
+func between(seq iter.Seq[dt.LocalDateTime], from, to dt.LocalDateTime) iter.Seq[dt.LocalDateTime] {
+	return func(yield func(dt.LocalDateTime) bool) {
+		for v := range seq {
+			if !v.Before(to) {
+				return
+			}
+			if !v.Before(from) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// dates returns the distinct dates of the values of seq, which must be
+// in ascending order.
+func dates(seq iter.Seq[dt.LocalDateTime]) iter.Seq[dt.LocalDate] {
+	return func(yield func(dt.LocalDate) bool) {
+		var prev dt.LocalDate
+		first := true
+		for v := range seq {
+			d := v.LocalDate()
+			if !first && d.Equal(prev) {
+				continue
+			}
+			prev, first = d, false
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// datesBetween returns the values of seq that are in the date range.
+// The values of seq must be in ascending order.
+func datesBetween(seq iter.Seq[dt.LocalDate], dr dt.DateRange) iter.Seq[dt.LocalDate] {
+	return func(yield func(dt.LocalDate) bool) {
+		for d := range seq {
+			if !d.Before(dr.End()) {
+				return
+			}
+			if dr.Contains(d) && !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// clock is a time of day, in seconds since midnight.
+type clock int
+
+func (c clock) at(d dt.LocalDate) dt.LocalDateTime {
+	return dt.DateTime(d.Year(), d.Month(), d.Day(), int(c)/3600, int(c)/60%60, int(c)%60)
+}
+
+// expander contains the rule parts used for expansion, after defaults
+// have been applied from the start date-time.
+type expander struct {
+	Rule
+	start     dt.LocalDateTime
+	interval  int
+	ordinals  bool // BYDAY ordinals apply
+	monthly   bool // BYDAY ordinals are relative to the month
+	byDayBits uint8
+	clocks    []clock // times of day for daily and longer frequencies
+	never     bool    // the rule has no occurrences
+}
+
+// expand returns the occurrences of the rule from start. If loc is not nil,
+// an UNTIL in UTC is converted to the local time in loc.
+func (r Rule) expand(start dt.LocalDateTime, loc *time.Location) iter.Seq[dt.LocalDateTime] {
+	e := newExpander(r, start, loc)
+	return e.all
+}
+
+func newExpander(r Rule, start dt.LocalDateTime, loc *time.Location) *expander {
+	e := &expander{
+		Rule:     r,
+		start:    start,
+		interval: r.interval(),
+	}
+	if r.UntilUTC && loc != nil {
+		year, month, day, hour, minute, second := r.Until.DateTime()
+		utc := time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+		e.Until = dt.LocalDateTimeOf(utc.In(loc))
+	}
+
+	// defaults from RFC 5545 section 3.3.10, where missing parts are
+	// taken from the start date-time
+	if len(r.ByWeekNo) == 0 && len(r.ByYearDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		switch r.Freq {
+		case Yearly:
+			if len(r.ByMonth) == 0 {
+				e.ByMonth = []time.Month{start.Month()}
+			}
+			e.ByMonthDay = []int{start.Day()}
+		case Monthly:
+			e.ByMonthDay = []int{start.Day()}
+		case Weekly:
+			e.ByDay = []WeekdayNum{{Weekday: start.Weekday()}}
+		}
+	}
+	if r.Freq > Hourly && len(r.ByHour) == 0 {
+		e.ByHour = []int{start.Hour()}
+	}
+	if r.Freq > Minutely && len(r.ByMinute) == 0 {
+		e.ByMinute = []int{start.Minute()}
+	}
+	if r.Freq > Secondly && len(r.BySecond) == 0 {
+		e.BySecond = []int{start.Second()}
+	}
+
+	// a leap second cannot be represented by a local date-time, so a rule
+	// whose only BYSECOND value is 60 never occurs
+	e.BySecond = slices.DeleteFunc(slices.Clone(e.BySecond), func(s int) bool { return s > 59 })
+	if len(e.BySecond) == 0 && len(r.BySecond) > 0 {
+		e.never = true
+	}
+
+	e.ordinals = (r.Freq == Monthly || r.Freq == Yearly) && len(r.ByWeekNo) == 0
+	e.monthly = r.Freq == Monthly || len(r.ByMonth) > 0
+	for _, wn := range e.ByDay {
+		if wn.N == 0 || !e.ordinals {
+			e.byDayBits |= 1 << (wn.Weekday % 7)
+		}
+	}
+	if r.Freq >= Daily {
+		e.clocks = clocks(e.ByHour, e.ByMinute, e.BySecond)
+	}
+	return e
+}
+
+// clocks returns the times of day for all combinations of hours,
+// minutes and seconds, in ascending order.
+func clocks(hours, minutes, seconds []int) []clock {
+	var list []clock
+	for _, h := range hours {
+		for _, m := range minutes {
+			for _, s := range seconds {
+				if h >= 0 && h < 24 && m >= 0 && m < 60 && s >= 0 && s < 60 {
+					list = append(list, clock(h*3600+m*60+s))
+				}
+			}
+		}
+	}
+	slices.Sort(list)
+	return slices.Compact(list)
+}
+
+// all yields the occurrences of the rule.
+func (e *expander) all(yield func(dt.LocalDateTime) bool) {
+	if e.never || e.Freq < Secondly || e.Freq > Yearly {
+		return
+	}
+	count := 0
+	for n := 0; ; {
+		occurrences, next := e.period(n)
+		if next < 0 {
+			return
+		}
+		for _, occ := range occurrences {
+			if occ.Before(e.start) {
+				continue
+			}
+			if e.isAfterUntil(occ) {
+				return
+			}
+			if !yield(occ) {
+				return
+			}
+			count++
+			if e.Count > 0 && count >= e.Count {
+				return
+			}
+		}
+		n = next
+	}
+}
+
+func (e *expander) isAfterUntil(occ dt.LocalDateTime) bool {
+	if e.Until.IsZero() {
+		return false
+	}
+	if e.untilDate {
+		return occ.LocalDate().After(e.Until.LocalDate())
+	}
+	return occ.After(e.Until)
+}
+
+// period returns the occurrences in the nth period after the start, in
+// ascending order, and the number of the next period that could contain
+// an occurrence. If there are no more periods, next is negative.
+func (e *expander) period(n int) (occurrences []dt.LocalDateTime, next int) {
+	var days []dt.LocalDate
+	clocks := e.clocks
+	next = n + 1
+	startDate := e.start.LocalDate()
+
+	switch e.Freq {
+	case Yearly:
+		year := e.start.Year() + n*e.interval
+		if year > maxYear {
+			return nil, -1
+		}
+		days = e.yearDays(year)
+	case Monthly:
+		first := dt.Date(e.start.Year(), e.start.Month()+time.Month(n*e.interval), 1)
+		if first.Year() > maxYear {
+			return nil, -1
+		}
+		days = e.filterDays(first, first.AddDate(0, 1, 0))
+	case Weekly:
+		first := weekStart(startDate, e.WeekStart).AddDate(0, 0, 7*n*e.interval)
+		if first.Year() > maxYear {
+			return nil, -1
+		}
+		days = e.filterDays(first, first.AddDate(0, 0, 7))
+	case Daily:
+		d := startDate.AddDate(0, 0, n*e.interval)
+		if d.Year() > maxYear {
+			return nil, -1
+		}
+		days = e.filterDays(d, d.AddDate(0, 0, 1))
+	default:
+		return e.subDailyPeriod(n)
+	}
+
+	for _, d := range days {
+		for _, c := range clocks {
+			occurrences = append(occurrences, c.at(d))
+		}
+	}
+	return e.setPos(occurrences), next
+}
+
+// subDailyPeriod returns the occurrences for a rule with an hourly, minutely or
+// secondly frequency. When the period does not match the rule, the next period
+// is the first that could match, so that rules such as "every hour on Mondays"
+// do not need to examine every hour.
+func (e *expander) subDailyPeriod(n int) (occurrences []dt.LocalDateTime, next int) {
+	var unit int64
+	var base dt.LocalDateTime
+	year, month, day, hour, minute, second := e.start.DateTime()
+	switch e.Freq {
+	case Hourly:
+		unit, base = 3600, dt.DateTime(year, month, day, hour, 0, 0)
+	case Minutely:
+		unit, base = 60, dt.DateTime(year, month, day, hour, minute, 0)
+	default:
+		unit, base = 1, dt.DateTime(year, month, day, hour, minute, second)
+	}
+	step := unit * int64(e.interval)
+	p := dt.LocalDateTimeOf(time.Unix(base.Unix()+int64(n)*step, 0).UTC())
+	if p.Year() > maxYear {
+		return nil, -1
+	}
+
+	// skipTo returns the number of the first period at or after t
+	skipTo := func(t dt.LocalDateTime) int {
+		elapsed := t.Unix() - base.Unix()
+		return int((elapsed + step - 1) / step)
+	}
+
+	d := p.LocalDate()
+	year, month, day, hour, minute, second = p.DateTime()
+	if len(e.filterDays(d, d.AddDate(0, 0, 1))) == 0 {
+		return nil, skipTo(dt.DateTime(year, month, day+1, 0, 0, 0))
+	}
+	if len(e.ByHour) > 0 && !slices.Contains(e.ByHour, hour) {
+		return nil, skipTo(dt.DateTime(year, month, day, hour+1, 0, 0))
+	}
+	if e.Freq < Hourly && len(e.ByMinute) > 0 && !slices.Contains(e.ByMinute, minute) {
+		return nil, skipTo(dt.DateTime(year, month, day, hour, minute+1, 0))
+	}
+
+	var hours, minutes, seconds []int
+	switch e.Freq {
+	case Hourly:
+		hours, minutes, seconds = []int{hour}, e.ByMinute, e.BySecond
+	case Minutely:
+		hours, minutes, seconds = []int{hour}, []int{minute}, e.BySecond
+	default:
+		if len(e.BySecond) > 0 && !slices.Contains(e.BySecond, second) {
+			return nil, n + 1
+		}
+		hours, minutes, seconds = []int{hour}, []int{minute}, []int{second}
+	}
+	for _, c := range clocks(hours, minutes, seconds) {
+		occurrences = append(occurrences, c.at(d))
+	}
+	return e.setPos(occurrences), n + 1
+}
+
+// setPos applies BYSETPOS to the occurrences in a period.
+func (e *expander) setPos(occurrences []dt.LocalDateTime) []dt.LocalDateTime {
+	if len(e.BySetPos) == 0 || len(occurrences) == 0 {
+		return occurrences
+	}
+	var selected []dt.LocalDateTime
+	for i, occ := range occurrences {
+		if matchesOrdinal(e.BySetPos, i+1, len(occurrences)) {
+			selected = append(selected, occ)
+		}
+	}
+	return selected
+}
+
+// yearDays returns the days in the year that match the rule.
+func (e *expander) yearDays(year int) []dt.LocalDate {
+	if len(e.ByWeekNo) == 0 {
+		if len(e.ByMonth) == 0 {
+			first := dt.Date(year, time.January, 1)
+			return e.filterDays(first, first.AddDate(1, 0, 0))
+		}
+		var days []dt.LocalDate
+		for month := time.January; month <= time.December; month++ {
+			if slices.Contains(e.ByMonth, month) {
+				first := dt.Date(year, month, 1)
+				days = append(days, e.filterDays(first, first.AddDate(0, 1, 0))...)
+			}
+		}
+		return days
+	}
+
+	// The weeks of the year can include days at the end of the previous
+	// year, and at the start of the next year.
+	week1 := weekOne(year, e.WeekStart)
+	weeks := int(weekOne(year+1, e.WeekStart).Sub(week1) / (7 * 24 * time.Hour))
+	var days []dt.LocalDate
+	for week := 1; week <= weeks; week++ {
+		if matchesOrdinal(e.ByWeekNo, week, weeks) {
+			first := week1.AddDate(0, 0, 7*(week-1))
+			days = append(days, e.filterDays(first, first.AddDate(0, 0, 7))...)
+		}
+	}
+	return days
+}
+
+// filterDays returns the days in the range [first, end) that match the
+// BYMONTH, BYYEARDAY, BYMONTHDAY and BYDAY parts of the rule.
+func (e *expander) filterDays(first, end dt.LocalDate) []dt.LocalDate {
+	var days []dt.LocalDate
+	for d := first; d.Before(end); d = d.AddDate(0, 0, 1) {
+		if e.matchDay(d) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+func (e *expander) matchDay(d dt.LocalDate) bool {
+	year, month, day := d.Date()
+	if len(e.ByMonth) > 0 && !slices.Contains(e.ByMonth, month) {
+		return false
+	}
+	if len(e.ByYearDay) > 0 && !matchesOrdinal(e.ByYearDay, d.YearDay(), daysInYear(year)) {
+		return false
+	}
+	if len(e.ByMonthDay) > 0 && !matchesOrdinal(e.ByMonthDay, day, daysInMonth(year, month)) {
+		return false
+	}
+	if len(e.ByDay) > 0 && !e.matchWeekday(d) {
+		return false
+	}
+	return true
+}
+
+// matchWeekday reports whether d matches the BYDAY part of the rule.
+func (e *expander) matchWeekday(d dt.LocalDate) bool {
+	weekday := d.Weekday()
+	if e.byDayBits&(1<<weekday) != 0 {
+		return true
+	}
+	if !e.ordinals {
+		return false
+	}
+
+	// the position of d within the month or year, and the number of days
+	// in the month or year
+	pos, length := d.YearDay(), daysInYear(d.Year())
+	if e.monthly {
+		pos, length = d.Day(), daysInMonth(d.Year(), d.Month())
+	}
+	nth := (pos-1)/7 + 1
+	nthLast := -((length-pos)/7 + 1)
+	for _, wn := range e.ByDay {
+		if wn.Weekday == weekday && (wn.N == nth || wn.N == nthLast) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesOrdinal reports whether the value at position pos (starting at 1)
+// of a list of length values matches any of the ordinals in list. Negative
+// ordinals count from the end of the list, so -1 is the last value.
+func matchesOrdinal(list []int, pos, length int) bool {
+	for _, n := range list {
+		if n == pos || (n < 0 && length+1+n == pos) {
+			return true
+		}
+	}
+	return false
+}
+
+// weekStart returns the first day of the week containing d, where weeks
+// start on wkst.
+func weekStart(d dt.LocalDate, wkst time.Weekday) dt.LocalDate {
+	offset := (int(d.Weekday()) - int(wkst) + 7) % 7
+	return d.AddDate(0, 0, -offset)
+}
+
+// weekOne returns the first day of week number 1 of the year, which is the
+// first week containing at least four days of the year.
+func weekOne(year int, wkst time.Weekday) dt.LocalDate {
+	jan1 := dt.Date(year, time.January, 1)
+	start := weekStart(jan1, wkst)
+	if jan1.Sub(start) > 3*24*time.Hour {
+		start = start.AddDate(0, 0, 7)
+	}
+	return start
+}
+
+func daysInYear(year int) int {
+	return dt.Date(year, time.December, 31).YearDay()
+}
+
+func daysInMonth(year int, month time.Month) int {
+	return dt.Date(year, month+1, 0).Day()
+}
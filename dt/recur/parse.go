@@ -0,0 +1,327 @@
+package recur
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+)
+
+var (
+	errMissingFreq    = errors.New("recurrence rule has no FREQ")
+	errCountAndUntil  = errors.New("recurrence rule has both COUNT and UNTIL")
+	errInvalidDateVal = errors.New("invalid iCalendar date-time format")
+)
+
+// ParseRule parses a recurrence rule in the format of the RRULE property of
+// RFC 5545, such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU". The "RRULE:" prefix
+// is optional, and names and values are not case sensitive.
+func ParseRule(s string) (Rule, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 6 && strings.EqualFold(s[:6], "RRULE:") {
+		s = s[6:]
+	}
+	r := Rule{WeekStart: time.Monday}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+		name = strings.ToUpper(strings.TrimSpace(name))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if seen[name] {
+			return Rule{}, fmt.Errorf("recurrence rule has more than one %s", name)
+		}
+		seen[name] = true
+		if err := r.parsePart(name, value); err != nil {
+			return Rule{}, err
+		}
+	}
+	if err := r.validate(); err != nil {
+		return Rule{}, err
+	}
+	return r, nil
+}
+
+// MustParseRule is similar to ParseRule, but instead of returning an error it will
+// panic if s is not a valid recurrence rule.
+func MustParseRule(s string) Rule {
+	r, err := ParseRule(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
+}
+
+func (r *Rule) parsePart(name, value string) (err error) {
+	switch name {
+	case "FREQ":
+		r.Freq = 0
+		for f := Secondly; f <= Yearly; f++ {
+			if value == frequencyNames[f] {
+				r.Freq = f
+			}
+		}
+		if r.Freq == 0 {
+			return fmt.Errorf("invalid FREQ value %q", value)
+		}
+	case "INTERVAL":
+		r.Interval, err = parsePositive(name, value)
+	case "COUNT":
+		r.Count, err = parsePositive(name, value)
+	case "UNTIL":
+		var v dateTimeValue
+		v, err = parseDateTimeValue(value)
+		if err != nil {
+			return fmt.Errorf("invalid UNTIL value %q", value)
+		}
+		r.Until, r.UntilUTC, r.untilDate = v.dt, v.utc, v.date
+	case "BYSECOND":
+		r.BySecond, err = parseInts(name, value, 0, 60, false)
+	case "BYMINUTE":
+		r.ByMinute, err = parseInts(name, value, 0, 59, false)
+	case "BYHOUR":
+		r.ByHour, err = parseInts(name, value, 0, 23, false)
+	case "BYDAY":
+		r.ByDay, err = parseWeekdayNums(value)
+	case "BYMONTHDAY":
+		r.ByMonthDay, err = parseInts(name, value, 1, 31, true)
+	case "BYYEARDAY":
+		r.ByYearDay, err = parseInts(name, value, 1, 366, true)
+	case "BYWEEKNO":
+		r.ByWeekNo, err = parseInts(name, value, 1, 53, true)
+	case "BYMONTH":
+		var months []int
+		months, err = parseInts(name, value, 1, 12, false)
+		for _, m := range months {
+			r.ByMonth = append(r.ByMonth, time.Month(m))
+		}
+	case "BYSETPOS":
+		r.BySetPos, err = parseInts(name, value, 1, 366, true)
+	case "WKST":
+		weekday, ok := parseWeekday(value)
+		if !ok {
+			return fmt.Errorf("invalid WKST value %q", value)
+		}
+		r.WeekStart = weekday
+	default:
+		// extension parts are permitted by RFC 5545 and ignored
+		if !strings.HasPrefix(name, "X-") {
+			return fmt.Errorf("unknown recurrence rule part %q", name)
+		}
+	}
+	return err
+}
+
+// validate checks the combinations of parts that RFC 5545 does not permit.
+func (r Rule) validate() error {
+	if r.Freq == 0 {
+		return errMissingFreq
+	}
+	if r.Count != 0 && !r.Until.IsZero() {
+		return errCountAndUntil
+	}
+	if len(r.ByWeekNo) > 0 && r.Freq != Yearly {
+		return errors.New("BYWEEKNO is only valid with FREQ=YEARLY")
+	}
+	if len(r.ByYearDay) > 0 && (r.Freq == Daily || r.Freq == Weekly || r.Freq == Monthly) {
+		return fmt.Errorf("BYYEARDAY is not valid with FREQ=%s", r.Freq)
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq == Weekly {
+		return errors.New("BYMONTHDAY is not valid with FREQ=WEEKLY")
+	}
+	for _, wn := range r.ByDay {
+		if wn.N != 0 && (r.Freq != Monthly && r.Freq != Yearly || len(r.ByWeekNo) > 0) {
+			return fmt.Errorf("BYDAY value %s is not valid with FREQ=%s", wn, r.Freq)
+		}
+	}
+	return nil
+}
+
+func parsePositive(name, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid %s value %q", name, value)
+	}
+	return n, nil
+}
+
+// parseInts parses a comma-separated list of integers in the range [min, max].
+// If signed is true, values in the range [-max, -min] are also accepted.
+func parseInts(name, value string, min, max int, signed bool) ([]int, error) {
+	var list []int
+	for _, s := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(s)
+		abs := n
+		if signed && n < 0 {
+			abs = -n
+		}
+		if err != nil || abs < min || abs > max || (!signed && s[0] == '+') {
+			return nil, fmt.Errorf("invalid %s value %q", name, s)
+		}
+		list = append(list, n)
+	}
+	return list, nil
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for i, name := range weekdayNames {
+		if s == name {
+			return time.Weekday(i), true
+		}
+	}
+	return 0, false
+}
+
+func parseWeekdayNums(value string) ([]WeekdayNum, error) {
+	var list []WeekdayNum
+	for _, s := range strings.Split(value, ",") {
+		if len(s) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY value %q", s)
+		}
+		weekday, ok := parseWeekday(s[len(s)-2:])
+		if !ok {
+			return nil, fmt.Errorf("invalid BYDAY value %q", s)
+		}
+		wn := WeekdayNum{Weekday: weekday}
+		if ordinal := s[:len(s)-2]; ordinal != "" {
+			n, err := strconv.Atoi(ordinal)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("invalid BYDAY value %q", s)
+			}
+			wn.N = n
+		}
+		list = append(list, wn)
+	}
+	return list, nil
+}
+
+// String returns the rule in the format of the RRULE property of RFC 5545,
+// without the "RRULE:" prefix.
+func (r Rule) String() string {
+	return r.format(false)
+}
+
+// format returns the rule in RRULE format. If dateOnly is true, UNTIL is
+// formatted as a date, which is required when the start of the recurrence
+// set is a date.
+func (r Rule) format(dateOnly bool) string {
+	var sb strings.Builder
+	sb.WriteString("FREQ=")
+	sb.WriteString(r.Freq.String())
+	if !r.Until.IsZero() {
+		sb.WriteString(";UNTIL=")
+		v := dateTimeValue{dt: r.Until, utc: r.UntilUTC, date: r.untilDate || dateOnly}
+		sb.WriteString(v.String())
+	}
+	if r.Count > 0 {
+		sb.WriteString(";COUNT=")
+		sb.WriteString(strconv.Itoa(r.Count))
+	}
+	if r.Interval > 1 {
+		sb.WriteString(";INTERVAL=")
+		sb.WriteString(strconv.Itoa(r.Interval))
+	}
+	writeInts(&sb, "BYSECOND", r.BySecond)
+	writeInts(&sb, "BYMINUTE", r.ByMinute)
+	writeInts(&sb, "BYHOUR", r.ByHour)
+	if len(r.ByDay) > 0 {
+		sb.WriteString(";BYDAY=")
+		for i, wn := range r.ByDay {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(wn.String())
+		}
+	}
+	writeInts(&sb, "BYMONTHDAY", r.ByMonthDay)
+	writeInts(&sb, "BYYEARDAY", r.ByYearDay)
+	writeInts(&sb, "BYWEEKNO", r.ByWeekNo)
+	if len(r.ByMonth) > 0 {
+		months := make([]int, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = int(m)
+		}
+		writeInts(&sb, "BYMONTH", months)
+	}
+	writeInts(&sb, "BYSETPOS", r.BySetPos)
+	if r.WeekStart != time.Monday {
+		sb.WriteString(";WKST=")
+		sb.WriteString(weekdayNames[r.WeekStart%7])
+	}
+	return sb.String()
+}
+
+func writeInts(sb *strings.Builder, name string, list []int) {
+	if len(list) == 0 {
+		return
+	}
+	sb.WriteByte(';')
+	sb.WriteString(name)
+	sb.WriteByte('=')
+	for i, n := range list {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.Itoa(n))
+	}
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r Rule) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *Rule) UnmarshalText(data []byte) (err error) {
+	*r, err = ParseRule(string(data))
+	return err
+}
+
+// dateTimeValue is a DATE or DATE-TIME value in iCalendar format.
+type dateTimeValue struct {
+	dt   dt.LocalDateTime
+	utc  bool // value has a "Z" suffix
+	date bool // value is a DATE
+}
+
+// parseDateTimeValue parses a value in iCalendar DATE format (yyyymmdd)
+// or DATE-TIME format (yyyymmddThhmmss, with an optional "Z" suffix).
+func parseDateTimeValue(s string) (dateTimeValue, error) {
+	var v dateTimeValue
+	layout := "20060102T150405"
+	switch {
+	case len(s) == 8:
+		layout = "20060102"
+		v.date = true
+	case len(s) == 16 && (s[15] == 'Z' || s[15] == 'z'):
+		s = s[:15]
+		v.utc = true
+	}
+	t, err := time.Parse(layout, strings.ToUpper(s))
+	if err != nil {
+		return dateTimeValue{}, errInvalidDateVal
+	}
+	v.dt = dt.LocalDateTimeOf(t)
+	return v, nil
+}
+
+// String returns the value in iCalendar format.
+func (v dateTimeValue) String() string {
+	year, month, day, hour, minute, second := v.dt.DateTime()
+	if v.date {
+		return fmt.Sprintf("%04d%02d%02d", year, month, day)
+	}
+	s := fmt.Sprintf("%04d%02d%02dT%02d%02d%02d", year, month, day, hour, minute, second)
+	if v.utc {
+		s += "Z"
+	}
+	return s
+}
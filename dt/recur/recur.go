@@ -0,0 +1,133 @@
+// Package recur expands recurrence rules, as specified by the RRULE, RDATE
+// and EXDATE properties of RFC 5545 (iCalendar), into sequences of local
+// date-times or local dates.
+//
+// A Rule is a single recurrence rule, such as "every second Tuesday". A Set
+// combines a start date-time with any number of rules, additional dates and
+// excluded dates, and corresponds to the recurrence properties of an
+// iCalendar event.
+//
+// Occurrences are calculated lazily, so rules without a COUNT or UNTIL
+// can be expanded over a window without calculating every occurrence.
+package recur
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+)
+
+// Frequency is the FREQ of a recurrence rule, which determines the period
+// over which the rule repeats.
+type Frequency int
+
+// Frequencies of recurrence rules.
+const (
+	Secondly Frequency = iota + 1
+	Minutely
+	Hourly
+	Daily
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = [...]string{
+	Secondly: "SECONDLY",
+	Minutely: "MINUTELY",
+	Hourly:   "HOURLY",
+	Daily:    "DAILY",
+	Weekly:   "WEEKLY",
+	Monthly:  "MONTHLY",
+	Yearly:   "YEARLY",
+}
+
+// String returns the name of the frequency as it appears in an RRULE,
+// such as "WEEKLY".
+func (f Frequency) String() string {
+	if f >= Secondly && f <= Yearly {
+		return frequencyNames[f]
+	}
+	return "Frequency(" + strconv.Itoa(int(f)) + ")"
+}
+
+// weekdayNames are the two-letter abbreviations for days of the week
+// used in an RRULE, indexed by time.Weekday.
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// WeekdayNum is a day of the week in the BYDAY part of a recurrence rule,
+// optionally with an ordinal. For example, the first Monday of the month
+// is {N: 1, Weekday: time.Monday}, and the last Friday is {N: -1, Weekday: time.Friday}.
+// If N is zero, the WeekdayNum is every occurrence of the day within the period.
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// String returns the value in RRULE format, such as "MO" or "-1FR".
+func (wn WeekdayNum) String() string {
+	name := weekdayNames[wn.Weekday%7]
+	if wn.N == 0 {
+		return name
+	}
+	return strconv.Itoa(wn.N) + name
+}
+
+// Rule is a recurrence rule, as specified by the RRULE property of RFC 5545.
+// The zero value for each BY part means that the part is not specified.
+//
+// Values in the BY parts are not validated when expanding a rule, so a value
+// that is out of range, such as an hour of 25, never matches. ParseRule
+// returns an error for values that are out of range.
+type Rule struct {
+	Freq Frequency
+
+	// Interval is the number of periods between each repetition, so an
+	// Interval of 2 with a Weekly frequency means every second week. An
+	// Interval of zero is treated as 1.
+	Interval int
+
+	// Count is the number of occurrences of the rule. Zero means that the
+	// number of occurrences is not limited by a count.
+	Count int
+
+	// Until is the last date-time that can be an occurrence of the rule.
+	// Zero means that the occurrences are not limited by a date-time.
+	Until dt.LocalDateTime
+
+	// UntilUTC is true if Until is in UTC. When the rule is expanded as part
+	// of a Set with a location, Until is converted to the local time in that
+	// location. Otherwise Until is compared with occurrences as if it were
+	// a local date-time.
+	UntilUTC bool
+
+	BySecond   []int
+	ByMinute   []int
+	ByHour     []int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByYearDay  []int
+	ByWeekNo   []int
+	ByMonth    []time.Month
+	BySetPos   []int
+
+	// WeekStart is the day on which the week starts, which affects weekly
+	// rules with an interval greater than one, and the BYWEEKNO part. The
+	// default in RFC 5545 is Monday, so ParseRule sets WeekStart to
+	// time.Monday if WKST is not specified. Note that the zero value of
+	// time.Weekday is Sunday.
+	WeekStart time.Weekday
+
+	// untilDate is true if Until was specified as a date, which means
+	// it is compared with the date of each occurrence.
+	untilDate bool
+}
+
+// interval returns the interval, treating zero as one.
+func (r Rule) interval() int {
+	if r.Interval < 1 {
+		return 1
+	}
+	return r.Interval
+}
@@ -0,0 +1,305 @@
+package recur
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRule(t *testing.T) {
+	testCases := []struct {
+		Text     string
+		Valid    bool
+		Expected string
+	}{
+		{Text: "FREQ=DAILY;COUNT=10", Valid: true, Expected: "FREQ=DAILY;COUNT=10"},
+		{Text: "RRULE:freq=weekly;interval=2;wkst=su;byday=tu,th", Valid: true, Expected: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;WKST=SU"},
+		{Text: "FREQ=MONTHLY;BYDAY=1SU,-1SU;INTERVAL=1", Valid: true, Expected: "FREQ=MONTHLY;BYDAY=1SU,-1SU"},
+		{Text: "FREQ=YEARLY;UNTIL=19971224T000000Z;BYMONTH=1", Valid: true, Expected: "FREQ=YEARLY;UNTIL=19971224T000000Z;BYMONTH=1"},
+		{Text: "FREQ=YEARLY;UNTIL=19971224;BYYEARDAY=-1,100", Valid: true, Expected: "FREQ=YEARLY;UNTIL=19971224;BYYEARDAY=-1,100"},
+		{Text: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2", Valid: true, Expected: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2"},
+		{Text: "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO;WKST=MO", Valid: true, Expected: "FREQ=YEARLY;BYDAY=MO;BYWEEKNO=20"},
+		{Text: "FREQ=DAILY;BYHOUR=9,10;BYMINUTE=0,30;BYSECOND=0", Valid: true, Expected: "FREQ=DAILY;BYSECOND=0;BYMINUTE=0,30;BYHOUR=9,10"},
+		{Text: "FREQ=DAILY;X-NAME=value", Valid: true, Expected: "FREQ=DAILY"},
+		{Text: "", Valid: false},
+		{Text: "COUNT=10", Valid: false},
+		{Text: "FREQ=FORTNIGHTLY", Valid: false},
+		{Text: "FREQ=DAILY;FREQ=WEEKLY", Valid: false},
+		{Text: "FREQ=DAILY;COUNT=10;UNTIL=19971224", Valid: false},
+		{Text: "FREQ=DAILY;COUNT=0", Valid: false},
+		{Text: "FREQ=DAILY;INTERVAL=-1", Valid: false},
+		{Text: "FREQ=DAILY;BYHOUR=24", Valid: false},
+		{Text: "FREQ=DAILY;BYHOUR=-1", Valid: false},
+		{Text: "FREQ=MONTHLY;BYMONTHDAY=0", Valid: false},
+		{Text: "FREQ=MONTHLY;BYMONTHDAY=-32", Valid: false},
+		{Text: "FREQ=YEARLY;BYMONTH=13", Valid: false},
+		{Text: "FREQ=YEARLY;BYDAY=54MO", Valid: false},
+		{Text: "FREQ=YEARLY;BYDAY=XX", Valid: false},
+		{Text: "FREQ=WEEKLY;BYDAY=1MO", Valid: false},
+		{Text: "FREQ=YEARLY;BYWEEKNO=1;BYDAY=1MO", Valid: false},
+		{Text: "FREQ=MONTHLY;BYWEEKNO=1", Valid: false},
+		{Text: "FREQ=WEEKLY;BYMONTHDAY=1", Valid: false},
+		{Text: "FREQ=MONTHLY;BYYEARDAY=1", Valid: false},
+		{Text: "FREQ=DAILY;UNTIL=1997-12-24", Valid: false},
+		{Text: "FREQ=DAILY;WKST=XX", Valid: false},
+		{Text: "FREQ=DAILY;BYEASTER=1", Valid: false},
+		{Text: "FREQ=DAILY;COUNT", Valid: false},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		r, err := ParseRule(tc.Text)
+		if tc.Valid {
+			if assert.NoError(err, tc.Text) {
+				assert.Equal(tc.Expected, r.String(), tc.Text)
+			}
+		} else {
+			assert.Error(err, tc.Text)
+		}
+	}
+
+	assert.Panics(func() {
+		MustParseRule("FREQ=NEVER")
+	})
+}
+
+func TestRuleMarshal(t *testing.T) {
+	assert := assert.New(t)
+	type testStruct struct {
+		Repeat Rule `json:"repeat"`
+	}
+	st := testStruct{Repeat: MustParseRule("FREQ=WEEKLY;INTERVAL=2;BYDAY=TU")}
+	b, err := json.Marshal(&st)
+	assert.NoError(err)
+	assert.Equal(`{"repeat":"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU"}`, string(b))
+
+	var st2 testStruct
+	assert.NoError(json.Unmarshal(b, &st2))
+	assert.Equal(st.Repeat, st2.Repeat)
+	assert.Error(json.Unmarshal([]byte(`{"repeat":"FREQ=NEVER"}`), &st2))
+}
+
+func TestRuleAll(t *testing.T) {
+	assert := assert.New(t)
+
+	// every second Tuesday, constructed without parsing
+	r := Rule{
+		Freq:      Weekly,
+		Interval:  2,
+		ByDay:     []WeekdayNum{{Weekday: time.Tuesday}},
+		WeekStart: time.Monday,
+	}
+	start := dt.DateTime(2024, time.January, 1, 9, 30, 0)
+	var actual []string
+	for occ := range r.All(start) {
+		actual = append(actual, occ.String())
+		if len(actual) == 3 {
+			break
+		}
+	}
+	assert.Equal([]string{"2024-01-02T09:30:00", "2024-01-16T09:30:00", "2024-01-30T09:30:00"}, actual)
+
+	// occurrences before the window are skipped but still counted
+	r = MustParseRule("FREQ=DAILY;COUNT=5")
+	actual = nil
+	for occ := range r.Between(start, dt.DateTime(2024, time.January, 3, 0, 0, 0), dt.DateTime(2024, time.February, 1, 0, 0, 0)) {
+		actual = append(actual, occ.String())
+	}
+	assert.Equal([]string{"2024-01-03T09:30:00", "2024-01-04T09:30:00", "2024-01-05T09:30:00"}, actual)
+
+	// the last day of each month
+	r = MustParseRule("FREQ=MONTHLY;BYMONTHDAY=-1")
+	var dates []string
+	for d := range r.DatesBetween(dt.Date(2024, time.January, 15), dt.MustParseDateRange("2024-01-01/2024-05-01")) {
+		dates = append(dates, d.String())
+	}
+	assert.Equal([]string{"2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30"}, dates)
+
+	// a rule that can never occur terminates
+	r = MustParseRule("FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30")
+	for range r.All(start) {
+		t.Error("February 30 should not occur")
+	}
+
+	// the zero rule has no occurrences
+	for range (Rule{}).All(start) {
+		t.Error("zero rule should not have occurrences")
+	}
+}
+
+func TestRuleSubDaily(t *testing.T) {
+	assert := assert.New(t)
+	start := dt.DateTime(2024, time.March, 1, 23, 59, 58)
+
+	r := MustParseRule("FREQ=SECONDLY;COUNT=4")
+	var actual []string
+	for occ := range r.All(start) {
+		actual = append(actual, occ.String())
+	}
+	assert.Equal([]string{"2024-03-01T23:59:58", "2024-03-01T23:59:59", "2024-03-02T00:00:00", "2024-03-02T00:00:01"}, actual)
+
+	// hourly on weekdays in business hours skips nights and weekends
+	r = MustParseRule("FREQ=HOURLY;INTERVAL=4;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9,13,17")
+	actual = nil
+	for occ := range r.All(dt.DateTime(2024, time.March, 1, 9, 0, 0)) {
+		actual = append(actual, occ.String())
+		if len(actual) == 5 {
+			break
+		}
+	}
+	assert.Equal([]string{
+		"2024-03-01T09:00:00", "2024-03-01T13:00:00", "2024-03-01T17:00:00",
+		"2024-03-04T09:00:00", "2024-03-04T13:00:00",
+	}, actual)
+
+	// a day is a whole number of 45 minute periods, so 10:30 is the only match
+	r = MustParseRule("FREQ=MINUTELY;INTERVAL=45;BYHOUR=10;BYSECOND=0,30;COUNT=5")
+	actual = nil
+	for occ := range r.All(dt.DateTime(2024, time.March, 1, 9, 0, 0)) {
+		actual = append(actual, occ.String())
+	}
+	assert.Equal([]string{
+		"2024-03-01T10:30:00", "2024-03-01T10:30:30",
+		"2024-03-02T10:30:00", "2024-03-02T10:30:30", "2024-03-03T10:30:00",
+	}, actual)
+}
+
+func TestSet(t *testing.T) {
+	assert := assert.New(t)
+	s := MustParseSet(`
+		DTSTART;VALUE=DATE:20240101
+		RRULE:FREQ=MONTHLY;BYDAY=1MO;UNTIL=20240630
+		RDATE;VALUE=DATE:20240315,20240102
+		EXDATE;VALUE=DATE:20240205
+	`)
+	assert.True(s.DateOnly)
+	var actual []string
+	for d := range s.Dates() {
+		actual = append(actual, d.String())
+	}
+	assert.Equal([]string{
+		"2024-01-01", "2024-01-02", "2024-03-04", "2024-03-15", "2024-04-01", "2024-05-06", "2024-06-03",
+	}, actual)
+	assert.Equal("DTSTART;VALUE=DATE:20240101\n"+
+		"RRULE:FREQ=MONTHLY;UNTIL=20240630;BYDAY=1MO\n"+
+		"RDATE;VALUE=DATE:20240315,20240102\n"+
+		"EXDATE;VALUE=DATE:20240205", s.String())
+
+	actual = nil
+	for d := range s.DatesBetween(dt.MustParseDateRange("2024-03-01/2024-05-01")) {
+		actual = append(actual, d.String())
+	}
+	assert.Equal([]string{"2024-03-04", "2024-03-15", "2024-04-01"}, actual)
+
+	// multiple rules are merged without duplicates
+	s = Set{
+		Start: dt.DateTime(2024, time.January, 1, 9, 0, 0),
+		Rules: []Rule{
+			MustParseRule("FREQ=WEEKLY;BYDAY=MO;COUNT=3"),
+			MustParseRule("FREQ=DAILY;INTERVAL=7;COUNT=2"),
+			MustParseRule("FREQ=WEEKLY;BYDAY=WE;COUNT=2"),
+		},
+		ExDates: []dt.LocalDateTime{dt.DateTime(2024, time.January, 3, 9, 0, 0)},
+	}
+	actual = nil
+	for occ := range s.Between(dt.DateTime(2024, time.January, 1, 0, 0, 0), dt.DateTime(2024, time.February, 1, 0, 0, 0)) {
+		actual = append(actual, occ.String())
+	}
+	assert.Equal([]string{"2024-01-01T09:00:00", "2024-01-08T09:00:00", "2024-01-10T09:00:00", "2024-01-15T09:00:00"}, actual)
+	assert.Equal("DTSTART:20240101T090000\n"+
+		"RRULE:FREQ=WEEKLY;COUNT=3;BYDAY=MO\n"+
+		"RRULE:FREQ=DAILY;COUNT=2;INTERVAL=7\n"+
+		"RRULE:FREQ=WEEKLY;COUNT=2;BYDAY=WE\n"+
+		"EXDATE:20240103T090000", s.String())
+
+	// stopping early releases the rule iterators
+	for range s.All() {
+		break
+	}
+}
+
+func TestParseSetLocations(t *testing.T) {
+	assert := assert.New(t)
+	s := MustParseSet("DTSTART;TZID=Australia/Sydney:20240101T090000\r\n" +
+		"RRULE:FREQ=DAILY;UNTIL=20240102T220000Z\r\n" +
+		"RDATE:20240110T220000Z\r\n" +
+		"EXDATE;TZID=\"America/New_York\":20240101T170000\r\n")
+	assert.Equal("Australia/Sydney", s.Location.String())
+	var actual []string
+	for occ := range s.All() {
+		actual = append(actual, occ.String())
+	}
+	// 22:00 UTC is 09:00 the next day in Sydney, and 17:00 in New York on
+	// January 1 is 09:00 on January 2 in Sydney
+	assert.Equal([]string{"2024-01-01T09:00:00", "2024-01-03T09:00:00", "2024-01-11T09:00:00"}, actual)
+	assert.Equal("DTSTART;TZID=Australia/Sydney:20240101T090000\n"+
+		"RRULE:FREQ=DAILY;UNTIL=20240102T220000Z\n"+
+		"RDATE;TZID=Australia/Sydney:20240111T090000\n"+
+		"EXDATE;TZID=Australia/Sydney:20240102T090000", s.String())
+
+	s = MustParseSet("DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY;COUNT=2")
+	assert.Equal(time.UTC, s.Location)
+	assert.Equal("DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY;COUNT=2", s.String())
+
+	b, err := s.MarshalText()
+	assert.NoError(err)
+	var s2 Set
+	assert.NoError(s2.UnmarshalText(b))
+	assert.True(slices.Equal(slices.Collect(s.All()), slices.Collect(s2.All())))
+
+	for _, text := range []string{
+		"",
+		"RRULE:FREQ=DAILY",
+		"DTSTART:20240101T090000\nDTSTART:20240102T090000",
+		"DTSTART;TZID=Nowhere/Special:20240101T090000",
+		"DTSTART:2024-01-01",
+		"DTSTART;VALUE=DATE-TIME:20240101",
+		"DTSTART:20240101T090000\nRRULE:FREQ=NEVER",
+		"DTSTART:20240101T090000\nRDATE:20240101T090000/PT1H",
+		"DTSTART:20240101T090000\nRDATE;VALUE=PERIOD:20240101T090000/PT1H",
+		"DTSTART:20240101T090000\nEXRULE:FREQ=DAILY",
+		"DTSTART:20240101T090000\nSUMMARY",
+		"DTSTART;TZID:20240101T090000",
+	} {
+		_, err := ParseSet(text)
+		assert.Error(err, text)
+	}
+	assert.Panics(func() {
+		MustParseSet("")
+	})
+}
+
+func TestRuleLeapSecond(t *testing.T) {
+	assert := assert.New(t)
+	start := dt.DateTime(2024, time.March, 1, 9, 0, 0)
+	tests := []struct {
+		rule string
+		want []string
+	}{
+		{rule: "FREQ=MINUTELY;BYSECOND=60;COUNT=1"},
+		{rule: "FREQ=SECONDLY;BYSECOND=60;COUNT=1"},
+		{rule: "FREQ=DAILY;BYSECOND=60;COUNT=1"},
+		{rule: "FREQ=MINUTELY;BYSECOND=30,60;COUNT=2", want: []string{"2024-03-01T09:00:30", "2024-03-01T09:01:30"}},
+	}
+	for _, tt := range tests {
+		r := MustParseRule(tt.rule)
+		done := make(chan []string)
+		go func() {
+			var actual []string
+			for occ := range r.All(start) {
+				actual = append(actual, occ.String())
+			}
+			done <- actual
+		}()
+		select {
+		case actual := <-done:
+			assert.Equal(tt.want, actual, tt.rule)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: timed out", tt.rule)
+		}
+	}
+}
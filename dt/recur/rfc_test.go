@@ -0,0 +1,384 @@
+package recur
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRFC5545Examples checks the examples in RFC 5545 section 3.8.5.3.
+// Expected values of the form yyyymmdd are at 09:00:00.
+func TestRFC5545Examples(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Start    string
+		Rule     string
+		ExDate   string
+		Expected []string
+		Bounded  bool   // Expected is the complete list, unless Count is set
+		Count    int    // total number of occurrences, if not in Expected
+		Last     string // last occurrence, if not in Expected
+	}{
+		{
+			Name:     "daily for 10 occurrences",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=DAILY;COUNT=10",
+			Expected: []string{"19970902", "19970903", "19970904", "19970905", "19970906", "19970907", "19970908", "19970909", "19970910", "19970911"},
+			Bounded:  true,
+		},
+		{
+			Name:     "daily until December 24, 1997",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=DAILY;UNTIL=19971224T000000Z",
+			Expected: []string{"19970902", "19970903", "19970904"},
+			Bounded:  true,
+			Count:    113,
+			Last:     "19971223",
+		},
+		{
+			Name:     "every other day - forever",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=DAILY;INTERVAL=2",
+			Expected: []string{"19970902", "19970904", "19970906", "19970908", "19970910", "19970912", "19970914", "19970916", "19970918", "19970920", "19970922", "19970924", "19970926", "19970928", "19970930", "19971002"},
+		},
+		{
+			Name:     "every 10 days, 5 occurrences",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=DAILY;INTERVAL=10;COUNT=5",
+			Expected: []string{"19970902", "19970912", "19970922", "19971002", "19971012"},
+			Bounded:  true,
+		},
+		{
+			Name:     "every day in January, for 3 years (yearly)",
+			Start:    "19980101T090000",
+			Rule:     "FREQ=YEARLY;UNTIL=20000131T140000Z;BYMONTH=1;BYDAY=SU,MO,TU,WE,TH,FR,SA",
+			Expected: []string{"19980101", "19980102", "19980103"},
+			Bounded:  true,
+			Count:    93,
+			Last:     "20000131",
+		},
+		{
+			Name:     "every day in January, for 3 years (daily)",
+			Start:    "19980101T090000",
+			Rule:     "FREQ=DAILY;UNTIL=20000131T140000Z;BYMONTH=1",
+			Expected: []string{"19980101", "19980102", "19980103"},
+			Bounded:  true,
+			Count:    93,
+			Last:     "20000131",
+		},
+		{
+			Name:     "weekly for 10 occurrences",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=WEEKLY;COUNT=10",
+			Expected: []string{"19970902", "19970909", "19970916", "19970923", "19970930", "19971007", "19971014", "19971021", "19971028", "19971104"},
+			Bounded:  true,
+		},
+		{
+			Name:     "weekly until December 24, 1997",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=WEEKLY;UNTIL=19971224T000000Z",
+			Expected: []string{"19970902", "19970909", "19970916", "19970923", "19970930", "19971007", "19971014", "19971021", "19971028", "19971104", "19971111", "19971118", "19971125", "19971202", "19971209", "19971216", "19971223"},
+			Bounded:  true,
+		},
+		{
+			Name:     "every other week - forever",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=WEEKLY;INTERVAL=2;WKST=SU",
+			Expected: []string{"19970902", "19970916", "19970930", "19971014", "19971028", "19971111", "19971125", "19971209", "19971223", "19980106", "19980120", "19980203", "19980217"},
+		},
+		{
+			Name:     "weekly on Tuesday and Thursday for five weeks (until)",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=WEEKLY;UNTIL=19971007T000000Z;WKST=SU;BYDAY=TU,TH",
+			Expected: []string{"19970902", "19970904", "19970909", "19970911", "19970916", "19970918", "19970923", "19970925", "19970930", "19971002"},
+			Bounded:  true,
+		},
+		{
+			Name:     "weekly on Tuesday and Thursday for five weeks (count)",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=WEEKLY;COUNT=10;WKST=SU;BYDAY=TU,TH",
+			Expected: []string{"19970902", "19970904", "19970909", "19970911", "19970916", "19970918", "19970923", "19970925", "19970930", "19971002"},
+			Bounded:  true,
+		},
+		{
+			Name:  "every other week on Monday, Wednesday, and Friday until December 24, 1997",
+			Start: "19970901T090000",
+			Rule:  "FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;WKST=SU;BYDAY=MO,WE,FR",
+			Expected: []string{
+				"19970901", "19970903", "19970905", "19970915", "19970917", "19970919", "19970929",
+				"19971001", "19971003", "19971013", "19971015", "19971017", "19971027", "19971029", "19971031",
+				"19971110", "19971112", "19971114", "19971124", "19971126", "19971128",
+				"19971208", "19971210", "19971212", "19971222",
+			},
+			Bounded: true,
+		},
+		{
+			Name:     "every other week on Tuesday and Thursday, for 8 occurrences",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=WEEKLY;INTERVAL=2;COUNT=8;WKST=SU;BYDAY=TU,TH",
+			Expected: []string{"19970902", "19970904", "19970916", "19970918", "19970930", "19971002", "19971014", "19971016"},
+			Bounded:  true,
+		},
+		{
+			Name:     "monthly on the first Friday for 10 occurrences",
+			Start:    "19970905T090000",
+			Rule:     "FREQ=MONTHLY;COUNT=10;BYDAY=1FR",
+			Expected: []string{"19970905", "19971003", "19971107", "19971205", "19980102", "19980206", "19980306", "19980403", "19980501", "19980605"},
+			Bounded:  true,
+		},
+		{
+			Name:     "monthly on the first Friday until December 24, 1997",
+			Start:    "19970905T090000",
+			Rule:     "FREQ=MONTHLY;UNTIL=19971224T000000Z;BYDAY=1FR",
+			Expected: []string{"19970905", "19971003", "19971107", "19971205"},
+			Bounded:  true,
+		},
+		{
+			Name:     "every other month on the first and last Sunday of the month for 10 occurrences",
+			Start:    "19970907T090000",
+			Rule:     "FREQ=MONTHLY;INTERVAL=2;COUNT=10;BYDAY=1SU,-1SU",
+			Expected: []string{"19970907", "19970928", "19971102", "19971130", "19980104", "19980125", "19980301", "19980329", "19980503", "19980531"},
+			Bounded:  true,
+		},
+		{
+			Name:     "monthly on the second-to-last Monday of the month for 6 months",
+			Start:    "19970922T090000",
+			Rule:     "FREQ=MONTHLY;COUNT=6;BYDAY=-2MO",
+			Expected: []string{"19970922", "19971020", "19971117", "19971222", "19980119", "19980216"},
+			Bounded:  true,
+		},
+		{
+			Name:     "monthly on the third-to-the-last day of the month, forever",
+			Start:    "19970928T090000",
+			Rule:     "FREQ=MONTHLY;BYMONTHDAY=-3",
+			Expected: []string{"19970928", "19971029", "19971128", "19971229", "19980129", "19980226"},
+		},
+		{
+			Name:     "monthly on the 2nd and 15th of the month for 10 occurrences",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=MONTHLY;COUNT=10;BYMONTHDAY=2,15",
+			Expected: []string{"19970902", "19970915", "19971002", "19971015", "19971102", "19971115", "19971202", "19971215", "19980102", "19980115"},
+			Bounded:  true,
+		},
+		{
+			Name:     "monthly on the first and last day of the month for 10 occurrences",
+			Start:    "19970930T090000",
+			Rule:     "FREQ=MONTHLY;COUNT=10;BYMONTHDAY=1,-1",
+			Expected: []string{"19970930", "19971001", "19971031", "19971101", "19971130", "19971201", "19971231", "19980101", "19980131", "19980201"},
+			Bounded:  true,
+		},
+		{
+			Name:     "every 18 months on the 10th thru 15th of the month for 10 occurrences",
+			Start:    "19970910T090000",
+			Rule:     "FREQ=MONTHLY;INTERVAL=18;COUNT=10;BYMONTHDAY=10,11,12,13,14,15",
+			Expected: []string{"19970910", "19970911", "19970912", "19970913", "19970914", "19970915", "19990310", "19990311", "19990312", "19990313"},
+			Bounded:  true,
+		},
+		{
+			Name:     "every Tuesday, every other month",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=MONTHLY;INTERVAL=2;BYDAY=TU",
+			Expected: []string{"19970902", "19970909", "19970916", "19970923", "19970930", "19971104", "19971111", "19971118", "19971125", "19980106", "19980113", "19980120", "19980127", "19980303", "19980310", "19980317", "19980324", "19980331"},
+		},
+		{
+			Name:     "yearly in June and July for 10 occurrences",
+			Start:    "19970610T090000",
+			Rule:     "FREQ=YEARLY;COUNT=10;BYMONTH=6,7",
+			Expected: []string{"19970610", "19970710", "19980610", "19980710", "19990610", "19990710", "20000610", "20000710", "20010610", "20010710"},
+			Bounded:  true,
+		},
+		{
+			Name:     "every other year on January, February, and March for 10 occurrences",
+			Start:    "19970310T090000",
+			Rule:     "FREQ=YEARLY;INTERVAL=2;COUNT=10;BYMONTH=1,2,3",
+			Expected: []string{"19970310", "19990110", "19990210", "19990310", "20010110", "20010210", "20010310", "20030110", "20030210", "20030310"},
+			Bounded:  true,
+		},
+		{
+			Name:     "every third year on the 1st, 100th, and 200th day for 10 occurrences",
+			Start:    "19970101T090000",
+			Rule:     "FREQ=YEARLY;INTERVAL=3;COUNT=10;BYYEARDAY=1,100,200",
+			Expected: []string{"19970101", "19970410", "19970719", "20000101", "20000409", "20000718", "20030101", "20030410", "20030719", "20060101"},
+			Bounded:  true,
+		},
+		{
+			Name:     "every 20th Monday of the year, forever",
+			Start:    "19970519T090000",
+			Rule:     "FREQ=YEARLY;BYDAY=20MO",
+			Expected: []string{"19970519", "19980518", "19990517"},
+		},
+		{
+			Name:     "Monday of week number 20, forever",
+			Start:    "19970512T090000",
+			Rule:     "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO",
+			Expected: []string{"19970512", "19980511", "19990517"},
+		},
+		{
+			Name:     "every Thursday in March, forever",
+			Start:    "19970313T090000",
+			Rule:     "FREQ=YEARLY;BYMONTH=3;BYDAY=TH",
+			Expected: []string{"19970313", "19970320", "19970327", "19980305", "19980312", "19980319", "19980326", "19990304", "19990311", "19990318", "19990325"},
+		},
+		{
+			Name:  "every Thursday, but only during June, July, and August, forever",
+			Start: "19970605T090000",
+			Rule:  "FREQ=YEARLY;BYDAY=TH;BYMONTH=6,7,8",
+			Expected: []string{
+				"19970605", "19970612", "19970619", "19970626", "19970703", "19970710", "19970717", "19970724", "19970731",
+				"19970807", "19970814", "19970821", "19970828",
+				"19980604", "19980611", "19980618", "19980625", "19980702", "19980709", "19980716", "19980723", "19980730",
+				"19980806", "19980813", "19980820", "19980827",
+				"19990603", "19990610", "19990617", "19990624", "19990701", "19990708", "19990715", "19990722", "19990729",
+				"19990805", "19990812", "19990819", "19990826",
+			},
+		},
+		{
+			Name:     "every Friday the 13th, forever",
+			Start:    "19970902T090000",
+			ExDate:   "19970902T090000",
+			Rule:     "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			Expected: []string{"19980213", "19980313", "19981113", "19990813", "20001013"},
+		},
+		{
+			Name:     "the first Saturday that follows the first Sunday of the month, forever",
+			Start:    "19970913T090000",
+			Rule:     "FREQ=MONTHLY;BYDAY=SA;BYMONTHDAY=7,8,9,10,11,12,13",
+			Expected: []string{"19970913", "19971011", "19971108", "19971213", "19980110", "19980207", "19980307", "19980411", "19980509", "19980613"},
+		},
+		{
+			Name:     "every 4 years, the first Tuesday after a Monday in November, forever",
+			Start:    "19961105T090000",
+			Rule:     "FREQ=YEARLY;INTERVAL=4;BYMONTH=11;BYDAY=TU;BYMONTHDAY=2,3,4,5,6,7,8",
+			Expected: []string{"19961105", "20001107", "20041102"},
+		},
+		{
+			Name:     "the third instance into the month of one of Tuesday, Wednesday, or Thursday, for the next 3 months",
+			Start:    "19970904T090000",
+			Rule:     "FREQ=MONTHLY;COUNT=3;BYDAY=TU,WE,TH;BYSETPOS=3",
+			Expected: []string{"19970904", "19971007", "19971106"},
+			Bounded:  true,
+		},
+		{
+			Name:     "the second-to-last weekday of the month",
+			Start:    "19970929T090000",
+			Rule:     "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2",
+			Expected: []string{"19970929", "19971030", "19971127", "19971230", "19980129", "19980226", "19980330"},
+		},
+		{
+			Name:     "every 15 minutes for 6 occurrences",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=MINUTELY;INTERVAL=15;COUNT=6",
+			Expected: []string{"19970902T090000", "19970902T091500", "19970902T093000", "19970902T094500", "19970902T100000", "19970902T101500"},
+			Bounded:  true,
+		},
+		{
+			Name:     "every hour and a half for 4 occurrences",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=MINUTELY;INTERVAL=90;COUNT=4",
+			Expected: []string{"19970902T090000", "19970902T103000", "19970902T120000", "19970902T133000"},
+			Bounded:  true,
+		},
+		{
+			Name:     "every 20 minutes from 9:00 AM to 4:40 PM every day (daily)",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=DAILY;BYHOUR=9,10,11,12,13,14,15,16;BYMINUTE=0,20,40",
+			Expected: everyTwentyMinutes,
+		},
+		{
+			Name:     "every 20 minutes from 9:00 AM to 4:40 PM every day (minutely)",
+			Start:    "19970902T090000",
+			Rule:     "FREQ=MINUTELY;INTERVAL=20;BYHOUR=9,10,11,12,13,14,15,16",
+			Expected: everyTwentyMinutes,
+		},
+		{
+			Name:     "week start of Monday",
+			Start:    "19970805T090000",
+			Rule:     "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
+			Expected: []string{"19970805", "19970810", "19970819", "19970824"},
+			Bounded:  true,
+		},
+		{
+			Name:     "week start of Sunday",
+			Start:    "19970805T090000",
+			Rule:     "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+			Expected: []string{"19970805", "19970817", "19970819", "19970831"},
+			Bounded:  true,
+		},
+		{
+			Name:     "invalid dates are ignored",
+			Start:    "20070115T090000",
+			Rule:     "FREQ=MONTHLY;BYMONTHDAY=15,30;COUNT=5",
+			Expected: []string{"20070115", "20070130", "20070215", "20070315", "20070330"},
+			Bounded:  true,
+		},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		text := "DTSTART;TZID=America/New_York:" + tc.Start + "\nRRULE:" + tc.Rule
+		if tc.ExDate != "" {
+			text += "\nEXDATE;TZID=America/New_York:" + tc.ExDate
+		}
+		s, err := ParseSet(text)
+		if !assert.NoError(err, tc.Name) {
+			continue
+		}
+
+		var actual []string
+		for occ := range s.All() {
+			actual = append(actual, dateTimeValue{dt: occ}.String())
+			if !tc.Bounded && len(actual) == len(tc.Expected) || len(actual) > 1000 {
+				break
+			}
+		}
+		expected := make([]string, len(tc.Expected))
+		for i, v := range tc.Expected {
+			if len(v) == 8 {
+				v += "T090000"
+			}
+			expected[i] = v
+		}
+		if tc.Count == 0 {
+			assert.Equal(expected, actual, tc.Name)
+			continue
+		}
+		assert.Len(actual, tc.Count, tc.Name)
+		if assert.GreaterOrEqual(len(actual), len(expected), tc.Name) {
+			assert.Equal(expected, actual[:len(expected)], tc.Name)
+			assert.Equal(tc.Last+"T090000", actual[len(actual)-1], tc.Name)
+		}
+	}
+}
+
+// everyTwentyMinutes contains the first occurrences of the RFC 5545 example
+// "every 20 minutes from 9:00 AM to 4:40 PM every day".
+var everyTwentyMinutes = func() []string {
+	var list []string
+	for _, hour := range []string{"09", "10", "11", "12", "13", "14", "15", "16"} {
+		for _, minute := range []string{"00", "20", "40"} {
+			list = append(list, "19970902T"+hour+minute+"00")
+		}
+	}
+	return append(list, "19970903T090000")
+}()
+
+// TestRFC5545Erratum checks the RFC 5545 example "every 3 hours from 9:00 AM
+// to 5:00 PM on a specific day". The published example has an UNTIL in UTC
+// that is before 5:00 PM in New York, so the rule is tested with a floating start.
+func TestRFC5545Erratum(t *testing.T) {
+	assert := assert.New(t)
+	s := MustParseSet("DTSTART:19970902T090000\nRRULE:FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T170000Z")
+	var actual []string
+	for occ := range s.All() {
+		actual = append(actual, dateTimeValue{dt: occ}.String())
+	}
+	assert.Equal("19970902T090000 19970902T120000 19970902T150000", strings.Join(actual, " "))
+
+	s = MustParseSet("DTSTART;TZID=America/New_York:19970902T090000\nRRULE:FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T170000Z")
+	actual = nil
+	for occ := range s.All() {
+		actual = append(actual, dateTimeValue{dt: occ}.String())
+	}
+	assert.Equal("19970902T090000 19970902T120000", strings.Join(actual, " "))
+}
@@ -0,0 +1,333 @@
+package recur
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jjeffery/goda/dt"
+)
+
+var errMissingStart = errors.New("recurrence set has no DTSTART")
+
+// Set is a recurrence set, which is the combination of a start date-time,
+// recurrence rules, and additional and excluded date-times. It corresponds
+// to the DTSTART, RRULE, RDATE and EXDATE properties of an iCalendar event.
+//
+// The occurrences of a Set are the start, the occurrences of each rule and
+// the additional date-times, in ascending order and without duplicates,
+// excluding any of the excluded date-times.
+type Set struct {
+	Start dt.LocalDateTime
+
+	// DateOnly is true if the start is a date rather than a date-time. The
+	// occurrences of the set all have a time of midnight, and dates are
+	// formatted without a time.
+	DateOnly bool
+
+	// Location is the location of the start, if it has one. It is used to
+	// convert date-times in UTC, and date-times in other locations, to
+	// local date-times. It is formatted as the TZID parameter of the
+	// DTSTART property, or as a "Z" suffix if it is time.UTC.
+	Location *time.Location
+
+	Rules   []Rule
+	RDates  []dt.LocalDateTime
+	ExDates []dt.LocalDateTime
+}
+
+// All returns the occurrences of the set in ascending order.
+func (s Set) All() iter.Seq[dt.LocalDateTime] {
+	return func(yield func(dt.LocalDateTime) bool) {
+		excluded := make(map[int64]bool, len(s.ExDates))
+		for _, ex := range s.ExDates {
+			excluded[ex.Unix()] = true
+		}
+
+		// the start and the additional date-times are one source, and
+		// each rule is another
+		extra := append([]dt.LocalDateTime{s.Start}, s.RDates...)
+		slices.SortFunc(extra, func(a, b dt.LocalDateTime) int {
+			return cmp.Compare(a.Unix(), b.Unix())
+		})
+		sources := []iter.Seq[dt.LocalDateTime]{slices.Values(extra)}
+		for _, r := range s.Rules {
+			sources = append(sources, r.expand(s.Start, s.Location))
+		}
+
+		type head struct {
+			next  func() (dt.LocalDateTime, bool)
+			value dt.LocalDateTime
+		}
+		var heads []*head
+		for _, source := range sources {
+			next, stop := iter.Pull(source)
+			defer stop()
+			if v, ok := next(); ok {
+				heads = append(heads, &head{next: next, value: v})
+			}
+		}
+
+		var prev dt.LocalDateTime
+		first := true
+		for len(heads) > 0 {
+			min := 0
+			for i, h := range heads {
+				if h.value.Before(heads[min].value) {
+					min = i
+				}
+			}
+			v := heads[min].value
+			if next, ok := heads[min].next(); ok {
+				heads[min].value = next
+			} else {
+				heads = slices.Delete(heads, min, min+1)
+			}
+			if (!first && v.Equal(prev)) || excluded[v.Unix()] {
+				continue
+			}
+			prev, first = v, false
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Between returns the occurrences of the set that are on or after from
+// and before to.
+func (s Set) Between(from, to dt.LocalDateTime) iter.Seq[dt.LocalDateTime] {
+	return between(s.All(), from, to)
+}
+
+// Dates returns the distinct dates of the occurrences of the set.
+func (s Set) Dates() iter.Seq[dt.LocalDate] {
+	return dates(s.All())
+}
+
+// DatesBetween returns the distinct dates of the occurrences of the set
+// that are in the date range.
+func (s Set) DatesBetween(dr dt.DateRange) iter.Seq[dt.LocalDate] {
+	return datesBetween(s.Dates(), dr)
+}
+
+// ParseSet parses the recurrence properties of an iCalendar event, one
+// property per line. The DTSTART property is required, and the RRULE, RDATE
+// and EXDATE properties can appear any number of times. For example:
+//
+//	DTSTART;TZID=America/New_York:19970902T090000
+//	RRULE:FREQ=WEEKLY;COUNT=10
+//	EXDATE;TZID=America/New_York:19970909T090000
+//
+// The TZID parameter is loaded using time.LoadLocation. Values in UTC or in
+// a location other than the location of DTSTART are converted to the local
+// time in the location of DTSTART.
+func ParseSet(text string) (Set, error) {
+	var s Set
+	var haveStart bool
+	type pending struct {
+		name   string
+		params map[string]string
+		value  string
+	}
+	var dateLists []pending
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, params, value, err := splitProperty(line)
+		if err != nil {
+			return Set{}, err
+		}
+		switch name {
+		case "DTSTART":
+			if haveStart {
+				return Set{}, errors.New("recurrence set has more than one DTSTART")
+			}
+			haveStart = true
+			loc, err := paramLocation(params)
+			if err != nil {
+				return Set{}, err
+			}
+			v, err := parseDateTimeValue(value)
+			if err != nil || (v.date && params["VALUE"] == "DATE-TIME") {
+				return Set{}, fmt.Errorf("invalid DTSTART value %q", value)
+			}
+			s.Start, s.DateOnly, s.Location = v.dt, v.date, loc
+			if v.utc {
+				s.Location = time.UTC
+			}
+		case "RRULE":
+			r, err := ParseRule(value)
+			if err != nil {
+				return Set{}, err
+			}
+			s.Rules = append(s.Rules, r)
+		case "RDATE", "EXDATE":
+			// converted once the location of DTSTART is known
+			dateLists = append(dateLists, pending{name: name, params: params, value: value})
+		default:
+			return Set{}, fmt.Errorf("unsupported recurrence property %q", name)
+		}
+	}
+	if !haveStart {
+		return Set{}, errMissingStart
+	}
+
+	for _, p := range dateLists {
+		if p.params["VALUE"] == "PERIOD" {
+			return Set{}, fmt.Errorf("%s with VALUE=PERIOD is not supported", p.name)
+		}
+		loc, err := paramLocation(p.params)
+		if err != nil {
+			return Set{}, err
+		}
+		for _, value := range strings.Split(p.value, ",") {
+			v, err := parseDateTimeValue(strings.TrimSpace(value))
+			if err != nil {
+				return Set{}, fmt.Errorf("invalid %s value %q", p.name, value)
+			}
+			from := loc
+			if v.utc {
+				from = time.UTC
+			}
+			local := s.toLocal(v.dt, from)
+			if p.name == "RDATE" {
+				s.RDates = append(s.RDates, local)
+			} else {
+				s.ExDates = append(s.ExDates, local)
+			}
+		}
+	}
+	return s, nil
+}
+
+// MustParseSet is similar to ParseSet, but instead of returning an error it will
+// panic if text is not a valid recurrence set.
+func MustParseSet(text string) Set {
+	s, err := ParseSet(text)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// toLocal converts a date-time in the location from to the local date-time
+// in the location of the set. If either location is nil, or they are the
+// same, v is returned unchanged.
+func (s Set) toLocal(v dt.LocalDateTime, from *time.Location) dt.LocalDateTime {
+	if from == nil || s.Location == nil || from == s.Location {
+		return v
+	}
+	t, _ := v.InLocation(from, dt.ResolveShiftForward)
+	return dt.LocalDateTimeOf(t.In(s.Location))
+}
+
+// splitProperty splits a content line into its name, parameters and value.
+// Names and parameter names are converted to upper case.
+func splitProperty(line string) (name string, params map[string]string, value string, err error) {
+	// the value starts after the first colon that is not in a quoted parameter value
+	quoted := false
+	colon := -1
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", fmt.Errorf("invalid recurrence property %q", line)
+	}
+	value = line[colon+1:]
+	parts := strings.Split(line[:colon], ";")
+	name = strings.ToUpper(parts[0])
+	params = make(map[string]string)
+	for _, part := range parts[1:] {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return "", nil, "", fmt.Errorf("invalid property parameter %q", part)
+		}
+		k = strings.ToUpper(k)
+		v = strings.Trim(v, `"`)
+		if k != "TZID" {
+			v = strings.ToUpper(v)
+		}
+		params[k] = v
+	}
+	return name, params, value, nil
+}
+
+// paramLocation loads the location in the TZID parameter, if there is one.
+func paramLocation(params map[string]string) (*time.Location, error) {
+	tzid, ok := params["TZID"]
+	if !ok {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(tzid)
+	if err != nil {
+		return nil, fmt.Errorf("unknown TZID %q", tzid)
+	}
+	return loc, nil
+}
+
+// String returns the set as iCalendar properties, one per line.
+func (s Set) String() string {
+	var lines []string
+	lines = append(lines, "DTSTART"+s.valueParams()+":"+s.formatValue(s.Start))
+	for _, r := range s.Rules {
+		lines = append(lines, "RRULE:"+r.format(s.DateOnly))
+	}
+	if line := s.formatList("RDATE", s.RDates); line != "" {
+		lines = append(lines, line)
+	}
+	if line := s.formatList("EXDATE", s.ExDates); line != "" {
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s Set) valueParams() string {
+	var params string
+	if s.DateOnly {
+		params += ";VALUE=DATE"
+	} else if s.Location != nil && s.Location != time.UTC {
+		params += ";TZID=" + s.Location.String()
+	}
+	return params
+}
+
+func (s Set) formatValue(v dt.LocalDateTime) string {
+	return dateTimeValue{dt: v, date: s.DateOnly, utc: !s.DateOnly && s.Location == time.UTC}.String()
+}
+
+func (s Set) formatList(name string, list []dt.LocalDateTime) string {
+	if len(list) == 0 {
+		return ""
+	}
+	values := make([]string, len(list))
+	for i, v := range list {
+		values[i] = s.formatValue(v)
+	}
+	return name + s.valueParams() + ":" + strings.Join(values, ",")
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s Set) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *Set) UnmarshalText(data []byte) (err error) {
+	*s, err = ParseSet(string(data))
+	return err
+}
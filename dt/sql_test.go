@@ -0,0 +1,237 @@
+package dt
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDriver is a database/sql driver used for testing the sql.Scanner
+// and driver.Valuer implementations. Each query returns a single row with
+// a single column, whose value is looked up in the rows map using the
+// query text. Arguments passed to Exec are recorded in the args map
+// using the statement text.
+type fakeDriver struct {
+	mutex sync.Mutex
+	rows  map[string]driver.Value
+	args  map[string][]driver.Value
+}
+
+var testDriver = &fakeDriver{
+	rows: map[string]driver.Value{},
+	args: map[string][]driver.Value{},
+}
+
+func init() {
+	sql.Register("dtfake", testDriver)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	d := s.conn.driver
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.args[s.query] = args
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	d := s.conn.driver
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	value, ok := d.rows[s.query]
+	if !ok {
+		return nil, errors.New("unknown query: " + s.query)
+	}
+	return &fakeRows{value: value}, nil
+}
+
+type fakeRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+func openTestDB(t *testing.T, rows map[string]driver.Value) *sql.DB {
+	testDriver.mutex.Lock()
+	for query, value := range rows {
+		testDriver.rows[query] = value
+	}
+	testDriver.mutex.Unlock()
+
+	db, err := sql.Open("dtfake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestSQLScan(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := openTestDB(t, map[string]driver.Value{
+		"utc":       time.Date(2021, 1, 2, 10, 11, 12, 0, time.UTC),
+		"sydney":    time.Date(2021, 1, 2, 10, 11, 12, 0, sydney),
+		"negative":  time.Date(2021, 1, 2, 10, 11, 12, 0, time.FixedZone("", -10*3600)),
+		"string":    "2021-01-02T10:11:12",
+		"bytes":     []byte("2021-01-02 10:11:12.000000"),
+		"date-only": "2021-01-02",
+		"null":      nil,
+		"int":       int64(20210102),
+		"garbage":   "not a date",
+	})
+
+	testCases := []struct {
+		Query    string
+		Date     string
+		DateTime string
+	}{
+		{Query: "utc", Date: "2021-01-02", DateTime: "2021-01-02T10:11:12"},
+		{Query: "sydney", Date: "2021-01-02", DateTime: "2021-01-02T10:11:12"},
+		{Query: "negative", Date: "2021-01-02", DateTime: "2021-01-02T10:11:12"},
+		{Query: "string", Date: "2021-01-02", DateTime: "2021-01-02T10:11:12"},
+		{Query: "bytes", Date: "", DateTime: "2021-01-02T10:11:12"},
+		{Query: "date-only", Date: "2021-01-02", DateTime: "2021-01-02T00:00:00"},
+		{Query: "int"},
+		{Query: "garbage"},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		var d LocalDate
+		err := db.QueryRow(tc.Query).Scan(&d)
+		if tc.Date != "" {
+			assert.NoError(err, tc.Query)
+			assert.Equal(tc.Date, d.String(), tc.Query)
+		} else {
+			assert.Error(err, tc.Query)
+		}
+
+		var nd NullLocalDate
+		err = db.QueryRow(tc.Query).Scan(&nd)
+		if tc.Date != "" {
+			assert.NoError(err, tc.Query)
+			assert.True(nd.Valid, tc.Query)
+			assert.Equal(tc.Date, nd.LocalDate.String(), tc.Query)
+		} else {
+			assert.Error(err, tc.Query)
+		}
+
+		var dt LocalDateTime
+		err = db.QueryRow(tc.Query).Scan(&dt)
+		if tc.DateTime != "" {
+			assert.NoError(err, tc.Query)
+			assert.Equal(tc.DateTime, dt.String(), tc.Query)
+		} else {
+			assert.Error(err, tc.Query)
+		}
+
+		var ndt NullLocalDateTime
+		err = db.QueryRow(tc.Query).Scan(&ndt)
+		if tc.DateTime != "" {
+			assert.NoError(err, tc.Query)
+			assert.True(ndt.Valid, tc.Query)
+			assert.Equal(tc.DateTime, ndt.LocalDateTime.String(), tc.Query)
+		} else {
+			assert.Error(err, tc.Query)
+		}
+	}
+
+	// NULL can only be scanned into the nullable types
+	var d LocalDate
+	assert.Error(db.QueryRow("null").Scan(&d))
+	var dt LocalDateTime
+	assert.Error(db.QueryRow("null").Scan(&dt))
+
+	nd := NullLocalDate{Valid: true}
+	assert.NoError(db.QueryRow("null").Scan(&nd))
+	assert.False(nd.Valid)
+	ndt := NullLocalDateTime{Valid: true}
+	assert.NoError(db.QueryRow("null").Scan(&ndt))
+	assert.False(ndt.Valid)
+}
+
+func TestSQLValue(t *testing.T) {
+	db := openTestDB(t, nil)
+	assert := assert.New(t)
+
+	_, err := db.Exec("insert",
+		Date(2021, time.January, 2),
+		DateTime(2021, time.January, 2, 10, 11, 12),
+		NullLocalDate{},
+		NullLocalDate{LocalDate: Date(2021, time.January, 3), Valid: true},
+		NullLocalDateTime{},
+		NullLocalDateTime{LocalDateTime: DateTime(2021, time.January, 3, 4, 5, 6), Valid: true},
+	)
+	assert.NoError(err)
+
+	testDriver.mutex.Lock()
+	args := testDriver.args["insert"]
+	testDriver.mutex.Unlock()
+	assert.Equal([]driver.Value{
+		"2021-01-02",
+		"2021-01-02T10:11:12",
+		nil,
+		"2021-01-03",
+		nil,
+		"2021-01-03T04:05:06",
+	}, args)
+}
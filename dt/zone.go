@@ -0,0 +1,183 @@
+package dt
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrSkippedTime is returned by ResolveStrict when a local date-time
+	// does not exist in a location, because it falls in a gap caused by
+	// a transition such as the start of daylight saving time.
+	ErrSkippedTime = errors.New("local date-time is skipped by a timezone transition")
+
+	// ErrAmbiguousTime is returned by ResolveStrict when a local date-time
+	// occurs twice in a location, because it falls in an overlap caused by
+	// a transition such as the end of daylight saving time.
+	ErrAmbiguousTime = errors.New("local date-time is ambiguous due to a timezone transition")
+)
+
+// ZoneMapping describes how a local date-time maps onto instants in time
+// in a particular location. Usually a local date-time occurs exactly once,
+// but around a timezone transition it might not occur at all (a gap) or it
+// might occur twice (an overlap).
+//
+// A ZoneMapping is passed to a Resolver, which decides which instant
+// the local date-time represents.
+type ZoneMapping struct {
+	LocalDateTime LocalDateTime
+	Location      *time.Location
+	earlier       time.Time
+	later         time.Time
+	gap           bool
+}
+
+// IsUnique reports whether the local date-time occurs exactly once in the location.
+func (m ZoneMapping) IsUnique() bool {
+	return !m.gap && m.earlier.Equal(m.later)
+}
+
+// IsGap reports whether the local date-time does not occur in the location,
+// because the clocks skipped over it.
+func (m ZoneMapping) IsGap() bool {
+	return m.gap
+}
+
+// IsOverlap reports whether the local date-time occurs twice in the location,
+// because the clocks were turned back over it.
+func (m ZoneMapping) IsOverlap() bool {
+	return !m.gap && !m.earlier.Equal(m.later)
+}
+
+// Earlier returns the earlier of the two candidate instants for the local
+// date-time. For an overlap this is the first time that the local date-time
+// occurs. For a gap this is the local date-time interpreted using the UTC
+// offset after the transition, which is shifted backwards by the length of
+// the gap. If the mapping is unique, Earlier and Later return the same instant.
+func (m ZoneMapping) Earlier() time.Time {
+	return m.earlier
+}
+
+// Later returns the later of the two candidate instants for the local
+// date-time. For an overlap this is the second time that the local date-time
+// occurs. For a gap this is the local date-time interpreted using the UTC
+// offset before the transition, which is shifted forwards by the length of
+// the gap. If the mapping is unique, Earlier and Later return the same instant.
+func (m ZoneMapping) Later() time.Time {
+	return m.later
+}
+
+// A Resolver decides which instant a local date-time represents when
+// converting it to a time.Time in a location. It is only called upon to
+// make a choice when the mapping is a gap or an overlap. The resolvers in
+// this package are ResolveStrict, ResolveEarlier, ResolveLater and
+// ResolveShiftForward. Custom resolvers can be written using the
+// information provided in the ZoneMapping.
+type Resolver func(m ZoneMapping) (time.Time, error)
+
+// ResolveStrict is a Resolver that returns an error if the local date-time
+// falls in a gap or an overlap. The error is ErrSkippedTime or ErrAmbiguousTime,
+// wrapped with the local date-time and location.
+func ResolveStrict(m ZoneMapping) (time.Time, error) {
+	if m.IsGap() {
+		return time.Time{}, fmt.Errorf("%s in %s: %w", m.LocalDateTime, m.Location, ErrSkippedTime)
+	}
+	if m.IsOverlap() {
+		return time.Time{}, fmt.Errorf("%s in %s: %w", m.LocalDateTime, m.Location, ErrAmbiguousTime)
+	}
+	return m.Earlier(), nil
+}
+
+// ResolveEarlier is a Resolver that always chooses the earlier instant.
+// See ZoneMapping.Earlier.
+func ResolveEarlier(m ZoneMapping) (time.Time, error) {
+	return m.Earlier(), nil
+}
+
+// ResolveLater is a Resolver that always chooses the later instant.
+// See ZoneMapping.Later.
+func ResolveLater(m ZoneMapping) (time.Time, error) {
+	return m.Later(), nil
+}
+
+// ResolveShiftForward is a Resolver that, for a local date-time in a gap,
+// shifts the local date-time forward by the length of the gap. For example,
+// if clocks go forward from 02:00 to 03:00 then 02:30 becomes 03:30.
+// For a local date-time in an overlap it chooses the earlier instant.
+// This is the most common behaviour of calendar applications, and is the
+// resolver used by InLocation when no resolver is specified.
+func ResolveShiftForward(m ZoneMapping) (time.Time, error) {
+	if m.IsGap() {
+		return m.Later(), nil
+	}
+	return m.Earlier(), nil
+}
+
+// MapLocation returns the mapping of the local date-time dt onto
+// instants in time in the location loc.
+func (dt LocalDateTime) MapLocation(loc *time.Location) ZoneMapping {
+	// Interpreting dt as UTC gives a reference point. Any instant at which
+	// dt is the wall clock time in loc is within a day of this point, so
+	// the offsets in effect a day either side are the only candidates.
+	u := dt.Unix()
+	_, offsetBefore := time.Unix(u-secondsPerDay, 0).In(loc).Zone()
+	_, offsetAfter := time.Unix(u+secondsPerDay, 0).In(loc).Zone()
+
+	m := ZoneMapping{
+		LocalDateTime: dt,
+		Location:      loc,
+	}
+	before := time.Unix(u-int64(offsetBefore), 0).In(loc)
+	after := time.Unix(u-int64(offsetAfter), 0).In(loc)
+	beforeValid := toLocalDateTime(before).Equal(dt)
+	afterValid := toLocalDateTime(after).Equal(dt)
+
+	switch {
+	case beforeValid && afterValid:
+		m.earlier, m.later = before, after
+		if after.Before(before) {
+			m.earlier, m.later = after, before
+		}
+	case beforeValid:
+		m.earlier, m.later = before, before
+	case afterValid:
+		m.earlier, m.later = after, after
+	default:
+		m.gap = true
+		m.earlier, m.later = after, before
+		if before.Before(after) {
+			m.earlier, m.later = before, after
+		}
+	}
+	return m
+}
+
+// InLocation returns the instant at which the local date-time dt occurs
+// in the location loc. If dt does not occur exactly once in loc, because of
+// a timezone transition such as the start or end of daylight saving time,
+// the resolver decides which instant to return, or whether to return an error.
+// If resolver is nil, ResolveShiftForward is used.
+//
+// The returned time.Time has its location set to loc.
+func (dt LocalDateTime) InLocation(loc *time.Location, resolver Resolver) (time.Time, error) {
+	m := dt.MapLocation(loc)
+	if m.IsUnique() {
+		return m.Earlier(), nil
+	}
+	if resolver == nil {
+		resolver = ResolveShiftForward
+	}
+	return resolver(m)
+}
+
+// LocalDateTimeOf returns the local date-time of the wall clock of t
+// in its location. Any fraction of a second is discarded.
+func LocalDateTimeOf(t time.Time) LocalDateTime {
+	return toLocalDateTime(t)
+}
+
+// LocalDateOf returns the local date of the wall clock of t in its location.
+func LocalDateOf(t time.Time) LocalDate {
+	return toLocalDate(t)
+}
@@ -0,0 +1,160 @@
+package dt
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestInLocation(t *testing.T) {
+	sydney := mustLoadLocation(t, "Australia/Sydney")
+	newYork := mustLoadLocation(t, "America/New_York")
+	apia := mustLoadLocation(t, "Pacific/Apia")
+
+	testCases := []struct {
+		Location     *time.Location
+		DateTime     string
+		Unique       bool
+		Gap          bool
+		Overlap      bool
+		Earlier      string
+		Later        string
+		ShiftForward string
+	}{
+		{
+			Location:     sydney,
+			DateTime:     "2024-05-01T09:00:00",
+			Unique:       true,
+			Earlier:      "2024-05-01T09:00:00+10:00",
+			Later:        "2024-05-01T09:00:00+10:00",
+			ShiftForward: "2024-05-01T09:00:00+10:00",
+		},
+		{
+			// clocks go forward from 02:00 to 03:00
+			Location:     sydney,
+			DateTime:     "2024-10-06T02:30:00",
+			Gap:          true,
+			Earlier:      "2024-10-06T01:30:00+10:00",
+			Later:        "2024-10-06T03:30:00+11:00",
+			ShiftForward: "2024-10-06T03:30:00+11:00",
+		},
+		{
+			// clocks go back from 03:00 to 02:00
+			Location:     sydney,
+			DateTime:     "2024-04-07T02:30:00",
+			Overlap:      true,
+			Earlier:      "2024-04-07T02:30:00+11:00",
+			Later:        "2024-04-07T02:30:00+10:00",
+			ShiftForward: "2024-04-07T02:30:00+11:00",
+		},
+		{
+			Location:     sydney,
+			DateTime:     "2024-04-07T03:00:00",
+			Unique:       true,
+			Earlier:      "2024-04-07T03:00:00+10:00",
+			Later:        "2024-04-07T03:00:00+10:00",
+			ShiftForward: "2024-04-07T03:00:00+10:00",
+		},
+		{
+			Location:     newYork,
+			DateTime:     "2024-03-10T02:15:00",
+			Gap:          true,
+			Earlier:      "2024-03-10T01:15:00-05:00",
+			Later:        "2024-03-10T03:15:00-04:00",
+			ShiftForward: "2024-03-10T03:15:00-04:00",
+		},
+		{
+			Location:     newYork,
+			DateTime:     "2024-11-03T01:59:59",
+			Overlap:      true,
+			Earlier:      "2024-11-03T01:59:59-04:00",
+			Later:        "2024-11-03T01:59:59-05:00",
+			ShiftForward: "2024-11-03T01:59:59-04:00",
+		},
+		{
+			// Samoa skipped 30 December 2011 entirely
+			Location:     apia,
+			DateTime:     "2011-12-30T12:00:00",
+			Gap:          true,
+			Earlier:      "2011-12-29T12:00:00-10:00",
+			Later:        "2011-12-31T12:00:00+14:00",
+			ShiftForward: "2011-12-31T12:00:00+14:00",
+		},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		dt := MustParseDateTime(tc.DateTime)
+		m := dt.MapLocation(tc.Location)
+		assert.Equal(tc.Unique, m.IsUnique(), tc.DateTime)
+		assert.Equal(tc.Gap, m.IsGap(), tc.DateTime)
+		assert.Equal(tc.Overlap, m.IsOverlap(), tc.DateTime)
+
+		check := func(resolver Resolver, expected string) {
+			actual, err := dt.InLocation(tc.Location, resolver)
+			assert.NoError(err, tc.DateTime)
+			assert.Equal(expected, actual.Format(time.RFC3339), tc.DateTime)
+			assert.Equal(tc.Location, actual.Location(), tc.DateTime)
+		}
+		check(ResolveEarlier, tc.Earlier)
+		check(ResolveLater, tc.Later)
+		check(ResolveShiftForward, tc.ShiftForward)
+		check(nil, tc.ShiftForward)
+
+		actual, err := dt.InLocation(tc.Location, ResolveStrict)
+		switch {
+		case tc.Gap:
+			assert.True(errors.Is(err, ErrSkippedTime), tc.DateTime)
+		case tc.Overlap:
+			assert.True(errors.Is(err, ErrAmbiguousTime), tc.DateTime)
+		default:
+			assert.NoError(err, tc.DateTime)
+			assert.Equal(tc.Earlier, actual.Format(time.RFC3339), tc.DateTime)
+		}
+	}
+}
+
+func TestInLocationCustomResolver(t *testing.T) {
+	sydney := mustLoadLocation(t, "Australia/Sydney")
+	var called bool
+	resolver := func(m ZoneMapping) (time.Time, error) {
+		called = true
+		return time.Date(2000, 1, 1, 0, 0, 0, 0, m.Location), nil
+	}
+
+	_, err := MustParseDateTime("2024-05-01T09:00:00").InLocation(sydney, resolver)
+	assert.NoError(t, err)
+	assert.False(t, called, "resolver should not be called for unique mapping")
+
+	actual, err := MustParseDateTime("2024-10-06T02:30:00").InLocation(sydney, resolver)
+	assert.NoError(t, err)
+	assert.True(t, called)
+	assert.Equal(t, 2000, actual.Year())
+}
+
+func TestLocalDateTimeOf(t *testing.T) {
+	assert := assert.New(t)
+	tm := time.Date(2024, 5, 1, 23, 30, 15, 999, time.FixedZone("", -10*3600))
+
+	assert.Equal("2024-05-01T23:30:15", LocalDateTimeOf(tm).String())
+	assert.Equal("2024-05-01", LocalDateOf(tm).String())
+	assert.Equal("2024-05-02T09:30:15", LocalDateTimeOf(tm.UTC()).String())
+	assert.Equal("2024-05-02", LocalDateOf(tm.UTC()).String())
+
+	// round trip through InLocation
+	sydney := mustLoadLocation(t, "Australia/Sydney")
+	dt := LocalDateTimeOf(tm.In(sydney))
+	tm2, err := dt.InLocation(sydney, ResolveStrict)
+	assert.NoError(err)
+	assert.True(tm.Truncate(time.Second).Equal(tm2))
+}
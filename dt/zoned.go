@@ -0,0 +1,392 @@
+package dt
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ZonedDateTime represents a local date-time in a particular timezone,
+// together with the UTC offset in effect at that date-time. Unlike
+// LocalDateTime, a ZonedDateTime identifies a unique instant in time.
+// Unlike time.Time, a ZonedDateTime keeps the timezone identifier when
+// it is serialized, so that it can be restored intact.
+//
+// The text representation of a ZonedDateTime is the RFC 9557 (IXDTF)
+// extended date-time format, for example
+// "2024-05-01T09:00:00+10:00[Australia/Brisbane]".
+//
+// Like LocalDateTime, ZonedDateTime only specifies the time to second
+// accuracy. The zero value of ZonedDateTime is midnight, January 1, year 1 UTC.
+type ZonedDateTime struct {
+	t time.Time
+}
+
+var (
+	errInvalidZonedDateTimeFormat = errors.New("invalid zoned date-time format")
+	errInvalidOffsetFormat        = errors.New("invalid UTC offset format")
+)
+
+var offsetRegexp = regexp.MustCompile(`^([-+])(\d{2})(?::?(\d{2})(?::?(\d{2}))?)?$`)
+
+// ZonedDateTimeOf returns the zoned date-time for the instant t in the
+// location of t. Any fraction of a second is discarded. The timezone
+// identifier is the name of the location, so t should be in a location
+// returned by time.LoadLocation, or in UTC.
+func ZonedDateTimeOf(t time.Time) ZonedDateTime {
+	return ZonedDateTime{t: t.Truncate(time.Second)}
+}
+
+// InZone returns the zoned date-time for the local date-time dt in the
+// location loc. See InLocation for details of how the resolver is used.
+func (dt LocalDateTime) InZone(loc *time.Location, resolver Resolver) (ZonedDateTime, error) {
+	t, err := dt.InLocation(loc, resolver)
+	if err != nil {
+		return ZonedDateTime{}, err
+	}
+	return ZonedDateTime{t: t}, nil
+}
+
+// Time returns the instant in time represented by z, in the location of z.
+func (z ZonedDateTime) Time() time.Time {
+	return z.t
+}
+
+// LocalDateTime returns the local date-time of z.
+func (z ZonedDateTime) LocalDateTime() LocalDateTime {
+	return toLocalDateTime(z.t)
+}
+
+// LocalDate returns the local date of z.
+func (z ZonedDateTime) LocalDate() LocalDate {
+	return toLocalDate(z.t)
+}
+
+// Location returns the timezone of z.
+func (z ZonedDateTime) Location() *time.Location {
+	return z.t.Location()
+}
+
+// Zone returns the identifier of the timezone of z, for example "Australia/Brisbane".
+func (z ZonedDateTime) Zone() string {
+	return zoneID(z.t)
+}
+
+// Offset returns the offset of z in seconds east of UTC.
+func (z ZonedDateTime) Offset() int {
+	_, offset := z.t.Zone()
+	return offset
+}
+
+// IsZero reports whether z represents the zero zoned date-time,
+// midnight, January 1, year 1 UTC.
+func (z ZonedDateTime) IsZero() bool {
+	return z.t.IsZero()
+}
+
+// After reports whether the instant z is after u.
+func (z ZonedDateTime) After(u ZonedDateTime) bool {
+	return z.t.After(u.t)
+}
+
+// Before reports whether the instant z is before u.
+func (z ZonedDateTime) Before(u ZonedDateTime) bool {
+	return z.t.Before(u.t)
+}
+
+// Equal reports whether z and u represent the same local date-time, offset
+// and timezone. To compare instants regardless of the timezone, compare
+// z.Time() and u.Time().
+func (z ZonedDateTime) Equal(u ZonedDateTime) bool {
+	return z.t.Equal(u.t) && z.Offset() == u.Offset() && z.Zone() == u.Zone()
+}
+
+// In returns the zoned date-time for the same instant as z in the location loc.
+func (z ZonedDateTime) In(loc *time.Location) ZonedDateTime {
+	return ZonedDateTime{t: z.t.In(loc)}
+}
+
+// Add returns the zoned date-time z + duration. The elapsed time is
+// preserved, so adding 24 hours across the start of daylight saving time
+// results in a local time one hour later than z.
+func (z ZonedDateTime) Add(duration time.Duration) ZonedDateTime {
+	return ZonedDateTime{t: z.t.Add(toSeconds(duration))}
+}
+
+// Sub returns the elapsed duration z-u.
+func (z ZonedDateTime) Sub(u ZonedDateTime) time.Duration {
+	return z.t.Sub(u.t)
+}
+
+// AddDate returns the zoned date-time corresponding to adding the given number
+// of years, months and days to the local date-time of z. The local time of day
+// is preserved, so adding one day across the start of daylight saving time
+// results in an elapsed time of 23 hours.
+//
+// If the resulting local date-time falls in a gap, it is shifted forward by
+// the length of the gap. If it falls in an overlap, the offset of z is used if
+// possible, otherwise the earlier instant is chosen.
+func (z ZonedDateTime) AddDate(years int, months int, days int) ZonedDateTime {
+	dt := z.LocalDateTime().AddDate(years, months, days)
+	return z.withLocalDateTime(dt)
+}
+
+// AddPeriod returns the zoned date-time corresponding to adding the period p
+// to z. It is equivalent to z.AddDate(p.Years(), p.Months(), p.Days()).
+func (z ZonedDateTime) AddPeriod(p Period) ZonedDateTime {
+	return z.AddDate(p.years, p.months, p.days)
+}
+
+// withLocalDateTime returns the zoned date-time for dt in the location of z,
+// preferring the offset of z if dt falls in an overlap.
+func (z ZonedDateTime) withLocalDateTime(dt LocalDateTime) ZonedDateTime {
+	m := dt.MapLocation(z.Location())
+	if m.IsOverlap() {
+		offset := z.Offset()
+		if _, o := m.Later().Zone(); o == offset {
+			return ZonedDateTime{t: m.Later()}
+		}
+	}
+	t, _ := ResolveShiftForward(m)
+	return ZonedDateTime{t: t}
+}
+
+// String returns a string representation of z in the RFC 9557
+// format, for example "2024-05-01T09:00:00+10:00[Australia/Brisbane]".
+func (z ZonedDateTime) String() string {
+	return zonedDateTimeString(z)
+}
+
+// zonedDateTimeString returns the string representation of the zoned date-time.
+func zonedDateTimeString(z ZonedDateTime) string {
+	return localDateTimeString(z.LocalDateTime()) + offsetString(z.Offset()) + "[" + z.Zone() + "]"
+}
+
+// zoneID returns the timezone identifier for the location of t. Locations
+// created using time.FixedZone without a name are identified by their offset.
+func zoneID(t time.Time) string {
+	name := t.Location().String()
+	if name == "" {
+		_, offset := t.Zone()
+		name = offsetString(offset)
+	}
+	return name
+}
+
+// offsetString returns the string representation of a UTC offset
+// in seconds in the format ±hh:mm. If the offset is not a whole number
+// of minutes, the format is ±hh:mm:ss.
+func offsetString(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	hours, minutes, seconds := offset/3600, offset/60%60, offset%60
+	if seconds != 0 {
+		return fmt.Sprintf("%c%02d:%02d:%02d", sign, hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, hours, minutes)
+}
+
+// parseOffset parses a UTC offset in one of the forms ±hh, ±hhmm, ±hh:mm,
+// ±hhmmss or ±hh:mm:ss, and returns the offset in seconds east of UTC.
+func parseOffset(s string) (int, error) {
+	match := offsetRegexp.FindStringSubmatch(s)
+	if match == nil {
+		return 0, errInvalidOffsetFormat
+	}
+	hours, _ := strconv.Atoi(match[2])
+	minutes, _ := strconv.Atoi(match[3])
+	seconds, _ := strconv.Atoi(match[4])
+	if hours > 23 || minutes > 59 || seconds > 59 {
+		return 0, errInvalidOffsetFormat
+	}
+	offset := hours*3600 + minutes*60 + seconds
+	if match[1] == "-" {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+// splitOffset splits a date-time string into the date-time and the UTC
+// offset that follows it, if any. The offset is either "Z" or starts with
+// a sign, and follows the time designator "T".
+func splitOffset(s string) (datetime string, offset string) {
+	t := strings.IndexByte(s, 'T')
+	if t < 0 {
+		return s, ""
+	}
+	i := strings.IndexAny(s[t:], "Zz+-")
+	if i < 0 {
+		return s, ""
+	}
+	return s[:t+i], s[t+i:]
+}
+
+// loadZone returns the location for a timezone identifier, which is either
+// an IANA timezone name or a UTC offset.
+func loadZone(name string) (*time.Location, error) {
+	if strings.HasPrefix(name, "+") || strings.HasPrefix(name, "-") {
+		offset, err := parseOffset(name)
+		if err != nil {
+			return nil, err
+		}
+		return time.FixedZone("", offset), nil
+	}
+	return time.LoadLocation(name)
+}
+
+// ParseZonedDateTime attempts to parse a string in the RFC 9557 format
+// into a zoned date-time. Leading and trailing space and quotation marks are
+// ignored. The string consists of a date-time in any of the formats accepted
+// by ParseDateTime, a UTC offset, and a timezone identifier in square brackets,
+// for example "2024-05-01T09:00:00+10:00[Australia/Brisbane]".
+//
+// The timezone identifier is an IANA timezone name or a UTC offset. It may be
+// followed by additional suffix tags such as "[u-ca=gregory]", which are
+// ignored unless they are marked critical with a "!", in which case an error
+// is returned.
+//
+// If the UTC offset is "Z", the date-time is interpreted as UTC and converted
+// to the timezone. If the UTC offset is omitted, the date-time is interpreted
+// as a local date-time in the timezone and resolved with ResolveShiftForward.
+// Otherwise the UTC offset must be valid for the date-time in the timezone.
+func ParseZonedDateTime(s string) (ZonedDateTime, error) {
+	s = strings.Trim(s, " \t\"'")
+	bracket := strings.IndexByte(s, '[')
+	if bracket < 0 {
+		return ZonedDateTime{}, errInvalidZonedDateTimeFormat
+	}
+
+	zone, err := parseSuffixTags(s[bracket:])
+	if err != nil {
+		return ZonedDateTime{}, err
+	}
+	loc, err := loadZone(zone)
+	if err != nil {
+		return ZonedDateTime{}, err
+	}
+
+	datetimeText, offsetText := splitOffset(s[:bracket])
+	dt, err := ParseDateTime(datetimeText)
+	if err != nil {
+		return ZonedDateTime{}, err
+	}
+
+	switch offsetText {
+	case "":
+		return dt.InZone(loc, ResolveShiftForward)
+	case "Z", "z":
+		return ZonedDateTime{t: time.Unix(dt.Unix(), 0).In(loc)}, nil
+	}
+
+	offset, err := parseOffset(offsetText)
+	if err != nil {
+		return ZonedDateTime{}, err
+	}
+	t := time.Unix(dt.Unix()-int64(offset), 0).In(loc)
+	if _, o := t.Zone(); o != offset {
+		return ZonedDateTime{}, fmt.Errorf("offset %s is not valid for %s in %s", offsetText, dt, zone)
+	}
+	return ZonedDateTime{t: t}, nil
+}
+
+// parseSuffixTags parses the RFC 9557 suffix tags in s and returns the timezone
+// identifier, which must be the first tag.
+func parseSuffixTags(s string) (zone string, err error) {
+	for i := 0; s != ""; i++ {
+		end := strings.IndexByte(s, ']')
+		if s[0] != '[' || end < 0 {
+			return "", errInvalidZonedDateTimeFormat
+		}
+		tag := s[1:end]
+		s = s[end+1:]
+
+		critical := strings.HasPrefix(tag, "!")
+		tag = strings.TrimPrefix(tag, "!")
+		if i == 0 && !strings.Contains(tag, "=") {
+			zone = tag
+			continue
+		}
+		if critical {
+			return "", fmt.Errorf("unsupported critical suffix tag [!%s]", tag)
+		}
+	}
+	if zone == "" {
+		return "", errInvalidZonedDateTimeFormat
+	}
+	return zone, nil
+}
+
+// MustParseZonedDateTime is similar to ParseZonedDateTime, but instead of returning
+// an error it will panic if s is not in the expected format.
+func MustParseZonedDateTime(s string) ZonedDateTime {
+	z, err := ParseZonedDateTime(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return z
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The zoned date-time is a quoted string in the RFC 9557 format.
+func (z ZonedDateTime) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + zonedDateTimeString(z) + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The zoned date-time is expected to be a quoted string in the RFC 9557 format.
+// By convention, a JSON null is a no-op.
+func (z *ZonedDateTime) UnmarshalJSON(data []byte) (err error) {
+	if isNullJSON(data) {
+		return nil
+	}
+	s := string(data)
+	*z, err = ParseZonedDateTime(s)
+	return
+}
+
+// MarshalText implements the encoding.TextMarshaller interface.
+// The zoned date-time is in the RFC 9557 format.
+func (z ZonedDateTime) MarshalText() ([]byte, error) {
+	return []byte(zonedDateTimeString(z)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaller interface.
+// The zoned date-time is expected to be in the RFC 9557 format.
+func (z *ZonedDateTime) UnmarshalText(data []byte) (err error) {
+	s := string(data)
+	*z, err = ParseZonedDateTime(s)
+	return
+}
+
+// Scan implements the sql.Scanner interface. The value is expected to be a
+// string or []byte in the RFC 9557 format, or a time.Time, in which case the
+// timezone is the location of the time.
+func (z *ZonedDateTime) Scan(src interface{}) (err error) {
+	switch v := src.(type) {
+	case time.Time:
+		*z = ZonedDateTimeOf(v)
+	case string:
+		*z, err = ParseZonedDateTime(v)
+	case []byte:
+		*z, err = ParseZonedDateTime(string(v))
+	case nil:
+		err = errors.New("cannot scan NULL into ZonedDateTime")
+	default:
+		err = fmt.Errorf("cannot scan %T into ZonedDateTime", src)
+	}
+	return
+}
+
+// Value implements the driver.Valuer interface. The zoned date-time is
+// passed to the database as a string in the RFC 9557 format, because
+// no commonly used database column type preserves the timezone identifier.
+func (z ZonedDateTime) Value() (driver.Value, error) {
+	return zonedDateTimeString(z), nil
+}
@@ -0,0 +1,227 @@
+package dt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseZonedDateTime(t *testing.T) {
+	testCases := []struct {
+		Text     string
+		Valid    bool
+		Expected string
+	}{
+		{
+			Text:     "2024-05-01T09:00:00+10:00[Australia/Brisbane]",
+			Valid:    true,
+			Expected: "2024-05-01T09:00:00+10:00[Australia/Brisbane]",
+		},
+		{
+			Text:     `"20240501T0900+1000[Australia/Brisbane]"`,
+			Valid:    true,
+			Expected: "2024-05-01T09:00:00+10:00[Australia/Brisbane]",
+		},
+		{
+			Text:     "2024-05-01T09:00:00.123+10[Australia/Brisbane]",
+			Valid:    true,
+			Expected: "2024-05-01T09:00:00+10:00[Australia/Brisbane]",
+		},
+		{
+			Text:     "2024-05-01T09:00:00[Australia/Brisbane]",
+			Valid:    true,
+			Expected: "2024-05-01T09:00:00+10:00[Australia/Brisbane]",
+		},
+		{
+			Text:     "2024-05-01T09:00:00Z[Australia/Brisbane]",
+			Valid:    true,
+			Expected: "2024-05-01T19:00:00+10:00[Australia/Brisbane]",
+		},
+		{
+			Text:     "2024-05-01T09:00:00+10:00[!Australia/Brisbane][u-ca=gregory]",
+			Valid:    true,
+			Expected: "2024-05-01T09:00:00+10:00[Australia/Brisbane]",
+		},
+		{
+			Text:     "2024-05-01T09:00:00-04:00[America/New_York]",
+			Valid:    true,
+			Expected: "2024-05-01T09:00:00-04:00[America/New_York]",
+		},
+		{
+			Text:     "2024-05-01T09:00:00+05:30[+05:30]",
+			Valid:    true,
+			Expected: "2024-05-01T09:00:00+05:30[+05:30]",
+		},
+		{
+			Text:     "2024-05-01T09:00:00+00:00[UTC]",
+			Valid:    true,
+			Expected: "2024-05-01T09:00:00+00:00[UTC]",
+		},
+		{
+			// second occurrence of 02:30 when clocks go back
+			Text:     "2024-04-07T02:30:00+10:00[Australia/Sydney]",
+			Valid:    true,
+			Expected: "2024-04-07T02:30:00+10:00[Australia/Sydney]",
+		},
+		{Text: "2024-05-01T09:00:00+11:00[Australia/Brisbane]", Valid: false},
+		{Text: "2024-05-01T09:00:00+10:00", Valid: false},
+		{Text: "2024-05-01T09:00:00+10:00[]", Valid: false},
+		{Text: "2024-05-01T09:00:00+10:00[Not/AZone]", Valid: false},
+		{Text: "2024-05-01T09:00:00+10:00[Australia/Brisbane][!u-ca=hebrew]", Valid: false},
+		{Text: "2024-05-01T09:00:00+10:00[Australia/Brisbane", Valid: false},
+		{Text: "2024-05-01+10:00[Australia/Brisbane]", Valid: false},
+		{Text: "2024-05-01T09:00:00+25:00[Australia/Brisbane]", Valid: false},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		z, err := ParseZonedDateTime(tc.Text)
+		if tc.Valid {
+			assert.NoError(err, tc.Text)
+			assert.Equal(tc.Expected, z.String(), tc.Text)
+
+			z2, err := ParseZonedDateTime(z.String())
+			assert.NoError(err, tc.Text)
+			assert.True(z.Equal(z2), tc.Text)
+		} else {
+			assert.Error(err, tc.Text)
+		}
+	}
+}
+
+func TestZonedDateTimeAccessors(t *testing.T) {
+	assert := assert.New(t)
+	z := MustParseZonedDateTime("2024-05-01T09:00:00+10:00[Australia/Brisbane]")
+
+	assert.Equal("2024-05-01T09:00:00", z.LocalDateTime().String())
+	assert.Equal("2024-05-01", z.LocalDate().String())
+	assert.Equal("Australia/Brisbane", z.Zone())
+	assert.Equal("Australia/Brisbane", z.Location().String())
+	assert.Equal(10*3600, z.Offset())
+	assert.True(z.Time().Equal(time.Date(2024, 4, 30, 23, 0, 0, 0, time.UTC)))
+
+	utc := z.In(time.UTC)
+	assert.Equal("2024-04-30T23:00:00+00:00[UTC]", utc.String())
+	assert.False(z.Equal(utc))
+	assert.True(z.Time().Equal(utc.Time()))
+
+	assert.True(ZonedDateTime{}.IsZero())
+	assert.Equal("0001-01-01T00:00:00+00:00[UTC]", ZonedDateTime{}.String())
+
+	local := MustParseDateTime("2024-05-01T09:00:00")
+	z2, err := local.InZone(z.Location(), ResolveStrict)
+	assert.NoError(err)
+	assert.True(z.Equal(z2))
+
+	z3 := ZonedDateTimeOf(time.Date(2024, 5, 1, 9, 0, 0, 999, z.Location()))
+	assert.True(z.Equal(z3))
+}
+
+func TestZonedDateTimeArithmetic(t *testing.T) {
+	testCases := []struct {
+		Start    string
+		Days     int
+		Duration time.Duration
+		AddDate  string
+		Add      string
+	}{
+		{
+			// clocks go forward on 2024-10-06
+			Start:    "2024-10-05T09:00:00+10:00[Australia/Sydney]",
+			Days:     1,
+			Duration: 24 * time.Hour,
+			AddDate:  "2024-10-06T09:00:00+11:00[Australia/Sydney]",
+			Add:      "2024-10-06T10:00:00+11:00[Australia/Sydney]",
+		},
+		{
+			// clocks go back on 2024-04-07
+			Start:    "2024-04-06T09:00:00+11:00[Australia/Sydney]",
+			Days:     1,
+			Duration: 24 * time.Hour,
+			AddDate:  "2024-04-07T09:00:00+10:00[Australia/Sydney]",
+			Add:      "2024-04-07T08:00:00+10:00[Australia/Sydney]",
+		},
+		{
+			// result in a gap is shifted forward
+			Start:    "2024-10-05T02:30:00+10:00[Australia/Sydney]",
+			Days:     1,
+			Duration: 24 * time.Hour,
+			AddDate:  "2024-10-06T03:30:00+11:00[Australia/Sydney]",
+			Add:      "2024-10-06T03:30:00+11:00[Australia/Sydney]",
+		},
+		{
+			// result in an overlap keeps the offset if possible
+			Start:    "2024-04-08T02:30:00+10:00[Australia/Sydney]",
+			Days:     -1,
+			Duration: -24 * time.Hour,
+			AddDate:  "2024-04-07T02:30:00+10:00[Australia/Sydney]",
+			Add:      "2024-04-07T02:30:00+10:00[Australia/Sydney]",
+		},
+		{
+			Start:    "2024-04-06T02:30:00+11:00[Australia/Sydney]",
+			Days:     1,
+			Duration: 25 * time.Hour,
+			AddDate:  "2024-04-07T02:30:00+11:00[Australia/Sydney]",
+			Add:      "2024-04-07T02:30:00+10:00[Australia/Sydney]",
+		},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range testCases {
+		start := MustParseZonedDateTime(tc.Start)
+		assert.Equal(tc.AddDate, start.AddDate(0, 0, tc.Days).String(), tc.Start)
+		assert.Equal(tc.AddDate, start.AddPeriod(NewPeriod(0, 0, tc.Days)).String(), tc.Start)
+		end := start.Add(tc.Duration)
+		assert.Equal(tc.Add, end.String(), tc.Start)
+		assert.Equal(tc.Duration, end.Sub(start), tc.Start)
+	}
+
+	a := MustParseZonedDateTime("2024-05-01T09:00:00+10:00[Australia/Brisbane]")
+	b := MustParseZonedDateTime("2024-05-01T09:00:00+10:00[Australia/Sydney]")
+	c := MustParseZonedDateTime("2024-05-01T09:00:01+10:00[Australia/Sydney]")
+	assert.False(a.Equal(b))
+	assert.False(a.Before(b))
+	assert.False(a.After(b))
+	assert.True(a.Before(c))
+	assert.True(c.After(a))
+}
+
+func TestZonedDateTimeMarshal(t *testing.T) {
+	assert := assert.New(t)
+	type testStruct struct {
+		Start ZonedDateTime `json:"start"`
+	}
+	st := testStruct{
+		Start: MustParseZonedDateTime("2024-05-01T09:00:00+10:00[Australia/Brisbane]"),
+	}
+
+	b, err := json.Marshal(&st)
+	assert.NoError(err)
+	assert.Equal(`{"start":"2024-05-01T09:00:00+10:00[Australia/Brisbane]"}`, string(b))
+
+	var st2 testStruct
+	assert.NoError(json.Unmarshal(b, &st2))
+	assert.True(st.Start.Equal(st2.Start))
+	assert.Equal("Australia/Brisbane", st2.Start.Zone())
+
+	v, err := st.Start.Value()
+	assert.NoError(err)
+	assert.Equal("2024-05-01T09:00:00+10:00[Australia/Brisbane]", v)
+
+	var z ZonedDateTime
+	assert.NoError(z.Scan(v))
+	assert.True(st.Start.Equal(z))
+	assert.NoError(z.Scan([]byte("2024-05-01T09:00:00+10:00[Australia/Brisbane]")))
+	assert.True(st.Start.Equal(z))
+	assert.NoError(z.Scan(st.Start.Time()))
+	assert.True(st.Start.Equal(z))
+	assert.Error(z.Scan(nil))
+	assert.Error(z.Scan(42))
+
+	assert.NoError(z.UnmarshalText([]byte("2024-05-01T09:00:00Z[UTC]")))
+	b, err = z.MarshalText()
+	assert.NoError(err)
+	assert.Equal("2024-05-01T09:00:00+00:00[UTC]", string(b))
+}